@@ -228,6 +228,8 @@ type TestsRunParams struct {
 			Dst string `arg:"" help:"Destination, one of: 'seed', 'generated', or collected corpus' directory."`
 		} `cmd:"" help:"Sync fuzz corpora."`
 	} `cmd:""`
+
+	Replay ReplayParams `cmd:"" help:"Replay recorded traffic and compare responses."`
 }
 
 func main() {
@@ -326,6 +328,12 @@ func main() {
 
 		err = fuzzCopyCorpus(src, dst, logger)
 
+	case "replay <dir>":
+		ctx, stop := ctxutil.SigTerm(context.Background())
+		defer stop()
+
+		err = replay(ctx, &cli.Replay, os.Stdout, logger)
+
 	default:
 		err = fmt.Errorf("unknown command: %s", cmd)
 	}
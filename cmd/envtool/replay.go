@@ -0,0 +1,849 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/FerretDB/wire/wireclient"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+)
+
+// ReplayParams represents `envtool replay` parameters.
+//
+//nolint:vet // for readability
+type ReplayParams struct {
+	Dir        string        `arg:"" help:"Directory with records created by --dev-records-dir." type:"existingdir"`
+	URI        string        `default:"mongodb://127.0.0.1:27017/" help:"Target URI."`
+	CompareURI string        `help:"Second target URI to compare responses with."`
+	Speed      float64       `default:"1" help:"Speed multiplier for recorded timing; 0 disables delays."`
+	Jobs       int           `default:"8" help:"Maximum number of connections replayed concurrently."`
+	Wait       time.Duration `default:"1m" help:"How long to wait for targets to become available."`
+	Report     string        `help:"Write JSON report to the given file." type:"path"`
+	Strict     bool          `help:"Fail if responses' statuses differ between targets."`
+}
+
+// replaySkipCommands contains commands that are not replayed.
+//
+// Recorded authentication conversations can't be replayed;
+// targets' credentials should be passed in URIs instead.
+var replaySkipCommands = map[string]struct{}{
+	"authenticate": {},
+	"logout":       {},
+	"saslContinue": {},
+	"saslStart":    {},
+}
+
+// replayRemoveFields contains request fields that are removed before replaying.
+var replayRemoveFields = []string{
+	"$clusterTime",
+	"speculativeAuthenticate",
+}
+
+// replayVolatileFields contains response fields that are ignored when responses are compared.
+var replayVolatileFields = []string{
+	"$clusterTime",
+	"connectionId",
+	"electionId",
+	"lastWrite",
+	"localTime",
+	"operationTime",
+	"topologyVersion",
+	"you",
+}
+
+// replayConn represents a single recorded client connection.
+type replayConn struct {
+	file    string
+	records []wire.Record
+	times   []time.Time // nil if arrival times were not recorded
+}
+
+// replayTarget represents a connection to a single replay target.
+type replayTarget struct {
+	conn    *wireclient.Conn
+	cursors map[string]int64 // namespace -> the last open cursor ID
+}
+
+// loadReplayConns loads all recorded connections from the given directory.
+func loadReplayConns(dir string) ([]replayConn, error) {
+	var res []replayConn
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		if filepath.Ext(entry.Name()) != ".bin" {
+			return nil
+		}
+
+		var records []wire.Record
+		if records, err = wire.LoadRecords(path, 0); err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		if len(records) == 0 {
+			return nil
+		}
+
+		var times []time.Time
+		if times, err = loadReplayTimes(strings.TrimSuffix(path, ".bin") + ".times"); err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		// records with invalid messages are truncated by the loader
+		if len(times) < len(records) {
+			times = nil
+		}
+
+		res = append(res, replayConn{
+			file:    path,
+			records: records,
+			times:   times,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// start connections in the recorded order
+	slices.SortStableFunc(res, func(a, b replayConn) int {
+		return a.start().Compare(b.start())
+	})
+
+	return res, nil
+}
+
+// loadReplayTimes loads requests' arrival times from the given file.
+// It returns nil if the file does not exist.
+func loadReplayTimes(file string) ([]time.Time, error) {
+	f, err := os.Open(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	defer f.Close() //nolint:errcheck // we are only reading it
+
+	var res []time.Time
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		var us int64
+		if us, err = strconv.ParseInt(s.Text(), 10, 64); err != nil {
+			return nil, lazyerrors.Errorf("%s: %w", file, err)
+		}
+
+		res = append(res, time.UnixMicro(us))
+	}
+
+	if err = s.Err(); err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return res, nil
+}
+
+// start returns the time the first request was received, or zero time if unknown.
+func (rc *replayConn) start() time.Time {
+	if len(rc.times) == 0 {
+		return time.Time{}
+	}
+
+	return rc.times[0]
+}
+
+// prepareReplayRequest returns the request to send to the target, or nil if it should be skipped.
+func prepareReplayRequest(body wire.MsgBody, cursors map[string]int64) (wire.MsgBody, string, error) {
+	msg, ok := body.(*wire.OpMsg)
+	if !ok {
+		// legacy OP_QUERY handshakes are replayed as-is
+		if query, ok := body.(*wire.OpQuery); ok {
+			doc, err := query.Query()
+			if err != nil {
+				return nil, "", lazyerrors.Error(err)
+			}
+
+			return body, doc.Command(), nil
+		}
+
+		return nil, "", lazyerrors.Errorf("unsupported body type %T", body)
+	}
+
+	doc, err := msg.DocumentDeep()
+	if err != nil {
+		return nil, "", lazyerrors.Error(err)
+	}
+
+	command := doc.Command()
+
+	if _, ok = replaySkipCommands[command]; ok {
+		return nil, command, nil
+	}
+
+	for _, f := range replayRemoveFields {
+		doc.Remove(f)
+	}
+
+	db, _ := doc.Get("$db").(string)
+
+	// cursor IDs are different for every target, so use the last one opened for the same collection
+	switch command {
+	case "getMore":
+		coll, _ := doc.Get("collection").(string)
+		if id, ok := cursors[db+"."+coll]; ok {
+			if err = doc.Replace("getMore", id); err != nil {
+				return nil, "", lazyerrors.Error(err)
+			}
+		}
+
+	case "killCursors":
+		coll, _ := doc.Get("killCursors").(string)
+		if id, ok := cursors[db+"."+coll]; ok {
+			if err = doc.Replace("cursors", wirebson.MustArray(id)); err != nil {
+				return nil, "", lazyerrors.Error(err)
+			}
+
+			delete(cursors, db+"."+coll)
+		}
+	}
+
+	res, err := wire.NewOpMsg(doc)
+	if err != nil {
+		return nil, "", lazyerrors.Error(err)
+	}
+
+	res.Flags = msg.Flags &^ wire.OpMsgFlags(wire.OpMsgChecksumPresent)
+
+	return res, command, nil
+}
+
+// replayResponseDocument returns the document of the given response.
+func replayResponseDocument(body wire.MsgBody) (*wirebson.Document, error) {
+	switch body := body.(type) {
+	case *wire.OpMsg:
+		return body.DocumentDeep()
+	case *wire.OpReply:
+		return body.DocumentDeep()
+	default:
+		return nil, lazyerrors.Errorf("unsupported body type %T", body)
+	}
+}
+
+// replayStatus returns "ok" for successful responses and error code name otherwise.
+func replayStatus(doc *wirebson.Document) string {
+	switch ok := doc.Get("ok").(type) {
+	case float64:
+		if ok == 1 {
+			return "ok"
+		}
+	case int32:
+		if ok == 1 {
+			return "ok"
+		}
+	}
+
+	if name, _ := doc.Get("codeName").(string); name != "" {
+		return name
+	}
+
+	return fmt.Sprint(doc.Get("code"))
+}
+
+// trackCursor updates open cursors with the given response.
+func (t *replayTarget) trackCursor(doc *wirebson.Document) {
+	cursor, _ := doc.Get("cursor").(*wirebson.Document)
+	if cursor == nil {
+		return
+	}
+
+	ns, _ := cursor.Get("ns").(string)
+	id, _ := cursor.Get("id").(int64)
+
+	if id == 0 {
+		delete(t.cursors, ns)
+		return
+	}
+
+	t.cursors[ns] = id
+}
+
+// replayNormalize returns a copy of the successful response suitable for comparison.
+func replayNormalize(doc *wirebson.Document) ([]byte, error) {
+	doc = doc.Copy()
+
+	for _, f := range replayVolatileFields {
+		doc.Remove(f)
+	}
+
+	// cursor IDs are always different; only check if the cursor is open
+	if cursor, _ := doc.Get("cursor").(*wirebson.Document); cursor != nil {
+		cursor = cursor.Copy()
+
+		id, _ := cursor.Get("id").(int64)
+		if err := cursor.Replace("id", id != 0); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		if err := doc.Replace("cursor", cursor); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	raw, err := doc.Encode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return raw, nil
+}
+
+// replayLatency represents latency statistics of a single target in the report.
+type replayLatency struct {
+	Errors int     `json:"errors"`
+	P50    float64 `json:"p50_ms"`
+	P95    float64 `json:"p95_ms"`
+	P99    float64 `json:"p99_ms"`
+	Max    float64 `json:"max_ms"`
+}
+
+// replayCommandReport represents statistics of a single command in the report.
+type replayCommandReport struct {
+	Command          string          `json:"command"`
+	Count            int             `json:"count"`
+	Targets          []replayLatency `json:"targets"`
+	StatusMismatches int             `json:"status_mismatches"`
+	BodyMismatches   int             `json:"body_mismatches"`
+}
+
+// replayReport represents the replay report.
+type replayReport struct {
+	Targets           []string              `json:"targets"`
+	Connections       int                   `json:"connections"`
+	FailedConnections int                   `json:"failed_connections"`
+	Requests          int                   `json:"requests"`
+	Skipped           int                   `json:"skipped"`
+	Commands          []replayCommandReport `json:"commands"`
+}
+
+// replayCommandStats accumulates statistics of a single command.
+type replayCommandStats struct {
+	latencies        [][]time.Duration // per target
+	errors           []int             // per target
+	statusMismatches int
+	bodyMismatches   int
+}
+
+// replayer replays recorded connections against one or two targets.
+type replayer struct {
+	params  *ReplayParams
+	uris    []string
+	start   time.Time // the first recorded request's arrival time
+	started time.Time // replay start time
+	l       *slog.Logger
+
+	m                 sync.Mutex
+	commands          map[string]*replayCommandStats
+	requests          int
+	skipped           int
+	failedConnections int
+}
+
+// replay implements `envtool replay` command.
+func replay(ctx context.Context, params *ReplayParams, w io.Writer, logger *slog.Logger) error {
+	if params.Speed < 0 {
+		return fmt.Errorf("invalid speed %v", params.Speed)
+	}
+
+	if params.Jobs <= 0 {
+		params.Jobs = 1
+	}
+
+	conns, err := loadReplayConns(params.Dir)
+	if err != nil {
+		return err
+	}
+
+	if len(conns) == 0 {
+		return fmt.Errorf("no records found in %s", params.Dir)
+	}
+
+	uris := []string{params.URI}
+	if params.CompareURI != "" {
+		uris = append(uris, params.CompareURI)
+	}
+
+	r := &replayer{
+		params:   params,
+		uris:     uris,
+		l:        logger,
+		commands: map[string]*replayCommandStats{},
+	}
+
+	for _, uri := range uris {
+		if err = r.waitTarget(ctx, uri); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range conns {
+		if s := c.start(); !s.IsZero() && (r.start.IsZero() || s.Before(r.start)) {
+			r.start = s
+		}
+	}
+
+	logger.InfoContext(
+		ctx, fmt.Sprintf("Replaying %d connections", len(conns)),
+		slog.Any("targets", r.targets()), slog.Float64("speed", params.Speed),
+	)
+
+	r.started = time.Now()
+
+	var wg sync.WaitGroup
+	jobs := make(chan struct{}, params.Jobs)
+
+	for _, c := range conns {
+		jobs <- struct{}{}
+
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				<-jobs
+				wg.Done()
+			}()
+
+			if err := r.replayConn(ctx, &c); err != nil {
+				logger.WarnContext(ctx, "Connection replay failed", slog.String("file", c.file), logging.Error(err))
+
+				r.m.Lock()
+				r.failedConnections++
+				r.m.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+
+	report := r.report(len(conns))
+
+	if err = writeReplayReport(w, report); err != nil {
+		return err
+	}
+
+	if params.Report != "" {
+		var b []byte
+		if b, err = json.MarshalIndent(report, "", "  "); err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		if err = os.WriteFile(params.Report, append(b, '\n'), 0o666); err != nil {
+			return lazyerrors.Error(err)
+		}
+	}
+
+	if params.Strict {
+		var mismatches int
+		for _, c := range report.Commands {
+			mismatches += c.StatusMismatches
+		}
+
+		if mismatches > 0 {
+			return fmt.Errorf("%d responses' statuses differ", mismatches)
+		}
+	}
+
+	return nil
+}
+
+// targets returns targets' URIs without credentials.
+func (r *replayer) targets() []string {
+	res := make([]string, len(r.uris))
+
+	for i, uri := range r.uris {
+		res[i] = uri
+
+		if clean, _, _, _, err := wireclient.Credentials(uri); err == nil {
+			res[i] = clean
+		}
+	}
+
+	return res
+}
+
+// waitTarget waits for the given target to become available.
+func (r *replayer) waitTarget(ctx context.Context, uri string) error {
+	clean, _, _, _, err := wireclient.Credentials(uri)
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.params.Wait)
+	defer cancel()
+
+	conn := wireclient.ConnectPing(ctx, clean, r.l)
+	if conn == nil {
+		return fmt.Errorf("target %s is not available", clean)
+	}
+
+	return conn.Close()
+}
+
+// connect connects to the given target and authenticates if credentials are present.
+func (r *replayer) connect(ctx context.Context, uri string) (*wireclient.Conn, error) {
+	clean, userinfo, authSource, authMechanism, err := wireclient.Credentials(uri)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	conn, err := wireclient.Connect(ctx, clean, r.l)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if userinfo == nil {
+		return conn, nil
+	}
+
+	if authSource == "" {
+		authSource = "admin"
+	}
+
+	if err = conn.Login(ctx, userinfo, authSource, authMechanism); err != nil {
+		_ = conn.Close()
+		return nil, lazyerrors.Error(err)
+	}
+
+	return conn, nil
+}
+
+// sleepUntil waits until the request with the given arrival time should be sent.
+func (r *replayer) sleepUntil(ctx context.Context, t time.Time) {
+	if r.params.Speed == 0 || t.IsZero() || r.start.IsZero() {
+		return
+	}
+
+	d := time.Duration(float64(t.Sub(r.start))/r.params.Speed) - time.Since(r.started)
+	if d <= 0 {
+		return
+	}
+
+	sleepCtx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+
+	<-sleepCtx.Done()
+}
+
+// replayConn replays a single recorded connection.
+func (r *replayer) replayConn(ctx context.Context, c *replayConn) error {
+	targets := make([]*replayTarget, len(r.uris))
+
+	for i, uri := range r.uris {
+		conn, err := r.connect(ctx, uri)
+		if err != nil {
+			return err
+		}
+
+		defer conn.Close() //nolint:errcheck // nothing to do
+
+		targets[i] = &replayTarget{
+			conn:    conn,
+			cursors: map[string]int64{},
+		}
+	}
+
+	for i, rec := range c.records {
+		if rec.Body == nil {
+			continue
+		}
+
+		if c.times != nil {
+			r.sleepUntil(ctx, c.times[i])
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := r.replayRequest(ctx, targets, rec.Body); err != nil {
+			return lazyerrors.Errorf("request %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// replayRequest sends a single request to all targets and records the results.
+func (r *replayer) replayRequest(ctx context.Context, targets []*replayTarget, body wire.MsgBody) error {
+	var command string
+
+	latencies := make([]time.Duration, len(targets))
+	docs := make([]*wirebson.Document, len(targets))
+
+	for i, t := range targets {
+		req, cmd, err := prepareReplayRequest(body, t.cursors)
+		if err != nil {
+			return err
+		}
+
+		command = cmd
+
+		if req == nil {
+			r.m.Lock()
+			r.skipped++
+			r.m.Unlock()
+
+			return nil
+		}
+
+		// no response is expected
+		if msg, ok := req.(*wire.OpMsg); ok && msg.Flags.FlagSet(wire.OpMsgMoreToCome) {
+			var b []byte
+			if b, err = req.MarshalBinary(); err != nil {
+				return lazyerrors.Error(err)
+			}
+
+			header := &wire.MsgHeader{
+				MessageLength: int32(len(b) + wire.MsgHeaderLen),
+				OpCode:        wire.OpCodeMsg,
+			}
+
+			if err = t.conn.Write(ctx, header, req); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		start := time.Now()
+
+		_, resBody, err := t.conn.Request(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		latencies[i] = time.Since(start)
+
+		if docs[i], err = replayResponseDocument(resBody); err != nil {
+			return err
+		}
+
+		t.trackCursor(docs[i])
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.requests++
+
+	if docs[0] == nil {
+		return nil
+	}
+
+	stats := r.commands[command]
+	if stats == nil {
+		stats = &replayCommandStats{
+			latencies: make([][]time.Duration, len(targets)),
+			errors:    make([]int, len(targets)),
+		}
+		r.commands[command] = stats
+	}
+
+	statuses := make([]string, len(targets))
+
+	for i, doc := range docs {
+		stats.latencies[i] = append(stats.latencies[i], latencies[i])
+
+		if statuses[i] = replayStatus(doc); statuses[i] != "ok" {
+			stats.errors[i]++
+		}
+	}
+
+	if len(targets) < 2 {
+		return nil
+	}
+
+	if statuses[0] != statuses[1] {
+		stats.statusMismatches++
+
+		r.l.DebugContext(
+			ctx, fmt.Sprintf("Response status mismatch for %s", command),
+			slog.String("target", statuses[0]), slog.String("compare", statuses[1]),
+		)
+
+		return nil
+	}
+
+	if statuses[0] != "ok" {
+		return nil
+	}
+
+	b0, err := replayNormalize(docs[0])
+	if err != nil {
+		return err
+	}
+
+	b1, err := replayNormalize(docs[1])
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(b0, b1) {
+		stats.bodyMismatches++
+
+		r.l.DebugContext(
+			ctx, fmt.Sprintf("Response body mismatch for %s", command),
+			slog.String("target", docs[0].LogMessage()), slog.String("compare", docs[1].LogMessage()),
+		)
+	}
+
+	return nil
+}
+
+// report returns the replay report.
+func (r *replayer) report(connections int) *replayReport {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	res := &replayReport{
+		Targets:           r.targets(),
+		Connections:       connections,
+		FailedConnections: r.failedConnections,
+		Requests:          r.requests,
+		Skipped:           r.skipped,
+		Commands:          []replayCommandReport{},
+	}
+
+	for command, stats := range r.commands {
+		c := replayCommandReport{
+			Command:          command,
+			Count:            len(stats.latencies[0]),
+			Targets:          make([]replayLatency, len(stats.latencies)),
+			StatusMismatches: stats.statusMismatches,
+			BodyMismatches:   stats.bodyMismatches,
+		}
+
+		for i, l := range stats.latencies {
+			c.Targets[i] = replayLatency{
+				Errors: stats.errors[i],
+				P50:    replayPercentile(l, 50),
+				P95:    replayPercentile(l, 95),
+				P99:    replayPercentile(l, 99),
+				Max:    replayPercentile(l, 100),
+			}
+		}
+
+		res.Commands = append(res.Commands, c)
+	}
+
+	slices.SortFunc(res.Commands, func(a, b replayCommandReport) int {
+		return strings.Compare(a.Command, b.Command)
+	})
+
+	return res
+}
+
+// replayPercentile returns the given percentile of latencies in milliseconds.
+func replayPercentile(latencies []time.Duration, p int) float64 {
+	if len(latencies) == 0 {
+		return 0
+	}
+
+	l := slices.Clone(latencies)
+	slices.Sort(l)
+
+	i := (len(l)*p+99)/100 - 1
+	if i < 0 {
+		i = 0
+	}
+
+	return float64(l[i].Microseconds()) / 1000
+}
+
+// writeReplayReport writes human-readable report to w.
+func writeReplayReport(w io.Writer, report *replayReport) error {
+	fmt.Fprintf(
+		w, "Connections: %d (failed: %d), requests: %d (skipped: %d)\n\n",
+		report.Connections, report.FailedConnections, report.Requests, report.Skipped,
+	)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	header := "COMMAND\tCOUNT"
+	for i := range report.Targets {
+		header += fmt.Sprintf("\tERRORS#%[1]d\tP50#%[1]d\tP95#%[1]d\tP99#%[1]d\tMAX#%[1]d", i+1)
+	}
+
+	if len(report.Targets) > 1 {
+		header += "\tSTATUS DIFF\tBODY DIFF"
+	}
+
+	fmt.Fprintln(tw, header)
+
+	for _, c := range report.Commands {
+		row := fmt.Sprintf("%s\t%d", c.Command, c.Count)
+		for _, t := range c.Targets {
+			row += fmt.Sprintf("\t%d\t%.3fms\t%.3fms\t%.3fms\t%.3fms", t.Errors, t.P50, t.P95, t.P99, t.Max)
+		}
+
+		if len(report.Targets) > 1 {
+			row += fmt.Sprintf("\t%d\t%d", c.StatusMismatches, c.BodyMismatches)
+		}
+
+		fmt.Fprintln(tw, row)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	fmt.Fprintln(w)
+
+	for i, t := range report.Targets {
+		fmt.Fprintf(w, "#%d: %s\n", i+1, t)
+	}
+
+	return nil
+}
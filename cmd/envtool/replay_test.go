@@ -0,0 +1,250 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/FerretDB/wire"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/testutil"
+)
+
+// writeReplayRecord writes a recorded connection with the given requests and arrival times to dir.
+func writeReplayRecord(t *testing.T, dir string, times string, msgs ...*wire.OpMsg) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+
+	for i, msg := range msgs {
+		b, err := msg.MarshalBinary()
+		require.NoError(t, err)
+
+		header := &wire.MsgHeader{
+			MessageLength: int32(len(b) + wire.MsgHeaderLen),
+			RequestID:     int32(i + 1),
+			OpCode:        wire.OpCodeMsg,
+		}
+		require.NoError(t, wire.WriteMessage(w, header, msg))
+	}
+
+	require.NoError(t, w.Flush())
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "ab"), 0o777))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ab", "abc.bin"), buf.Bytes(), 0o666))
+
+	if times != "" {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "ab", "abc.times"), []byte(times), 0o666))
+	}
+}
+
+// replayServer starts a fake server that returns a cursor for find and ok for other commands.
+func replayServer(t *testing.T) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = l.Close() })
+
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer c.Close() //nolint:errcheck // test server
+
+				r := bufio.NewReader(c)
+				w := bufio.NewWriter(c)
+
+				for {
+					header, body, err := wire.ReadMessage(r)
+					if err != nil {
+						return
+					}
+
+					doc, err := body.(*wire.OpMsg).Document()
+					if err != nil {
+						return
+					}
+
+					var res *wire.OpMsg
+
+					switch doc.Command() {
+					case "find":
+						res = wire.MustOpMsg(
+							"cursor", wirebson.MustDocument("id", int64(42), "ns", "db.coll"),
+							"ok", float64(1),
+						)
+					case "getMore":
+						res = wire.MustOpMsg("ok", float64(0), "code", int32(43), "codeName", "CursorNotFound")
+						if doc.Get("getMore") == int64(42) {
+							res = wire.MustOpMsg("ok", float64(1))
+						}
+					default:
+						res = wire.MustOpMsg("ok", float64(1))
+					}
+
+					b, err := res.MarshalBinary()
+					if err != nil {
+						return
+					}
+
+					resHeader := &wire.MsgHeader{
+						MessageLength: int32(len(b) + wire.MsgHeaderLen),
+						ResponseTo:    header.RequestID,
+						OpCode:        wire.OpCodeMsg,
+					}
+
+					if err = wire.WriteMessage(w, resHeader, res); err != nil {
+						return
+					}
+
+					if err = w.Flush(); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	return "mongodb://" + l.Addr().String() + "/"
+}
+
+func TestPrepareReplayRequest(t *testing.T) {
+	t.Parallel()
+
+	cursors := map[string]int64{"db.coll": 42}
+
+	req, command, err := prepareReplayRequest(wire.MustOpMsg("saslStart", int32(1), "$db", "admin"), cursors)
+	require.NoError(t, err)
+	assert.Equal(t, "saslStart", command)
+	assert.Nil(t, req)
+
+	req, command, err = prepareReplayRequest(wire.MustOpMsg(
+		"getMore", int64(1),
+		"collection", "coll",
+		"$db", "db",
+		"$clusterTime", wirebson.MustDocument(),
+	), cursors)
+	require.NoError(t, err)
+	assert.Equal(t, "getMore", command)
+
+	doc, err := req.(*wire.OpMsg).Document()
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), doc.Get("getMore"))
+	assert.Nil(t, doc.Get("$clusterTime"))
+
+	_, _, err = prepareReplayRequest(wire.MustOpMsg(
+		"killCursors", "coll",
+		"cursors", wirebson.MustArray(int64(1)),
+		"$db", "db",
+	), cursors)
+	require.NoError(t, err)
+	assert.Empty(t, cursors)
+}
+
+func TestReplayPercentile(t *testing.T) {
+	t.Parallel()
+
+	var l []time.Duration
+	for i := range 100 {
+		l = append(l, time.Duration(100-i)*time.Millisecond)
+	}
+
+	assert.Equal(t, float64(0), replayPercentile(nil, 50))
+	assert.Equal(t, float64(50), replayPercentile(l, 50))
+	assert.Equal(t, float64(95), replayPercentile(l, 95))
+	assert.Equal(t, float64(100), replayPercentile(l, 100))
+}
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	writeReplayRecord(
+		t, dir, "1000000\n1001000\n1002000\n1003000\n",
+		wire.MustOpMsg("saslStart", int32(1), "$db", "admin"),
+		wire.MustOpMsg("find", "coll", "$db", "db"),
+		wire.MustOpMsg("getMore", int64(1), "collection", "coll", "$db", "db"),
+		wire.MustOpMsg("ping", int32(1), "$db", "admin"),
+	)
+
+	uri := replayServer(t)
+
+	params := &ReplayParams{
+		Dir:        dir,
+		URI:        uri,
+		CompareURI: uri,
+		Speed:      1,
+		Jobs:       1,
+		Wait:       10 * time.Second,
+		Report:     filepath.Join(dir, "report.json"),
+		Strict:     true,
+	}
+
+	var buf bytes.Buffer
+	err := replay(context.Background(), params, &buf, testutil.Logger(t))
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "Connections: 1 (failed: 0), requests: 3 (skipped: 1)")
+	assert.Contains(t, out, "getMore")
+
+	b, err := os.ReadFile(params.Report)
+	require.NoError(t, err)
+
+	var report replayReport
+	require.NoError(t, json.Unmarshal(b, &report))
+
+	require.Len(t, report.Commands, 3)
+	assert.Equal(t, "getMore", report.Commands[1].Command)
+	assert.Equal(t, 0, report.Commands[1].Targets[0].Errors, "cursor ID should be replaced")
+}
+
+func TestLoadReplayConns(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	writeReplayRecord(
+		t, dir, "1000000\n1500000\n",
+		wire.MustOpMsg("ping", int32(1), "$db", "admin"),
+		wire.MustOpMsg("ping", int32(1), "$db", "admin"),
+	)
+
+	conns, err := loadReplayConns(dir)
+	require.NoError(t, err)
+	require.Len(t, conns, 1)
+
+	assert.Len(t, conns[0].records, 2)
+	assert.Equal(t, []time.Time{time.UnixMicro(1_000_000), time.UnixMicro(1_500_000)}, conns[0].times)
+}
@@ -107,7 +107,7 @@ func setupUser(ctx context.Context, uri string, l *slog.Logger) error {
 	var res wirebson.RawDocument
 
 	for ctx.Err() == nil {
-		err = pool.WithConn(func(conn *pgx.Conn) error {
+		err = pool.WithConn(ctx, func(conn *pgx.Conn) error {
 			res, err = documentdb.CreateUser(ctx, conn, l, createUser)
 			return err
 		})
@@ -67,21 +67,30 @@
 	PostgreSQLURLFile []byte `name:"postgresql-url-file" help:"Path to a file containing the PostgreSQL connection URL. If non-empty, this overrides --postgresql-url." group:"PostgreSQL"     type:"filecontent"`
 
 	Listen struct {
-		Addr        string `default:"127.0.0.1:27017" help:"Listen TCP address for MongoDB protocol."`
-		Unix        string `default:""                help:"Listen Unix domain socket path for MongoDB protocol."`
-		TLS         string `default:""                help:"Listen TLS address for MongoDB protocol."`
-		TLSCertFile string `default:""                help:"TLS cert file path."`
-		TLSKeyFile  string `default:""                help:"TLS key file path."`
-		TLSCaFile   string `default:""                help:"TLS CA file path."`
-		DataAPIAddr string `default:""                help:"Listen TCP address for HTTP Data API."`
-		MCPAddr     string `default:""                help:"Listen TCP address for HTTP MCP server."`
+		Addr           string `default:"127.0.0.1:27017" help:"Listen TCP address for MongoDB protocol."`
+		Unix           string `default:""                help:"Listen Unix domain socket path for MongoDB protocol."`
+		TLS            string `default:""                help:"Listen TLS address for MongoDB protocol."`
+		TLSCertFile    string `default:""                help:"TLS cert file path."`
+		TLSKeyFile     string `default:""                help:"TLS key file path."`
+		TLSCaFile      string `default:""                help:"TLS CA file path."`
+		MaxConnections int    `default:"0"               help:"Maximum number of client connections (0 means no limit)."`
+		DataAPIAddr    string `default:""                help:"Listen TCP address for HTTP Data API."`
+		MCPAddr        string `default:""                help:"Listen TCP address for HTTP MCP server."`
 	} `embed:"" prefix:"listen-" group:"Interfaces"`
 
 	Proxy struct {
-		Addr        string `default:"" help:"Proxy address."`
-		TLSCertFile string `default:"" help:"Proxy TLS cert file path."`
-		TLSKeyFile  string `default:"" help:"Proxy TLS key file path."`
-		TLSCaFile   string `default:"" help:"Proxy TLS CA file path."`
+		Addr            string        `default:""    help:"Proxy address."`
+		TLSCertFile     string        `default:""    help:"Proxy TLS cert file path."`
+		TLSKeyFile      string        `default:""    help:"Proxy TLS key file path."`
+		TLSCaFile       string        `default:""    help:"Proxy TLS CA file path."`
+		PoolSize        int           `default:"0"   help:"Maximum number of pooled proxy connections (0 disables pooling)."`
+		PoolWaitTimeout time.Duration `default:"30s" help:"Maximum time to wait for a pooled proxy connection (0 waits forever)."`
+
+		Username      string `default:""              help:"Proxy upstream username."`
+		Password      string `default:""              help:"Proxy upstream password."`
+		AuthDB        string `default:"admin"         help:"Proxy upstream authentication database."`
+		AuthMechanism string `default:"SCRAM-SHA-256" help:"Proxy upstream authentication mechanism." enum:"SCRAM-SHA-256,SCRAM-SHA-1"`
+		UserMapFile   string `default:""              help:"Path to a JSON file mapping FerretDB users to proxy upstream users."`
 	} `embed:"" prefix:"proxy-" group:"Interfaces"`
 
 	DebugAddr string `default:"127.0.0.1:8088" help:"Listen address for HTTP handlers for metrics, pprof, etc." group:"Interfaces"`
@@ -89,6 +98,26 @@
 	Mode     string `default:"${default_mode}" help:"${help_mode}"                           enum:"${enum_mode}"   group:"Miscellaneous"`
 	StateDir string `default:"."               help:"Process state directory."               group:"Miscellaneous"`
 	Auth     bool   `default:"true"            help:"Enable authentication (on by default)." group:"Miscellaneous" negatable:""`
+	ReadOnly bool   `default:"false"           help:"Reject all commands that modify data."   group:"Miscellaneous"`
+
+	DefaultMaxTime  time.Duration `default:"0s" help:"Time limit for commands without maxTimeMS (0 means no limit)." group:"Miscellaneous"`
+	SlowOpThreshold time.Duration `default:"0s" help:"Log commands slower than that (0 disables)."                   group:"Miscellaneous"`
+
+	Admission struct {
+		MaxRequests     int           `default:"0"   help:"Maximum number of concurrently handled requests (0 means PostgreSQL pool size)."`
+		MaxUserRequests int           `default:"0"   help:"Maximum number of concurrently handled requests per user (0 means no limit)."`
+		MaxWait         time.Duration `default:"10s" help:"Maximum time requests wait in the admission queue (0 means no limit)."`
+	} `embed:"" prefix:"admission-" group:"Miscellaneous"`
+
+	Routing struct {
+		File           string        `default:""    help:"Path to a JSON file with routing rules for 'routing' mode."`
+		ReloadInterval time.Duration `default:"10s" help:"Interval for checking routing rules file for changes (0 disables reloading)."`
+	} `embed:"" prefix:"routing-" group:"Miscellaneous"`
+
+	TTLMonitor struct {
+		Interval  time.Duration `default:"60s"   help:"Interval between TTL monitor passes (negative value disables the monitor)."`
+		BatchSize int           `default:"10000" help:"Maximum number of expired documents deleted from a collection at once."`
+	} `embed:"" prefix:"ttl-monitor-" group:"Miscellaneous"`
 
 	Log struct {
 		Level  string `default:"${default_log_level}" help:"${help_log_level}"`
@@ -481,12 +510,28 @@ func run() {
 		PostgreSQLURL:          cli.PostgreSQLURL,
 		Auth:                   cli.Auth,
 		ReplSetName:            cli.Dev.ReplSetName,
+		ReadOnly:               cli.ReadOnly,
 		SessionCleanupInterval: 0,
+		TTLMonitorInterval:     cli.TTLMonitor.Interval,
+		TTLMonitorBatchSize:    cli.TTLMonitor.BatchSize,
+		DefaultMaxTime:         cli.DefaultMaxTime,
+		SlowOpThreshold:        cli.SlowOpThreshold,
+		MaxRequests:            cli.Admission.MaxRequests,
+		MaxUserRequests:        cli.Admission.MaxUserRequests,
+		AdmissionMaxWait:       cli.Admission.MaxWait,
 
 		ProxyAddr:        cli.Proxy.Addr,
 		ProxyTLSCertFile: cli.Proxy.TLSCertFile,
 		ProxyTLSKeyFile:  cli.Proxy.TLSKeyFile,
 		ProxyTLSCAFile:   cli.Proxy.TLSCaFile,
+		ProxyPoolSize:    cli.Proxy.PoolSize,
+		ProxyPoolWait:    cli.Proxy.PoolWaitTimeout,
+
+		ProxyUsername:      cli.Proxy.Username,
+		ProxyPassword:      cli.Proxy.Password,
+		ProxyAuthDB:        cli.Proxy.AuthDB,
+		ProxyAuthMechanism: cli.Proxy.AuthMechanism,
+		ProxyUserMapFile:   cli.Proxy.UserMapFile,
 
 		TCPAddr:        cli.Listen.Addr,
 		UnixAddr:       cli.Listen.Unix,
@@ -494,9 +539,13 @@ func run() {
 		TLSCertFile:    cli.Listen.TLSCertFile,
 		TLSKeyFile:     cli.Listen.TLSKeyFile,
 		TLSCAFile:      cli.Listen.TLSCaFile,
+		MaxConnections: cli.Listen.MaxConnections,
 		Mode:           middleware.Mode(cli.Mode),
 		TestRecordsDir: cli.Dev.RecordsDir,
 
+		RoutingFile:           cli.Routing.File,
+		RoutingReloadInterval: cli.Routing.ReloadInterval,
+
 		DataAPIAddr: cli.Listen.DataAPIAddr,
 
 		MCPAddr: cli.Listen.MCPAddr,
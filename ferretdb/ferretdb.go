@@ -131,12 +131,28 @@ func New(config *Config) (*FerretDB, error) {
 		PostgreSQLURL:          config.PostgreSQLURL,
 		Auth:                   false,
 		ReplSetName:            "",
+		ReadOnly:               false,
 		SessionCleanupInterval: 0,
+		TTLMonitorInterval:     0,
+		TTLMonitorBatchSize:    0,
+		DefaultMaxTime:         0,
+		SlowOpThreshold:        0,
+		MaxRequests:            0,
+		MaxUserRequests:        0,
+		AdmissionMaxWait:       0,
 
 		ProxyAddr:        "",
 		ProxyTLSCertFile: "",
 		ProxyTLSKeyFile:  "",
 		ProxyTLSCAFile:   "",
+		ProxyPoolSize:    0,
+		ProxyPoolWait:    0,
+
+		ProxyUsername:      "",
+		ProxyPassword:      "",
+		ProxyAuthDB:        "",
+		ProxyAuthMechanism: "",
+		ProxyUserMapFile:   "",
 
 		TCPAddr:        config.ListenAddr,
 		UnixAddr:       "",
@@ -144,9 +160,13 @@ func New(config *Config) (*FerretDB, error) {
 		TLSCertFile:    "",
 		TLSKeyFile:     "",
 		TLSCAFile:      "",
+		MaxConnections: 0,
 		Mode:           middleware.NormalMode,
 		TestRecordsDir: "",
 
+		RoutingFile:           "",
+		RoutingReloadInterval: 0,
+
 		DataAPIAddr: "",
 
 		MCPAddr: "",
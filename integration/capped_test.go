@@ -0,0 +1,330 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/FerretDB/FerretDB/v2/integration/setup"
+)
+
+func TestCapped(t *testing.T) {
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t)
+	db := collection.Database()
+
+	opts := options.CreateCollection().SetCapped(true).SetSizeInBytes(1000).SetMaxDocuments(3)
+	require.NoError(t, db.CreateCollection(ctx, collection.Name(), opts))
+
+	for i := range 5 {
+		_, err := collection.InsertOne(ctx, bson.D{{"_id", int32(i)}})
+		require.NoError(t, err)
+	}
+
+	t.Run("Evicted", func(t *testing.T) {
+		cursor, err := collection.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{"_id", 1}}))
+		require.NoError(t, err)
+
+		expected := []bson.D{{{"_id", int32(2)}}, {{"_id", int32(3)}}, {{"_id", int32(4)}}}
+		AssertEqualDocumentsSlice(t, expected, FetchAll(t, ctx, cursor))
+	})
+
+	t.Run("ListCollections", func(t *testing.T) {
+		cursor, err := db.ListCollections(ctx, bson.D{{"name", collection.Name()}})
+		require.NoError(t, err)
+
+		res := FetchAll(t, ctx, cursor)
+		require.Len(t, res, 1)
+
+		opts, ok := res[0].Map()["options"].(bson.D)
+		require.True(t, ok)
+
+		m := opts.Map()
+		assert.Equal(t, true, m["capped"])
+		assert.EqualValues(t, 4096, m["size"])
+		assert.EqualValues(t, 3, m["max"])
+	})
+
+	t.Run("ListCollectionsGetMore", func(t *testing.T) {
+		other := collection.Name() + "_other"
+		require.NoError(t, db.CreateCollection(ctx, other, opts))
+
+		filter := bson.D{{"name", bson.D{{"$in", bson.A{collection.Name(), other}}}}}
+		cursor, err := db.ListCollections(ctx, filter, options.ListCollections().SetBatchSize(1))
+		require.NoError(t, err)
+
+		res := FetchAll(t, ctx, cursor)
+		require.Len(t, res, 2)
+
+		for _, c := range res {
+			opts, ok := c.Map()["options"].(bson.D)
+			require.True(t, ok, "%v", c)
+			assert.Equal(t, true, opts.Map()["capped"], "%v", c)
+		}
+	})
+
+	t.Run("SameOptions", func(t *testing.T) {
+		err := db.CreateCollection(ctx, collection.Name(), opts)
+		require.NoError(t, err)
+	})
+}
+
+func TestCappedWrites(t *testing.T) {
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t)
+	db := collection.Database()
+
+	find := func(t *testing.T, coll *mongo.Collection) []bson.D {
+		t.Helper()
+
+		cursor, err := coll.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{"_id", 1}}).SetProjection(bson.D{{"v", 0}}))
+		require.NoError(t, err)
+
+		return FetchAll(t, ctx, cursor)
+	}
+
+	t.Run("Delete", func(t *testing.T) {
+		coll := db.Collection(collection.Name() + "_delete")
+		opts := options.CreateCollection().SetCapped(true).SetSizeInBytes(4096).SetMaxDocuments(3)
+		require.NoError(t, db.CreateCollection(ctx, coll.Name(), opts))
+
+		_, err := coll.InsertMany(ctx, []any{bson.D{{"_id", "a"}}, bson.D{{"_id", "b"}}, bson.D{{"_id", "c"}}})
+		require.NoError(t, err)
+
+		_, err = coll.DeleteOne(ctx, bson.D{{"_id", "c"}})
+		require.NoError(t, err)
+
+		_, err = coll.InsertOne(ctx, bson.D{{"_id", "d"}})
+		require.NoError(t, err)
+
+		expected := []bson.D{{{"_id", "a"}}, {{"_id", "b"}}, {{"_id", "d"}}}
+		AssertEqualDocumentsSlice(t, expected, find(t, coll))
+	})
+
+	t.Run("DeleteCollation", func(t *testing.T) {
+		coll := db.Collection(collection.Name() + "_deletecollation")
+		opts := options.CreateCollection().SetCapped(true).SetSizeInBytes(4096).SetMaxDocuments(2)
+		require.NoError(t, db.CreateCollection(ctx, coll.Name(), opts))
+
+		_, err := coll.InsertMany(ctx, []any{bson.D{{"_id", "a"}}, bson.D{{"_id", "b"}}})
+		require.NoError(t, err)
+
+		collation := &options.Collation{Locale: "en", Strength: 2}
+		_, err = coll.DeleteMany(ctx, bson.D{{"_id", "B"}}, options.Delete().SetCollation(collation))
+		require.NoError(t, err)
+
+		// the deleted document is forgotten, so "a" is not evicted
+		_, err = coll.InsertOne(ctx, bson.D{{"_id", "c"}})
+		require.NoError(t, err)
+
+		expected := []bson.D{{{"_id", "a"}}, {{"_id", "c"}}}
+		AssertEqualDocumentsSlice(t, expected, find(t, coll))
+	})
+
+	t.Run("Upsert", func(t *testing.T) {
+		coll := db.Collection(collection.Name() + "_upsert")
+		opts := options.CreateCollection().SetCapped(true).SetSizeInBytes(4096).SetMaxDocuments(2)
+		require.NoError(t, db.CreateCollection(ctx, coll.Name(), opts))
+
+		_, err := coll.InsertMany(ctx, []any{bson.D{{"_id", "a"}}, bson.D{{"_id", "b"}}})
+		require.NoError(t, err)
+
+		_, err = coll.UpdateOne(ctx, bson.D{{"_id", "c"}}, bson.D{{"$set", bson.D{{"v", int32(1)}}}}, options.Update().SetUpsert(true))
+		require.NoError(t, err)
+
+		expected := []bson.D{{{"_id", "b"}}, {{"_id", "c"}}}
+		AssertEqualDocumentsSlice(t, expected, find(t, coll))
+
+		err = coll.FindOneAndUpdate(
+			ctx,
+			bson.D{{"_id", "d"}},
+			bson.D{{"$set", bson.D{{"v", int32(1)}}}},
+			options.FindOneAndUpdate().SetUpsert(true),
+		).Err()
+		require.ErrorIs(t, err, mongo.ErrNoDocuments)
+
+		expected = []bson.D{{{"_id", "c"}}, {{"_id", "d"}}}
+		AssertEqualDocumentsSlice(t, expected, find(t, coll))
+	})
+
+	t.Run("Grow", func(t *testing.T) {
+		setup.SkipForMongoDB(t, "FerretDB-specific size accounting of updated documents")
+
+		coll := db.Collection(collection.Name() + "_grow")
+		opts := options.CreateCollection().SetCapped(true).SetSizeInBytes(4096)
+		require.NoError(t, db.CreateCollection(ctx, coll.Name(), opts))
+
+		_, err := coll.InsertMany(ctx, []any{bson.D{{"_id", "a"}}, bson.D{{"_id", "b"}}})
+		require.NoError(t, err)
+
+		_, err = coll.UpdateOne(ctx, bson.D{{"_id", "a"}}, bson.D{{"$set", bson.D{{"v", strings.Repeat("x", 4050)}}}})
+		require.NoError(t, err)
+
+		// the grown document is counted toward the size, so it is evicted
+		_, err = coll.InsertOne(ctx, bson.D{{"_id", "c"}})
+		require.NoError(t, err)
+
+		expected := []bson.D{{{"_id", "b"}}, {{"_id", "c"}}}
+		AssertEqualDocumentsSlice(t, expected, find(t, coll))
+	})
+}
+
+func TestCappedTailable(t *testing.T) {
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t)
+	db := collection.Database()
+
+	opts := options.CreateCollection().SetCapped(true).SetSizeInBytes(4096)
+	require.NoError(t, db.CreateCollection(ctx, collection.Name(), opts))
+
+	_, err := collection.InsertOne(ctx, bson.D{{"_id", "a"}, {"v", int32(1)}})
+	require.NoError(t, err)
+
+	findOpts := options.Find().
+		SetCursorType(options.TailableAwait).
+		SetMaxAwaitTime(time.Second).
+		SetProjection(bson.D{{"_id", 0}})
+
+	cursor, err := collection.Find(ctx, bson.D{{"v", bson.D{{"$gt", int32(0)}}}}, findOpts)
+	require.NoError(t, err)
+
+	defer cursor.Close(ctx)
+
+	var res bson.D
+
+	require.True(t, cursor.Next(ctx))
+	require.NoError(t, cursor.Decode(&res))
+	AssertEqualDocuments(t, bson.D{{"v", int32(1)}}, res)
+
+	// document that does not match the filter is skipped
+	_, err = collection.InsertOne(ctx, bson.D{{"_id", "b"}, {"v", int32(0)}})
+	require.NoError(t, err)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_, _ = collection.InsertOne(ctx, bson.D{{"_id", "c"}, {"v", int32(2)}})
+	}()
+
+	res = nil
+
+	require.True(t, cursor.Next(ctx))
+	require.NoError(t, cursor.Decode(&res))
+	AssertEqualDocuments(t, bson.D{{"v", int32(2)}}, res)
+
+	assert.False(t, cursor.TryNext(ctx))
+	assert.NotZero(t, cursor.ID())
+	assert.NoError(t, cursor.Err())
+}
+
+func TestCappedTailableAdmission(t *testing.T) {
+	setup.SkipForMongoDB(t, "MongoDB's admission control could not be configured per test")
+
+	t.Parallel()
+
+	s := setup.SetupWithOpts(t, &setup.SetupOpts{
+		ListenerOpts: &setup.ListenerOpts{MaxRequests: 1, AdmissionMaxWait: 100 * time.Millisecond},
+	})
+
+	ctx, collection := s.Ctx, s.Collection
+	db := collection.Database()
+
+	opts := options.CreateCollection().SetCapped(true).SetSizeInBytes(4096)
+	require.NoError(t, db.CreateCollection(ctx, collection.Name(), opts))
+
+	_, err := collection.InsertOne(ctx, bson.D{{"_id", "a"}})
+	require.NoError(t, err)
+
+	findOpts := options.Find().SetCursorType(options.TailableAwait).SetMaxAwaitTime(5 * time.Second)
+
+	cursor, err := collection.Find(ctx, bson.D{}, findOpts)
+	require.NoError(t, err)
+
+	defer cursor.Close(ctx)
+
+	require.True(t, cursor.Next(ctx))
+
+	// getMore waits for new documents without holding the only admission slot
+	next := make(chan bool)
+
+	go func() {
+		next <- cursor.Next(ctx)
+	}()
+
+	time.Sleep(500 * time.Millisecond)
+
+	_, err = collection.InsertOne(ctx, bson.D{{"_id", "b"}})
+	require.NoError(t, err)
+
+	require.True(t, <-next)
+
+	var res bson.D
+	require.NoError(t, cursor.Decode(&res))
+	AssertEqualDocuments(t, bson.D{{"_id", "b"}}, res)
+}
+
+func TestCappedErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t)
+	db := collection.Database()
+
+	t.Run("SizeRequired", func(t *testing.T) {
+		err := db.RunCommand(ctx, bson.D{{"create", collection.Name()}, {"capped", true}}).Err()
+
+		expected := mongo.CommandError{
+			Code:    72,
+			Name:    "InvalidOptions",
+			Message: "the 'size' field is required when 'capped' is true",
+		}
+		AssertEqualCommandError(t, expected, err)
+	})
+
+	t.Run("NonCapped", func(t *testing.T) {
+		_, err := collection.InsertOne(ctx, bson.D{{"_id", "a"}})
+		require.NoError(t, err)
+
+		err = db.RunCommand(ctx, bson.D{{"find", collection.Name()}, {"tailable", true}}).Err()
+
+		expected := mongo.CommandError{
+			Code: 2,
+			Name: "BadValue",
+			Message: "error processing query: " + db.Name() + "." + collection.Name() +
+				" tailable cursor requested on non capped collection",
+		}
+		AssertMatchesCommandError(t, expected, err)
+	})
+
+	t.Run("AwaitDataWithoutTailable", func(t *testing.T) {
+		err := db.RunCommand(ctx, bson.D{{"find", collection.Name()}, {"awaitData", true}}).Err()
+
+		expected := mongo.CommandError{
+			Code:    9,
+			Name:    "FailedToParse",
+			Message: "Cannot set 'awaitData' without also setting 'tailable'",
+		}
+		AssertEqualCommandError(t, expected, err)
+	})
+}
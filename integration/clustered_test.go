@@ -0,0 +1,156 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/FerretDB/FerretDB/v2/integration/setup"
+)
+
+func TestClustered(t *testing.T) {
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t)
+	db := collection.Database()
+
+	command := bson.D{
+		{"create", collection.Name()},
+		{"clusteredIndex", bson.D{{"key", bson.D{{"_id", 1}}}, {"unique", true}}},
+	}
+	require.NoError(t, db.RunCommand(ctx, command).Err())
+
+	_, err := collection.InsertMany(ctx, bson.A{
+		bson.D{{"_id", int32(3)}},
+		bson.D{{"_id", int32(1)}},
+		bson.D{{"_id", int32(2)}},
+	})
+	require.NoError(t, err)
+
+	t.Run("RangeScan", func(t *testing.T) {
+		filter := bson.D{{"_id", bson.D{{"$gte", int32(2)}}}}
+
+		cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.D{{"_id", 1}}))
+		require.NoError(t, err)
+
+		expected := []bson.D{{{"_id", int32(2)}}, {{"_id", int32(3)}}}
+		AssertEqualDocumentsSlice(t, expected, FetchAll(t, ctx, cursor))
+	})
+
+	t.Run("ListCollections", func(t *testing.T) {
+		cursor, err := db.ListCollections(ctx, bson.D{{"name", collection.Name()}})
+		require.NoError(t, err)
+
+		res := FetchAll(t, ctx, cursor)
+		require.Len(t, res, 1)
+
+		m := res[0].Map()
+		assert.NotContains(t, m, "idIndex")
+
+		opts, ok := m["options"].(bson.D)
+		require.True(t, ok)
+
+		clusteredIndex, ok := opts.Map()["clusteredIndex"].(bson.D)
+		require.True(t, ok)
+
+		expected := bson.D{
+			{"v", int32(2)},
+			{"key", bson.D{{"_id", int32(1)}}},
+			{"name", "_id_"},
+			{"unique", true},
+		}
+		AssertEqualDocuments(t, expected, clusteredIndex)
+	})
+
+	t.Run("ListIndexes", func(t *testing.T) {
+		cursor, err := collection.Indexes().List(ctx)
+		require.NoError(t, err)
+
+		res := FetchAll(t, ctx, cursor)
+		require.Len(t, res, 1)
+
+		expected := bson.D{
+			{"v", int32(2)},
+			{"key", bson.D{{"_id", int32(1)}}},
+			{"name", "_id_"},
+			{"unique", true},
+			{"clustered", true},
+		}
+		AssertEqualDocuments(t, expected, res[0])
+	})
+
+	t.Run("SameOptions", func(t *testing.T) {
+		require.NoError(t, db.RunCommand(ctx, command).Err())
+	})
+}
+
+func TestClusteredCreateErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t)
+	db := collection.Database()
+
+	clusteredIndex := bson.D{{"key", bson.D{{"_id", 1}}}, {"unique", true}}
+
+	for name, tc := range map[string]struct {
+		command bson.D              // required, command to run
+		err     *mongo.CommandError // required, expected error from MongoDB
+	}{
+		"KeyMissing": {
+			command: bson.D{{"create", collection.Name()}, {"clusteredIndex", bson.D{{"unique", true}}}},
+			err: &mongo.CommandError{
+				Code:    40414,
+				Name:    "Location40414",
+				Message: "BSON field 'create.clusteredIndex.key' is missing but a required field",
+			},
+		},
+		"Capped": {
+			command: bson.D{
+				{"create", collection.Name()},
+				{"clusteredIndex", clusteredIndex},
+				{"capped", true},
+				{"size", 4096},
+			},
+			err: &mongo.CommandError{
+				Code: 72,
+				Name: "InvalidOptions",
+			},
+		},
+		"TimeSeries": {
+			command: bson.D{
+				{"create", collection.Name()},
+				{"clusteredIndex", clusteredIndex},
+				{"timeseries", bson.D{{"timeField", "t"}}},
+			},
+			err: &mongo.CommandError{
+				Code: 72,
+				Name: "InvalidOptions",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := db.RunCommand(ctx, tc.command).Err()
+			AssertMatchesCommandError(t, *tc.err, err)
+		})
+	}
+}
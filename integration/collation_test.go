@@ -0,0 +1,147 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/FerretDB/FerretDB/v2/integration/setup"
+)
+
+func TestCollation(t *testing.T) {
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t)
+
+	_, err := collection.InsertMany(ctx, bson.A{
+		bson.D{{"_id", int32(1)}, {"name", "Zebra"}},
+		bson.D{{"_id", int32(2)}, {"name", "apple"}},
+		bson.D{{"_id", int32(3)}, {"name", "Apple"}},
+		bson.D{{"_id", int32(4)}, {"name", "äpfel"}},
+	})
+	require.NoError(t, err)
+
+	caseInsensitive := &options.Collation{Locale: "en", Strength: 2}
+
+	t.Run("Find", func(t *testing.T) {
+		opts := options.Find().SetCollation(caseInsensitive).SetSort(bson.D{{"_id", 1}})
+
+		cursor, err := collection.Find(ctx, bson.D{{"name", "APPLE"}}, opts)
+		require.NoError(t, err)
+
+		expected := []bson.D{
+			{{"_id", int32(2)}, {"name", "apple"}},
+			{{"_id", int32(3)}, {"name", "Apple"}},
+		}
+		AssertEqualDocumentsSlice(t, expected, FetchAll(t, ctx, cursor))
+	})
+
+	t.Run("Sort", func(t *testing.T) {
+		opts := options.Find().
+			SetCollation(&options.Collation{Locale: "de"}).
+			SetSort(bson.D{{"name", 1}}).
+			SetProjection(bson.D{{"_id", 1}})
+
+		cursor, err := collection.Find(ctx, bson.D{}, opts)
+		require.NoError(t, err)
+
+		expected := []bson.D{{{"_id", int32(2)}}, {{"_id", int32(3)}}, {{"_id", int32(4)}}, {{"_id", int32(1)}}}
+		AssertEqualDocumentsSlice(t, expected, FetchAll(t, ctx, cursor))
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		n, err := collection.CountDocuments(ctx, bson.D{{"name", "apple"}}, options.Count().SetCollation(caseInsensitive))
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, n)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		res, err := collection.UpdateMany(
+			ctx,
+			bson.D{{"name", "zebra"}},
+			bson.D{{"$set", bson.D{{"v", int32(1)}}}},
+			options.Update().SetCollation(caseInsensitive),
+		)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, res.ModifiedCount)
+	})
+}
+
+func TestCollationErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t)
+	db := collection.Database()
+
+	for name, tc := range map[string]struct {
+		collation any                 // required, collation to use in find
+		err       *mongo.CommandError // required, expected error from MongoDB
+	}{
+		"WrongType": {
+			collation: "en",
+			err: &mongo.CommandError{
+				Code: 14,
+				Name: "TypeMismatch",
+			},
+		},
+		"LocaleMissing": {
+			collation: bson.D{{"strength", int32(2)}},
+			err: &mongo.CommandError{
+				Code: 40414,
+				Name: "Location40414",
+			},
+		},
+		"Strength": {
+			collation: bson.D{{"locale", "en"}, {"strength", int32(6)}},
+			err: &mongo.CommandError{
+				Code: 2,
+				Name: "BadValue",
+			},
+		},
+		"StrengthFractional": {
+			collation: bson.D{{"locale", "en"}, {"strength", 1.5}},
+			err: &mongo.CommandError{
+				Code: 2,
+				Name: "BadValue",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			command := bson.D{{"find", collection.Name()}, {"collation", tc.collation}}
+			err := db.RunCommand(ctx, command).Err()
+			AssertMatchesCommandError(t, *tc.err, err)
+		})
+	}
+
+	t.Run("UnknownField", func(t *testing.T) {
+		t.Parallel()
+
+		collation := bson.D{{"locale", "en"}, {"foo", int32(1)}}
+		err := db.RunCommand(ctx, bson.D{{"find", collection.Name()}, {"collation", collation}}).Err()
+
+		// MongoDB uses Location40415 as a code name
+		var ce mongo.CommandError
+		require.ErrorAs(t, err, &ce)
+		assert.Equal(t, int32(40415), ce.Code)
+	})
+}
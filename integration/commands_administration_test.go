@@ -1882,6 +1882,9 @@ func TestServerStatusCommand(t *testing.T) {
 			ferretdb, buildEnvironment := RemoveKey(t, field.Value.(bson.D), "buildEnvironment")
 			assert.IsType(t, bson.D{}, buildEnvironment)
 
+			ferretdb, pool := RemoveKey(t, ferretdb, "pool")
+			assert.IsType(t, bson.D{}, pool)
+
 			expected := bson.D{
 				{"version", info.Version},
 				{"gitVersion", info.Commit},
@@ -1915,7 +1918,7 @@ func TestServerStatusCommand(t *testing.T) {
 				// TODO https://github.com/FerretDB/FerretDB-DocumentDB/issues/629
 				// Fields are not set in FerretDB
 
-				case "collections", "internalCollections", "internalViews":
+				case "collections", "views", "internalCollections", "internalViews":
 					assert.IsType(t, int32(0), subField.Value)
 					catalogStatsComparable = append(catalogStatsComparable, bson.E{subField.Key, int32(0)})
 
@@ -1996,6 +1999,104 @@ func TestServerStatusCommand(t *testing.T) {
 	AssertEqualDocuments(t, expected, actualComparable)
 }
 
+//nolint:paralleltest // we test a global server status
+func TestServerStatusCommandSections(t *testing.T) {
+	ctx, collection := setup.Setup(t, shareddata.Scalars)
+
+	_, err := collection.Find(ctx, bson.D{})
+	require.NoError(t, err)
+
+	var actual bson.D
+	err = collection.Database().RunCommand(ctx, bson.D{{"serverStatus", int32(1)}}).Decode(&actual)
+	require.NoError(t, err)
+
+	m := actual.Map()
+
+	connections, ok := m["connections"].(bson.D)
+	require.True(t, ok)
+	assert.Greater(t, connections.Map()["current"], int32(0))
+	assert.Greater(t, connections.Map()["totalCreated"], int32(0))
+	assert.Greater(t, connections.Map()["active"], int32(0))
+
+	network, ok := m["network"].(bson.D)
+	require.True(t, ok)
+	assert.Greater(t, network.Map()["bytesIn"], int64(0))
+	assert.Greater(t, network.Map()["bytesOut"], int64(0))
+	assert.Greater(t, network.Map()["numRequests"], int64(0))
+
+	opcounters, ok := m["opcounters"].(bson.D)
+	require.True(t, ok)
+	assert.Greater(t, opcounters.Map()["insert"], int64(0))
+	assert.Greater(t, opcounters.Map()["query"], int64(0))
+	assert.Greater(t, opcounters.Map()["command"], int64(0))
+
+	mem, ok := m["mem"].(bson.D)
+	require.True(t, ok)
+	assert.Equal(t, int32(64), mem.Map()["bits"])
+	assert.Equal(t, true, mem.Map()["supported"])
+
+	catalogStats, ok := m["catalogStats"].(bson.D)
+	require.True(t, ok)
+	assert.Greater(t, catalogStats.Map()["collections"], int32(0))
+
+	t.Run("Exclude", func(t *testing.T) {
+		command := bson.D{{"serverStatus", int32(1)}, {"mem", false}, {"network", int32(0)}, {"opcounters", true}}
+
+		var res bson.D
+		err := collection.Database().RunCommand(ctx, command).Decode(&res)
+		require.NoError(t, err)
+
+		m := res.Map()
+		assert.NotContains(t, m, "mem")
+		assert.NotContains(t, m, "network")
+		assert.Contains(t, m, "opcounters")
+		assert.Contains(t, m, "connections")
+	})
+}
+
+func TestConnPoolStatsCommand(t *testing.T) {
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t, shareddata.Scalars)
+
+	// keep the cursor open to hold a connection
+	cursor, err := collection.Find(ctx, bson.D{}, options.Find().SetBatchSize(1))
+	require.NoError(t, err)
+
+	defer cursor.Close(ctx)
+
+	var actual bson.D
+	err = collection.Database().RunCommand(ctx, bson.D{{"connPoolStats", int32(1)}}).Decode(&actual)
+	require.NoError(t, err)
+
+	m := actual.Map()
+	assert.Equal(t, 1.0, m["ok"])
+
+	for _, k := range []string{"totalInUse", "totalAvailable", "totalCreated", "totalRefreshing"} {
+		assert.Contains(t, m, k)
+	}
+
+	assert.IsType(t, bson.D{}, m["pools"])
+	assert.IsType(t, bson.D{}, m["hosts"])
+
+	if setup.IsMongoDB(t) {
+		return
+	}
+
+	ferretdb, ok := m["ferretdb"].(bson.D)
+	require.True(t, ok)
+
+	pool, ok := ferretdb.Map()["pool"].(bson.D)
+	require.True(t, ok)
+	assert.Greater(t, pool.Map()["total"], int32(0))
+	assert.Greater(t, pool.Map()["max"], int32(0))
+
+	cursors, ok := ferretdb.Map()["cursors"].(bson.D)
+	require.True(t, ok)
+	assert.Greater(t, cursors.Map()["total"], int32(0))
+	assert.Contains(t, cursors.Map(), "hijackedConnections")
+}
+
 func TestServerStatusCommandMetrics(t *testing.T) {
 	setup.SkipForMongoDB(t, "MongoDB decommissioned server status metrics")
 
@@ -2491,3 +2592,50 @@ func TestCompactCommandNonExistent(t *testing.T) {
 		})
 	}
 }
+
+func TestTopCommand(t *testing.T) {
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t, shareddata.Scalars)
+
+	_, err := collection.InsertOne(ctx, bson.D{{"_id", "top"}})
+	require.NoError(t, err)
+
+	cursor, err := collection.Find(ctx, bson.D{})
+	require.NoError(t, err)
+	require.NoError(t, cursor.Close(ctx))
+
+	t.Run("NonAdmin", func(t *testing.T) {
+		t.Parallel()
+
+		err := collection.Database().RunCommand(ctx, bson.D{{"top", int32(1)}}).Err()
+
+		expected := mongo.CommandError{
+			Code:    13,
+			Name:    "Unauthorized",
+			Message: "top may only be run against the admin database.",
+		}
+		AssertEqualCommandError(t, expected, err)
+	})
+
+	var actual bson.D
+	err = collection.Database().Client().Database("admin").RunCommand(ctx, bson.D{{"top", int32(1)}}).Decode(&actual)
+	require.NoError(t, err)
+
+	m := actual.Map()
+	assert.Equal(t, 1.0, m["ok"])
+
+	totals, ok := m["totals"].(bson.D)
+	require.True(t, ok)
+	assert.Equal(t, "all times in microseconds", totals.Map()["note"])
+
+	ns, ok := totals.Map()[collection.Database().Name()+"."+collection.Name()].(bson.D)
+	require.True(t, ok, "%v", totals)
+
+	for _, k := range []string{"total", "readLock", "writeLock", "queries", "insert"} {
+		counter, ok := ns.Map()[k].(bson.D)
+		require.True(t, ok, k)
+		assert.GreaterOrEqual(t, counter.Map()["count"], int64(1), k)
+		assert.Contains(t, counter.Map(), "time", k)
+	}
+}
@@ -26,6 +26,7 @@
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/FerretDB/FerretDB/v2/internal/util/testutil"
 
@@ -393,3 +394,258 @@ func inProgress(tb testing.TB, ctx context.Context, adminDB *mongo.Database, n i
 
 	panic("not reached")
 }
+
+func TestCurrentOpFerretDB(t *testing.T) {
+	setup.SkipForMongoDB(t, "FerretDB returns only operations of its own instance")
+
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t)
+	adminDB := collection.Database().Client().Database("admin")
+
+	var res bson.D
+	err := adminDB.RunCommand(ctx, bson.D{{"currentOp", int32(1)}, {"op", "command"}}).Decode(&res)
+	require.NoError(t, err)
+
+	inprog, ok := res.Map()["inprog"].(bson.A)
+	require.True(t, ok)
+
+	var self bson.M
+
+	for _, v := range inprog {
+		op := v.(bson.D).Map()
+		assert.Equal(t, "command", op["op"])
+		assert.IsType(t, int32(0), op["opid"])
+
+		if command, _ := op["command"].(bson.D); len(command) > 0 && command[0].Key == "currentOp" {
+			self = op
+		}
+	}
+
+	require.NotNil(t, self, "currentOp operation itself not found")
+	assert.Equal(t, "admin.$cmd", self["ns"])
+	assert.Equal(t, true, self["active"])
+}
+
+func TestKillOp(t *testing.T) {
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t)
+	adminDB := collection.Database().Client().Database("admin")
+
+	t.Run("NotAdmin", func(t *testing.T) {
+		t.Parallel()
+
+		err := collection.Database().RunCommand(ctx, bson.D{{"killOp", int32(1)}, {"op", int32(1)}}).Err()
+
+		expected := mongo.CommandError{
+			Code:    13,
+			Name:    "Unauthorized",
+			Message: "killOp may only be run against the admin database.",
+		}
+		AssertEqualCommandError(t, expected, err)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		t.Parallel()
+
+		var res bson.D
+		err := adminDB.RunCommand(ctx, bson.D{{"killOp", int32(1)}, {"op", int32(2_000_000_000)}}).Decode(&res)
+		require.NoError(t, err)
+
+		AssertEqualDocuments(t, bson.D{{"info", "attempting to kill op"}, {"ok", float64(1)}}, res)
+	})
+
+	t.Run("AwaitData", func(t *testing.T) {
+		t.Parallel()
+
+		db := collection.Database()
+		cName := collection.Name() + "_capped"
+
+		opts := options.CreateCollection().SetCapped(true).SetSizeInBytes(4096)
+		require.NoError(t, db.CreateCollection(ctx, cName, opts))
+
+		var res bson.D
+		err := db.RunCommand(ctx, bson.D{
+			{"find", cName},
+			{"tailable", true},
+			{"awaitData", true},
+		}).Decode(&res)
+		require.NoError(t, err)
+
+		cursorID := res.Map()["cursor"].(bson.D).Map()["id"].(int64)
+		require.NotZero(t, cursorID)
+
+		errCh := make(chan error, 1)
+
+		go func() {
+			errCh <- db.RunCommand(ctx, bson.D{
+				{"getMore", cursorID},
+				{"collection", cName},
+				{"maxTimeMS", int32(30_000)},
+			}).Err()
+		}()
+
+		var opID any
+
+		require.Eventually(t, func() bool {
+			var res bson.D
+			err := adminDB.RunCommand(ctx, bson.D{
+				{"currentOp", int32(1)},
+				{"op", "getmore"},
+				{"ns", db.Name() + "." + cName},
+			}).Decode(&res)
+			require.NoError(t, err)
+
+			inprog := res.Map()["inprog"].(bson.A)
+			if len(inprog) == 0 {
+				return false
+			}
+
+			opID = inprog[0].(bson.D).Map()["opid"]
+
+			return true
+		}, 10*time.Second, 50*time.Millisecond)
+
+		err = adminDB.RunCommand(ctx, bson.D{{"killOp", int32(1)}, {"op", opID}}).Err()
+		require.NoError(t, err)
+
+		select {
+		case err = <-errCh:
+		case <-time.After(10 * time.Second):
+			t.Fatal("getMore was not interrupted")
+		}
+
+		var ce mongo.CommandError
+		require.ErrorAs(t, err, &ce)
+		assert.Equal(t, int32(11601), ce.Code)
+		assert.Equal(t, "Interrupted", ce.Name)
+	})
+}
+
+func TestCurrentOpUnprivileged(t *testing.T) {
+	t.Parallel()
+
+	s := setup.SetupWithOpts(t, nil)
+	ctx, db := s.Ctx, s.Collection.Database()
+	adminDB := db.Client().Database("admin")
+	username, password, mechanism := "currentopuser", "password", "SCRAM-SHA-256"
+
+	// TODO https://github.com/FerretDB/FerretDB-DocumentDB/issues/864
+	_ = db.RunCommand(ctx, bson.D{{"dropUser", username}})
+
+	err := db.RunCommand(ctx, bson.D{
+		{"createUser", username},
+		{"roles", bson.A{}},
+		{"pwd", password},
+		{"mechanisms", bson.A{mechanism}},
+	}).Err()
+	require.NoError(t, err)
+
+	credential := options.Credential{
+		AuthMechanism: mechanism,
+		AuthSource:    db.Name(),
+		Username:      username,
+		Password:      password,
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(s.MongoDBURI).SetAuth(credential))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, client.Disconnect(ctx))
+	})
+
+	userAdminDB := client.Database("admin")
+
+	t.Run("AllOps", func(t *testing.T) {
+		err := userAdminDB.RunCommand(ctx, bson.D{{"currentOp", int32(1)}}).Err()
+
+		var ce mongo.CommandError
+		require.ErrorAs(t, err, &ce)
+		assert.Equal(t, int32(13), ce.Code)
+		assert.Equal(t, "Unauthorized", ce.Name)
+	})
+
+	t.Run("OwnOps", func(t *testing.T) {
+		var res bson.D
+		err := userAdminDB.RunCommand(ctx, bson.D{{"currentOp", int32(1)}, {"$ownOps", true}}).Decode(&res)
+		require.NoError(t, err)
+
+		inprog, ok := res.Map()["inprog"].(bson.A)
+		require.True(t, ok)
+
+		for _, v := range inprog {
+			op := v.(bson.D).Map()
+
+			command, _ := op["command"].(bson.D)
+			require.NotEmpty(t, command)
+			assert.Equal(t, "currentOp", command[0].Key, "operation of another user: %v", op)
+		}
+	})
+
+	t.Run("KillOtherUser", func(t *testing.T) {
+		cName := s.Collection.Name() + "_capped"
+
+		opts := options.CreateCollection().SetCapped(true).SetSizeInBytes(4096)
+		require.NoError(t, db.CreateCollection(ctx, cName, opts))
+
+		var res bson.D
+		err := db.RunCommand(ctx, bson.D{
+			{"find", cName},
+			{"tailable", true},
+			{"awaitData", true},
+		}).Decode(&res)
+		require.NoError(t, err)
+
+		cursorID := res.Map()["cursor"].(bson.D).Map()["id"].(int64)
+		require.NotZero(t, cursorID)
+
+		errCh := make(chan error, 1)
+
+		go func() {
+			errCh <- db.RunCommand(ctx, bson.D{
+				{"getMore", cursorID},
+				{"collection", cName},
+				{"maxTimeMS", int32(30_000)},
+			}).Err()
+		}()
+
+		var opID any
+
+		require.Eventually(t, func() bool {
+			var res bson.D
+			err := adminDB.RunCommand(ctx, bson.D{
+				{"currentOp", int32(1)},
+				{"op", "getmore"},
+				{"ns", db.Name() + "." + cName},
+			}).Decode(&res)
+			require.NoError(t, err)
+
+			inprog := res.Map()["inprog"].(bson.A)
+			if len(inprog) == 0 {
+				return false
+			}
+
+			opID = inprog[0].(bson.D).Map()["opid"]
+
+			return true
+		}, 10*time.Second, 50*time.Millisecond)
+
+		err = userAdminDB.RunCommand(ctx, bson.D{{"killOp", int32(1)}, {"op", opID}}).Err()
+
+		var ce mongo.CommandError
+		require.ErrorAs(t, err, &ce)
+		assert.Equal(t, int32(13), ce.Code)
+		assert.Equal(t, "Unauthorized", ce.Name)
+
+		err = adminDB.RunCommand(ctx, bson.D{{"killOp", int32(1)}, {"op", opID}}).Err()
+		require.NoError(t, err)
+
+		select {
+		case <-errCh:
+		case <-time.After(10 * time.Second):
+			t.Fatal("getMore was not interrupted")
+		}
+	})
+}
@@ -0,0 +1,250 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/FerretDB/FerretDB/v2/integration/setup"
+)
+
+// geoPoint returns GeoJSON point with the given coordinates.
+func geoPoint(lng, lat float64) bson.D {
+	return bson.D{{"type", "Point"}, {"coordinates", bson.A{lng, lat}}}
+}
+
+// planUsesIndex returns true if the given MongoDB query plan stage or any of its input stages uses the given index.
+func planUsesIndex(stage any, index string) bool {
+	switch stage := stage.(type) {
+	case bson.D:
+		for _, e := range stage {
+			if e.Key == "indexName" && e.Value == index {
+				return true
+			}
+
+			if planUsesIndex(e.Value, index) {
+				return true
+			}
+		}
+
+	case bson.A:
+		for _, v := range stage {
+			if planUsesIndex(v, index) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func TestGeo2dsphere(t *testing.T) {
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t)
+
+	_, err := collection.InsertMany(ctx, bson.A{
+		bson.D{{"_id", "center"}, {"loc", geoPoint(0, 0)}, {"zone", "a"}},
+		bson.D{{"_id", "near"}, {"loc", geoPoint(0, 0.01)}, {"zone", "b"}},
+		bson.D{{"_id", "far"}, {"loc", geoPoint(0, 1)}, {"zone", "a"}},
+	})
+	require.NoError(t, err)
+
+	_, err = collection.Indexes().CreateOne(ctx, mongo.IndexModel{Keys: bson.D{{"loc", "2dsphere"}}})
+	require.NoError(t, err)
+
+	t.Run("Near", func(t *testing.T) {
+		t.Parallel()
+
+		filter := bson.D{{"loc", bson.D{{"$near", bson.D{
+			{"$geometry", geoPoint(0, 0)},
+			{"$maxDistance", 5000},
+		}}}}}
+
+		cursor, err := collection.Find(ctx, filter)
+		require.NoError(t, err)
+
+		// sorted by distance
+		assert.Equal(t, []any{"center", "near"}, CollectIDs(t, FetchAll(t, ctx, cursor)))
+	})
+
+	t.Run("NearSphere", func(t *testing.T) {
+		t.Parallel()
+
+		filter := bson.D{{"loc", bson.D{{"$nearSphere", bson.D{
+			{"$geometry", geoPoint(0, 1)},
+		}}}}}
+
+		cursor, err := collection.Find(ctx, filter)
+		require.NoError(t, err)
+
+		assert.Equal(t, []any{"far", "near", "center"}, CollectIDs(t, FetchAll(t, ctx, cursor)))
+	})
+
+	t.Run("GeoWithin", func(t *testing.T) {
+		t.Parallel()
+
+		polygon := bson.D{{"type", "Polygon"}, {"coordinates", bson.A{bson.A{
+			bson.A{-0.5, -0.5}, bson.A{0.5, -0.5}, bson.A{0.5, 0.5}, bson.A{-0.5, 0.5}, bson.A{-0.5, -0.5},
+		}}}}
+
+		filter := bson.D{{"loc", bson.D{{"$geoWithin", bson.D{{"$geometry", polygon}}}}}}
+
+		cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.D{{"_id", 1}}))
+		require.NoError(t, err)
+
+		assert.Equal(t, []any{"center", "near"}, CollectIDs(t, FetchAll(t, ctx, cursor)))
+	})
+
+	t.Run("GeoNear", func(t *testing.T) {
+		t.Parallel()
+
+		pipeline := bson.A{
+			bson.D{{"$geoNear", bson.D{
+				{"near", geoPoint(0, 0)},
+				{"distanceField", "dist"},
+				{"maxDistance", 200_000},
+				{"query", bson.D{{"zone", "a"}}},
+				{"spherical", true},
+			}}},
+		}
+
+		cursor, err := collection.Aggregate(ctx, pipeline)
+		require.NoError(t, err)
+
+		docs := FetchAll(t, ctx, cursor)
+		require.Equal(t, []any{"center", "far"}, CollectIDs(t, docs))
+
+		assert.InDelta(t, 0, docs[0].Map()["dist"], 1)
+		assert.InDelta(t, 111_000, docs[1].Map()["dist"], 1_000)
+	})
+
+	t.Run("GeoNearNotFirst", func(t *testing.T) {
+		t.Parallel()
+
+		pipeline := bson.A{
+			bson.D{{"$match", bson.D{}}},
+			bson.D{{"$geoNear", bson.D{{"near", geoPoint(0, 0)}, {"distanceField", "dist"}}}},
+		}
+
+		_, err := collection.Aggregate(ctx, pipeline)
+
+		// MongoDB uses Location40602 as a code name
+		var ce mongo.CommandError
+		require.ErrorAs(t, err, &ce)
+		assert.Equal(t, int32(40602), ce.Code)
+	})
+
+	t.Run("Explain", func(t *testing.T) {
+		t.Parallel()
+
+		filter := bson.D{{"loc", bson.D{{"$near", bson.D{{"$geometry", geoPoint(0, 0)}}}}}}
+
+		var res bson.D
+		err := collection.Database().RunCommand(ctx, bson.D{
+			{"explain", bson.D{{"find", collection.Name()}, {"filter", filter}}},
+		}).Decode(&res)
+		require.NoError(t, err)
+
+		queryPlanner, ok := res.Map()["queryPlanner"].(bson.D)
+		require.True(t, ok, "%v", res)
+
+		if !setup.IsMongoDB(t) {
+			// FerretDB returns PostgreSQL query plan with DocumentDB's internal index names,
+			// so we can't check that the 2dsphere index is used
+			assert.NotNil(t, queryPlanner.Map()["Plan"], "%v", queryPlanner)
+			return
+		}
+
+		assert.True(t, planUsesIndex(queryPlanner.Map()["winningPlan"], "loc_2dsphere"), "%v", queryPlanner)
+	})
+
+	t.Run("InvalidGeoJSON", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := collection.InsertOne(ctx, bson.D{
+			{"loc", bson.D{{"type", "Point"}, {"coordinates", bson.A{200.0, 0.0}}}},
+		})
+
+		// MongoDB uses Location16755 as a code name
+		var we mongo.WriteException
+		require.ErrorAs(t, err, &we)
+		require.Len(t, we.WriteErrors, 1)
+		assert.Equal(t, 16755, we.WriteErrors[0].Code)
+	})
+}
+
+func TestGeo2d(t *testing.T) {
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t)
+
+	_, err := collection.InsertMany(ctx, bson.A{
+		bson.D{{"_id", "origin"}, {"loc", bson.A{0.0, 0.0}}},
+		bson.D{{"_id", "one"}, {"loc", bson.A{1.0, 1.0}}},
+		bson.D{{"_id", "ten"}, {"loc", bson.A{10.0, 10.0}}},
+	})
+	require.NoError(t, err)
+
+	_, err = collection.Indexes().CreateOne(ctx, mongo.IndexModel{Keys: bson.D{{"loc", "2d"}}})
+	require.NoError(t, err)
+
+	t.Run("Near", func(t *testing.T) {
+		t.Parallel()
+
+		filter := bson.D{{"loc", bson.D{{"$near", bson.A{0.0, 0.0}}, {"$maxDistance", 5}}}}
+
+		cursor, err := collection.Find(ctx, filter)
+		require.NoError(t, err)
+
+		assert.Equal(t, []any{"origin", "one"}, CollectIDs(t, FetchAll(t, ctx, cursor)))
+	})
+
+	t.Run("GeoWithinBox", func(t *testing.T) {
+		t.Parallel()
+
+		filter := bson.D{{"loc", bson.D{{"$geoWithin", bson.D{{"$box", bson.A{bson.A{0.5, 0.5}, bson.A{20.0, 20.0}}}}}}}}
+
+		cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.D{{"_id", 1}}))
+		require.NoError(t, err)
+
+		assert.Equal(t, []any{"one", "ten"}, CollectIDs(t, FetchAll(t, ctx, cursor)))
+	})
+
+	t.Run("GeoNear", func(t *testing.T) {
+		t.Parallel()
+
+		pipeline := bson.A{
+			bson.D{{"$geoNear", bson.D{
+				{"near", bson.A{0.0, 0.0}},
+				{"distanceField", "dist"},
+				{"maxDistance", 2},
+			}}},
+		}
+
+		cursor, err := collection.Aggregate(ctx, pipeline)
+		require.NoError(t, err)
+
+		docs := FetchAll(t, ctx, cursor)
+		require.Equal(t, []any{"origin", "one"}, CollectIDs(t, docs))
+		assert.InDelta(t, 1.414, docs[1].Map()["dist"], 0.001)
+	})
+}
@@ -0,0 +1,123 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/FerretDB/FerretDB/v2/integration/setup"
+	"github.com/FerretDB/FerretDB/v2/integration/shareddata"
+)
+
+func TestProfile(t *testing.T) {
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t, shareddata.Scalars)
+	db := collection.Database()
+
+	t.Cleanup(func() {
+		_ = db.RunCommand(ctx, bson.D{{"profile", int32(0)}}).Err()
+	})
+
+	var res bson.D
+	err := db.RunCommand(ctx, bson.D{{"profile", int32(-1)}}).Decode(&res)
+	require.NoError(t, err)
+
+	m := res.Map()
+	assert.Equal(t, int32(0), m["was"])
+	assert.Equal(t, 1.0, m["ok"])
+
+	err = db.RunCommand(ctx, bson.D{{"profile", int32(2)}}).Decode(&res)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), res.Map()["was"])
+
+	err = db.RunCommand(ctx, bson.D{{"profile", int32(-1)}}).Decode(&res)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), res.Map()["was"])
+
+	cursor, err := collection.Find(ctx, bson.D{{"v", int32(42)}})
+	require.NoError(t, err)
+
+	expected := FetchAll(t, ctx, cursor)
+	require.NotEmpty(t, expected)
+
+	filter := bson.D{{"op", "query"}, {"ns", db.Name() + "." + collection.Name()}}
+
+	// entries could be written asynchronously
+	var entry bson.D
+
+	require.Eventually(t, func() bool {
+		err = db.Collection("system.profile").FindOne(ctx, filter).Decode(&entry)
+		return err == nil
+	}, 10*time.Second, 100*time.Millisecond, "%v", err)
+
+	m = entry.Map()
+
+	command, ok := m["command"].(bson.D)
+	require.True(t, ok, "%v", entry)
+	assert.Equal(t, bson.D{{"v", int32(42)}}, command.Map()["filter"])
+
+	assert.Equal(t, int32(len(expected)), m["nreturned"])
+	assert.IsType(t, "", m["planSummary"])
+	assert.NotNil(t, m["millis"])
+	assert.NotNil(t, m["ts"])
+}
+
+func TestProfileErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t)
+	db := collection.Database()
+
+	for name, tc := range map[string]struct {
+		command bson.D
+		err     *mongo.CommandError
+	}{
+		"InvalidLevel": {
+			command: bson.D{{"profile", int32(3)}},
+			err: &mongo.CommandError{
+				Code: 2,
+				Name: "BadValue",
+			},
+		},
+		"InvalidSampleRate": {
+			command: bson.D{{"profile", int32(-1)}, {"sampleRate", 1.5}},
+			err: &mongo.CommandError{
+				Code: 2,
+				Name: "BadValue",
+			},
+		},
+		"InvalidType": {
+			command: bson.D{{"profile", "1"}},
+			err: &mongo.CommandError{
+				Code: 14,
+				Name: "TypeMismatch",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := db.RunCommand(ctx, tc.command).Err()
+			AssertMatchesCommandError(t, *tc.err, err)
+		})
+	}
+}
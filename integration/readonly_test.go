@@ -0,0 +1,131 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/FerretDB/FerretDB/v2/integration/setup"
+	"github.com/FerretDB/FerretDB/v2/integration/shareddata"
+)
+
+func TestReadOnly(t *testing.T) {
+	setup.SkipForMongoDB(t, "FerretDB-specific read-only mode")
+
+	t.Parallel()
+
+	// read-only mode is toggled for the whole in-process listener used only by this test
+	s := setup.SetupWithOpts(t, &setup.SetupOpts{
+		ListenerOpts: &setup.ListenerOpts{TTLMonitorInterval: 100 * time.Millisecond},
+		Providers:    []shareddata.Provider{shareddata.Scalars},
+	})
+
+	ctx, collection := s.Ctx, s.Collection
+	db, admin := collection.Database(), collection.Database().Client().Database("admin")
+
+	ttlCollection := db.Collection(collection.Name() + "_ttl")
+
+	_, err := ttlCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{"expiresAt", 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	require.NoError(t, err)
+
+	_, err = ttlCollection.InsertOne(ctx, bson.D{{"_id", "expiring"}, {"expiresAt", time.Now().Add(time.Second)}})
+	require.NoError(t, err)
+
+	var res bson.D
+	require.NoError(t, admin.RunCommand(ctx, bson.D{{"setParameter", 1}, {"readOnly", true}}).Decode(&res))
+	AssertEqualDocuments(t, bson.D{{"was", false}, {"ok", float64(1)}}, res)
+
+	// disable read-only mode before the collection is dropped
+	t.Cleanup(func() {
+		_ = admin.RunCommand(ctx, bson.D{{"setParameter", 1}, {"readOnly", false}}).Err()
+	})
+
+	var hello bson.D
+	require.NoError(t, db.RunCommand(ctx, bson.D{{"hello", 1}}).Decode(&hello))
+	assert.Equal(t, true, hello.Map()["readOnly"])
+
+	cursor, err := collection.Find(ctx, bson.D{})
+	require.NoError(t, err)
+	require.NoError(t, cursor.Close(ctx))
+
+	// the TTL monitor does not delete expired documents
+	time.Sleep(2 * time.Second)
+
+	n, err := ttlCollection.CountDocuments(ctx, bson.D{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	for name, command := range map[string]bson.D{
+		"Insert":        {{"insert", collection.Name()}, {"documents", bson.A{bson.D{{"_id", "new"}}}}},
+		"Update":        {{"update", collection.Name()}, {"updates", bson.A{bson.D{{"q", bson.D{}}, {"u", bson.D{}}}}}},
+		"Delete":        {{"delete", collection.Name()}, {"deletes", bson.A{bson.D{{"q", bson.D{}}, {"limit", 0}}}}},
+		"FindAndModify": {{"findAndModify", collection.Name()}, {"remove", true}},
+		"Create":        {{"create", "new"}},
+		"Drop":          {{"drop", collection.Name()}},
+		"CreateIndexes": {{"createIndexes", collection.Name()}, {"indexes", bson.A{bson.D{{"key", bson.D{{"v", 1}}}, {"name", "v_1"}}}}},
+		"CreateUser":    {{"createUser", "user"}, {"roles", bson.A{}}, {"pwd", "password"}},
+		"AggregateOut":  {{"aggregate", collection.Name()}, {"pipeline", bson.A{bson.D{{"$out", "new"}}}}, {"cursor", bson.D{}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := db.RunCommand(ctx, command).Err()
+			expected := mongo.CommandError{
+				Code:    10107,
+				Name:    "NotWritablePrimary",
+				Message: "Command " + command[0].Key + " is not allowed in read-only mode",
+			}
+			AssertEqualCommandError(t, expected, err)
+		})
+	}
+
+	res = nil
+	require.NoError(t, admin.RunCommand(ctx, bson.D{{"setParameter", 1}, {"readOnly", false}}).Decode(&res))
+	AssertEqualDocuments(t, bson.D{{"was", true}, {"ok", float64(1)}}, res)
+
+	_, err = collection.InsertOne(ctx, bson.D{{"_id", "new"}})
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		n, err = ttlCollection.CountDocuments(ctx, bson.D{})
+		return err == nil && n == 0
+	}, 10*time.Second, 100*time.Millisecond)
+
+	res = nil
+	require.NoError(t, admin.RunCommand(ctx, bson.D{{"getParameter", 1}, {"readOnly", 1}}).Decode(&res))
+	AssertEqualDocuments(t, bson.D{{"readOnly", false}, {"ok", float64(1)}}, res)
+
+	err = db.RunCommand(ctx, bson.D{{"setParameter", 1}, {"readOnly", true}}).Err()
+	AssertEqualCommandError(t, mongo.CommandError{
+		Code:    13,
+		Name:    "Unauthorized",
+		Message: "setParameter may only be run against the admin database.",
+	}, err)
+
+	err = admin.RunCommand(ctx, bson.D{{"setParameter", 1}, {"unknown", true}}).Err()
+	AssertEqualCommandError(t, mongo.CommandError{
+		Code:    72,
+		Name:    "InvalidOptions",
+		Message: "attempted to set unrecognized parameter [unknown], use help:true to see options ",
+	}, err)
+}
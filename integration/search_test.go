@@ -0,0 +1,201 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/FerretDB/FerretDB/v2/integration/setup"
+)
+
+func TestVectorSearch(t *testing.T) {
+	setup.SkipForMongoDB(t, "Atlas Search is not available in MongoDB Community Edition")
+
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t)
+	db := collection.Database()
+
+	_, err := collection.InsertMany(ctx, bson.A{
+		bson.D{{"_id", int32(1)}, {"v", bson.A{1.0, 0.0, 0.0}}, {"tag", "a"}},
+		bson.D{{"_id", int32(2)}, {"v", bson.A{0.0, 1.0, 0.0}}, {"tag", "b"}},
+		bson.D{{"_id", int32(3)}, {"v", bson.A{0.9, 0.1, 0.0}}, {"tag", "b"}},
+	})
+	require.NoError(t, err)
+
+	definition := bson.D{{"fields", bson.A{
+		bson.D{{"type", "vector"}, {"path", "v"}, {"numDimensions", int32(3)}, {"similarity", "cosine"}},
+		bson.D{{"type", "filter"}, {"path", "tag"}},
+	}}}
+
+	var res bson.D
+	err = db.RunCommand(ctx, bson.D{
+		{"createSearchIndexes", collection.Name()},
+		{"indexes", bson.A{bson.D{{"name", "vector"}, {"type", "vectorSearch"}, {"definition", definition}}}},
+	}).Decode(&res)
+	require.NoError(t, err)
+
+	created, ok := res.Map()["indexesCreated"].(bson.A)
+	require.True(t, ok, "%v", res)
+	require.Len(t, created, 1)
+	assert.Equal(t, "vector", created[0].(bson.D).Map()["name"])
+
+	t.Run("List", func(t *testing.T) {
+		cursor, err := collection.Aggregate(ctx, bson.A{bson.D{{"$listSearchIndexes", bson.D{}}}})
+		require.NoError(t, err)
+
+		indexes := FetchAll(t, ctx, cursor)
+		require.Len(t, indexes, 1)
+
+		index := indexes[0].Map()
+		assert.Equal(t, "vector", index["name"])
+		assert.Equal(t, "vectorSearch", index["type"])
+		assert.Equal(t, "READY", index["status"])
+	})
+
+	t.Run("VectorSearch", func(t *testing.T) {
+		pipeline := bson.A{
+			bson.D{{"$vectorSearch", bson.D{
+				{"index", "vector"},
+				{"path", "v"},
+				{"queryVector", bson.A{1.0, 0.0, 0.0}},
+				{"numCandidates", int32(10)},
+				{"limit", int32(2)},
+			}}},
+			bson.D{{"$project", bson.D{{"score", bson.D{{"$meta", "vectorSearchScore"}}}}}},
+		}
+
+		cursor, err := collection.Aggregate(ctx, pipeline)
+		require.NoError(t, err)
+
+		docs := FetchAll(t, ctx, cursor)
+		require.Len(t, docs, 2)
+		assert.Equal(t, int32(1), docs[0].Map()["_id"])
+		assert.Equal(t, int32(3), docs[1].Map()["_id"])
+		assert.Contains(t, docs[0].Map(), "score")
+	})
+
+	t.Run("Filter", func(t *testing.T) {
+		pipeline := bson.A{
+			bson.D{{"$vectorSearch", bson.D{
+				{"index", "vector"},
+				{"path", "v"},
+				{"queryVector", bson.A{1.0, 0.0, 0.0}},
+				{"numCandidates", int32(10)},
+				{"limit", int32(1)},
+				{"filter", bson.D{{"tag", "b"}}},
+			}}},
+			bson.D{{"$project", bson.D{{"_id", 1}}}},
+		}
+
+		cursor, err := collection.Aggregate(ctx, pipeline)
+		require.NoError(t, err)
+
+		AssertEqualDocumentsSlice(t, []bson.D{{{"_id", int32(3)}}}, FetchAll(t, ctx, cursor))
+	})
+
+	t.Run("UnknownIndex", func(t *testing.T) {
+		pipeline := bson.A{bson.D{{"$vectorSearch", bson.D{
+			{"index", "unknown"},
+			{"path", "v"},
+			{"queryVector", bson.A{1.0, 0.0, 0.0}},
+			{"numCandidates", int32(10)},
+			{"limit", int32(1)},
+		}}}}
+
+		cursor, err := collection.Aggregate(ctx, pipeline)
+		require.NoError(t, err)
+		assert.Empty(t, FetchAll(t, ctx, cursor))
+	})
+
+	t.Run("Drop", func(t *testing.T) {
+		err := db.RunCommand(ctx, bson.D{{"dropSearchIndex", collection.Name()}, {"name", "vector"}}).Err()
+		require.NoError(t, err)
+
+		cursor, err := collection.Aggregate(ctx, bson.A{bson.D{{"$listSearchIndexes", bson.D{}}}})
+		require.NoError(t, err)
+		assert.Empty(t, FetchAll(t, ctx, cursor))
+
+		err = db.RunCommand(ctx, bson.D{{"dropSearchIndex", collection.Name()}, {"name", "vector"}}).Err()
+		AssertMatchesCommandError(t, mongo.CommandError{Code: 27, Name: "IndexNotFound"}, err)
+	})
+}
+
+func TestVectorSearchErrors(t *testing.T) {
+	setup.SkipForMongoDB(t, "Atlas Search is not available in MongoDB Community Edition")
+
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t)
+	db := collection.Database()
+
+	vectorField := func(similarity string, dimensions int32) bson.D {
+		return bson.D{{"fields", bson.A{bson.D{
+			{"type", "vector"}, {"path", "v"}, {"numDimensions", dimensions}, {"similarity", similarity},
+		}}}}
+	}
+
+	for name, tc := range map[string]struct {
+		index bson.D              // required, search index specification
+		err   *mongo.CommandError // required, expected error
+	}{
+		"SearchType": {
+			index: bson.D{{"definition", bson.D{{"mappings", bson.D{{"dynamic", true}}}}}},
+			err:   &mongo.CommandError{Code: 238, Name: "NotImplemented"},
+		},
+		"Similarity": {
+			index: bson.D{{"type", "vectorSearch"}, {"definition", vectorField("manhattan", 3)}},
+			err:   &mongo.CommandError{Code: 2, Name: "BadValue"},
+		},
+		"Dimensions": {
+			index: bson.D{{"type", "vectorSearch"}, {"definition", vectorField("cosine", 0)}},
+			err:   &mongo.CommandError{Code: 2, Name: "BadValue"},
+		},
+		"NoVectorField": {
+			index: bson.D{{"type", "vectorSearch"}, {"definition", bson.D{{"fields", bson.A{}}}}},
+			err:   &mongo.CommandError{Code: 2, Name: "BadValue"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := db.RunCommand(ctx, bson.D{
+				{"createSearchIndexes", collection.Name()},
+				{"indexes", bson.A{tc.index}},
+			}).Err()
+			AssertMatchesCommandError(t, *tc.err, err)
+		})
+	}
+
+	t.Run("NumCandidates", func(t *testing.T) {
+		t.Parallel()
+
+		pipeline := bson.A{bson.D{{"$vectorSearch", bson.D{
+			{"index", "vector"},
+			{"path", "v"},
+			{"queryVector", bson.A{1.0}},
+			{"numCandidates", int32(1)},
+			{"limit", int32(2)},
+		}}}}
+
+		_, err := collection.Aggregate(ctx, pipeline)
+		AssertMatchesCommandError(t, mongo.CommandError{Code: 2, Name: "BadValue"}, err)
+	})
+}
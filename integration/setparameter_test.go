@@ -0,0 +1,193 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/FerretDB/FerretDB/v2/integration/setup"
+)
+
+func TestSetParameterRuntime(t *testing.T) {
+	setup.SkipForMongoDB(t, "FerretDB-specific server parameters")
+
+	t.Parallel()
+
+	// parameters are changed for the whole in-process listener used only by this test
+	s := setup.SetupWithOpts(t, &setup.SetupOpts{
+		ListenerOpts: new(setup.ListenerOpts),
+	})
+
+	ctx, admin := s.Ctx, s.Collection.Database().Client().Database("admin")
+
+	var res bson.D
+	err := admin.RunCommand(ctx, bson.D{{"getCmdLineOpts", 1}}).Decode(&res)
+	require.NoError(t, err)
+
+	parsed, ok := res.Map()["parsed"].(bson.D)
+	require.True(t, ok, "%v", res)
+	startup, ok := parsed.Map()["setParameter"].(bson.D)
+	require.True(t, ok, "%v", parsed)
+	startupThreshold := startup.Map()["slowOpThresholdMs"]
+	require.NotNil(t, startupThreshold)
+
+	err = admin.RunCommand(ctx, bson.D{{"setParameter", 1}, {"slowOpThresholdMs", int32(1234)}}).Decode(&res)
+	require.NoError(t, err)
+	AssertEqualDocuments(t, bson.D{{"was", startupThreshold}, {"ok", float64(1)}}, res)
+
+	t.Cleanup(func() {
+		_ = admin.RunCommand(ctx, bson.D{{"setParameter", 1}, {"slowOpThresholdMs", startupThreshold}}).Err()
+	})
+
+	err = admin.RunCommand(ctx, bson.D{{"getParameter", 1}, {"slowOpThresholdMs", 1}}).Decode(&res)
+	require.NoError(t, err)
+	AssertEqualDocuments(t, bson.D{{"slowOpThresholdMs", int64(1234)}, {"ok", float64(1)}}, res)
+
+	err = admin.RunCommand(ctx, bson.D{{"getCmdLineOpts", 1}}).Decode(&res)
+	require.NoError(t, err)
+	parsed = res.Map()["parsed"].(bson.D)
+	startup = parsed.Map()["setParameter"].(bson.D)
+	assert.Equal(t, startupThreshold, startup.Map()["slowOpThresholdMs"], "startup value should not change")
+
+	t.Run("NotSettable", func(t *testing.T) {
+		err := admin.RunCommand(ctx, bson.D{{"setParameter", 1}, {"authenticationMechanisms", bson.A{"PLAIN"}}}).Err()
+		expected := mongo.CommandError{
+			Code:    2,
+			Name:    "BadValue",
+			Message: "not allowed to change [authenticationMechanisms] at runtime",
+		}
+		AssertEqualCommandError(t, expected, err)
+	})
+
+	t.Run("BadValue", func(t *testing.T) {
+		err := admin.RunCommand(ctx, bson.D{{"setParameter", 1}, {"slowOpThresholdMs", int32(-1)}}).Err()
+		expected := mongo.CommandError{
+			Code:    2,
+			Name:    "BadValue",
+			Message: "Invalid value for parameter slowOpThresholdMs: -1 is not in range [0, 9223372036854]",
+		}
+		AssertEqualCommandError(t, expected, err)
+
+		err = admin.RunCommand(ctx, bson.D{{"getParameter", 1}, {"slowOpThresholdMs", 1}}).Decode(&res)
+		require.NoError(t, err)
+		AssertEqualDocuments(t, bson.D{{"slowOpThresholdMs", int64(1234)}, {"ok", float64(1)}}, res)
+	})
+
+	t.Run("Atomic", func(t *testing.T) {
+		// the first change is valid, but nothing is applied because the second one is not
+		err := admin.RunCommand(ctx, bson.D{
+			{"setParameter", 1},
+			{"slowOpThresholdMs", int32(1)},
+			{"logLevel", int32(42)},
+		}).Err()
+		expected := mongo.CommandError{
+			Code:    2,
+			Name:    "BadValue",
+			Message: "Invalid value for parameter logLevel: 42 is not in range [-2, 5]",
+		}
+		AssertEqualCommandError(t, expected, err)
+
+		err = admin.RunCommand(ctx, bson.D{{"getParameter", 1}, {"slowOpThresholdMs", 1}}).Decode(&res)
+		require.NoError(t, err)
+		AssertEqualDocuments(t, bson.D{{"slowOpThresholdMs", int64(1234)}, {"ok", float64(1)}}, res)
+	})
+
+	t.Run("LogComponentVerbosity", func(t *testing.T) {
+		err := admin.RunCommand(ctx, bson.D{
+			{"setParameter", 1},
+			{"logComponentVerbosity", bson.D{{"pgx", bson.D{{"verbosity", int32(2)}}}}},
+		}).Decode(&res)
+		require.NoError(t, err)
+
+		was, ok := res.Map()["was"].(bson.D)
+		require.True(t, ok, "%v", res)
+		assert.Equal(t, bson.D{{"verbosity", int32(-1)}}, was.Map()["pgx"])
+
+		t.Cleanup(func() {
+			_ = admin.RunCommand(ctx, bson.D{
+				{"setParameter", 1},
+				{"logComponentVerbosity", bson.D{{"pgx", bson.D{{"verbosity", int32(-1)}}}}},
+			}).Err()
+		})
+
+		err = admin.RunCommand(ctx, bson.D{{"getParameter", 1}, {"logComponentVerbosity", 1}}).Decode(&res)
+		require.NoError(t, err)
+
+		v, ok := res.Map()["logComponentVerbosity"].(bson.D)
+		require.True(t, ok, "%v", res)
+		assert.Equal(t, was.Map()["verbosity"], v.Map()["verbosity"], "global verbosity should not change")
+		assert.Equal(t, bson.D{{"verbosity", int32(2)}}, v.Map()["pgx"])
+		assert.Equal(t, bson.D{{"verbosity", int32(-1)}}, v.Map()["pool"])
+
+		err = admin.RunCommand(ctx, bson.D{
+			{"setParameter", 1},
+			{"logComponentVerbosity", bson.D{{"unknown", bson.D{{"verbosity", int32(1)}}}}},
+		}).Err()
+		expected := mongo.CommandError{
+			Code:    2,
+			Name:    "BadValue",
+			Message: "Invalid component name logComponentVerbosity.unknown",
+		}
+		AssertEqualCommandError(t, expected, err)
+	})
+
+	t.Run("Unprivileged", func(t *testing.T) {
+		db := s.Collection.Database()
+		username, password, mechanism := "setparameteruser", "password", "SCRAM-SHA-256"
+
+		// TODO https://github.com/FerretDB/FerretDB-DocumentDB/issues/864
+		_ = db.RunCommand(ctx, bson.D{{"dropUser", username}})
+
+		err := db.RunCommand(ctx, bson.D{
+			{"createUser", username},
+			{"roles", bson.A{}},
+			{"pwd", password},
+			{"mechanisms", bson.A{mechanism}},
+		}).Err()
+		require.NoError(t, err)
+
+		credential := options.Credential{
+			AuthMechanism: mechanism,
+			AuthSource:    db.Name(),
+			Username:      username,
+			Password:      password,
+		}
+
+		client, err := mongo.Connect(ctx, options.Client().ApplyURI(s.MongoDBURI).SetAuth(credential))
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			require.NoError(t, client.Disconnect(ctx))
+		})
+
+		err = client.Database("admin").RunCommand(ctx, bson.D{{"setParameter", 1}, {"slowOpThresholdMs", int32(1)}}).Err()
+		expected := mongo.CommandError{
+			Code:    13,
+			Name:    "Unauthorized",
+			Message: "not authorized on admin to execute command setParameter",
+		}
+		AssertEqualCommandError(t, expected, err)
+
+		err = admin.RunCommand(ctx, bson.D{{"getParameter", 1}, {"slowOpThresholdMs", 1}}).Decode(&res)
+		require.NoError(t, err)
+		AssertEqualDocuments(t, bson.D{{"slowOpThresholdMs", int64(1234)}, {"ok", float64(1)}}, res)
+	})
+}
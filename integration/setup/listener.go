@@ -35,6 +35,17 @@
 type ListenerOpts struct {
 	// SessionCleanupInterval is a duration between expired session deletion runs.
 	SessionCleanupInterval time.Duration
+
+	// TTLMonitorInterval is a duration between TTL monitor passes.
+	TTLMonitorInterval time.Duration
+
+	// MaxRequests is a maximum number of concurrently handled requests.
+	// Zero value means PostgreSQL pool size.
+	MaxRequests int
+
+	// AdmissionMaxWait is a maximum time requests over limits wait to be handled.
+	// Zero value means no limit.
+	AdmissionMaxWait time.Duration
 }
 
 // unixSocketPath returns temporary Unix domain socket path for that test.
@@ -116,12 +127,28 @@ func setupListener(tb testing.TB, ctx context.Context, opts *ListenerOpts, logge
 		PostgreSQLURL:          *postgreSQLURLF,
 		Auth:                   true,
 		ReplSetName:            "", // TODO https://github.com/FerretDB/FerretDB-DocumentDB/issues/566
+		ReadOnly:               false,
 		SessionCleanupInterval: opts.SessionCleanupInterval,
+		TTLMonitorInterval:     opts.TTLMonitorInterval,
+		TTLMonitorBatchSize:    0,
+		DefaultMaxTime:         0,
+		SlowOpThreshold:        0,
+		MaxRequests:            opts.MaxRequests,
+		MaxUserRequests:        0,
+		AdmissionMaxWait:       opts.AdmissionMaxWait,
 
 		ProxyAddr:        "",
 		ProxyTLSCertFile: "",
 		ProxyTLSKeyFile:  "",
 		ProxyTLSCAFile:   "",
+		ProxyPoolSize:    0,
+		ProxyPoolWait:    0,
+
+		ProxyUsername:      "",
+		ProxyPassword:      "",
+		ProxyAuthDB:        "",
+		ProxyAuthMechanism: "",
+		ProxyUserMapFile:   "",
 
 		TCPAddr:        "",
 		UnixAddr:       "",
@@ -129,9 +156,13 @@ func setupListener(tb testing.TB, ctx context.Context, opts *ListenerOpts, logge
 		TLSCertFile:    "",
 		TLSKeyFile:     "",
 		TLSCAFile:      "",
+		MaxConnections: 0,
 		Mode:           middleware.NormalMode,
 		TestRecordsDir: testutil.TmpRecordsDir,
 
+		RoutingFile:           "",
+		RoutingReloadInterval: 0,
+
 		DataAPIAddr: "",
 	}
 
@@ -0,0 +1,200 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/FerretDB/FerretDB/v2/integration/setup"
+)
+
+func TestTextSearch(t *testing.T) {
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t)
+
+	_, err := collection.InsertMany(ctx, bson.A{
+		bson.D{{"_id", int32(1)}, {"title", "Moby Dick"}, {"summary", "A captain hunts the white whale across the sea"}},
+		bson.D{{"_id", int32(2)}, {"title", "Pride and Prejudice"}, {"summary", "A romance between Elizabeth and Darcy"}},
+		bson.D{{"_id", int32(3)}, {"title", "The Old Man and the Sea"}, {"summary", "An old fisherman struggles with a giant fish"}},
+		bson.D{{"_id", int32(4)}, {"title", "Whale Songs"}, {"summary", "Essays about whales and the ocean"}},
+	})
+	require.NoError(t, err)
+
+	_, err = collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{"title", "text"}, {"summary", "text"}},
+		Options: options.Index().SetName("text").SetWeights(bson.D{{"title", int32(10)}}).SetDefaultLanguage("english"),
+	})
+	require.NoError(t, err)
+
+	t.Run("ListIndexes", func(t *testing.T) {
+		cursor, err := collection.Indexes().List(ctx)
+		require.NoError(t, err)
+
+		indexes := FetchAll(t, ctx, cursor)
+		require.Len(t, indexes, 2)
+
+		index := indexes[1].Map()
+		assert.Equal(t, "text", index["name"])
+		assert.Equal(t, "english", index["default_language"])
+		assert.Equal(t, "language", index["language_override"])
+		assert.Equal(t, bson.D{{"summary", int32(1)}, {"title", int32(10)}}, index["weights"])
+	})
+
+	for name, tc := range map[string]struct {
+		search   string // required, $text.$search
+		expected []any  // expected _id values in any order
+	}{
+		"Stemming": {
+			search:   "whale",
+			expected: []any{int32(1), int32(4)},
+		},
+		"AnyTerm": {
+			search:   "romance fisherman",
+			expected: []any{int32(2), int32(3)},
+		},
+		"Phrase": {
+			search:   `"white whale"`,
+			expected: []any{int32(1)},
+		},
+		"Negation": {
+			search:   "sea -captain",
+			expected: []any{int32(3)},
+		},
+		"NoMatch": {
+			search:   "spaceship",
+			expected: []any{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cursor, err := collection.Find(ctx, bson.D{{"$text", bson.D{{"$search", tc.search}}}})
+			require.NoError(t, err)
+
+			assert.ElementsMatch(t, tc.expected, CollectIDs(t, FetchAll(t, ctx, cursor)))
+		})
+	}
+
+	t.Run("ScoreSort", func(t *testing.T) {
+		t.Parallel()
+
+		score := bson.D{{"score", bson.D{{"$meta", "textScore"}}}}
+		opts := options.Find().SetProjection(score).SetSort(score)
+
+		cursor, err := collection.Find(ctx, bson.D{{"$text", bson.D{{"$search", "whale"}}}}, opts)
+		require.NoError(t, err)
+
+		docs := FetchAll(t, ctx, cursor)
+
+		// title has a higher weight
+		assert.Equal(t, []any{int32(4), int32(1)}, CollectIDs(t, docs))
+
+		for _, doc := range docs {
+			assert.IsType(t, float64(0), doc.Map()["score"])
+		}
+	})
+
+	t.Run("Aggregate", func(t *testing.T) {
+		t.Parallel()
+
+		pipeline := bson.A{
+			bson.D{{"$match", bson.D{{"$text", bson.D{{"$search", "whale"}}}}}},
+			bson.D{{"$sort", bson.D{{"score", bson.D{{"$meta", "textScore"}}}}}},
+			bson.D{{"$project", bson.D{{"score", bson.D{{"$meta", "textScore"}}}}}},
+		}
+
+		cursor, err := collection.Aggregate(ctx, pipeline)
+		require.NoError(t, err)
+
+		assert.Equal(t, []any{int32(4), int32(1)}, CollectIDs(t, FetchAll(t, ctx, cursor)))
+	})
+}
+
+func TestTextSearchIndexOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Wildcard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, collection := setup.Setup(t)
+
+		_, err := collection.InsertMany(ctx, bson.A{
+			bson.D{{"_id", int32(1)}, {"a", "red apple"}},
+			bson.D{{"_id", int32(2)}, {"b", bson.D{{"c", "green apple"}}}},
+			bson.D{{"_id", int32(3)}, {"a", "banana"}},
+		})
+		require.NoError(t, err)
+
+		_, err = collection.Indexes().CreateOne(ctx, mongo.IndexModel{Keys: bson.D{{"$**", "text"}}})
+		require.NoError(t, err)
+
+		cursor, err := collection.Find(ctx, bson.D{{"$text", bson.D{{"$search", "apple"}}}})
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []any{int32(1), int32(2)}, CollectIDs(t, FetchAll(t, ctx, cursor)))
+	})
+
+	t.Run("LanguageOverride", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, collection := setup.Setup(t)
+
+		_, err := collection.InsertMany(ctx, bson.A{
+			bson.D{{"_id", int32(1)}, {"text", "running"}, {"lang", "english"}},
+			bson.D{{"_id", int32(2)}, {"text", "running"}, {"lang", "none"}},
+		})
+		require.NoError(t, err)
+
+		_, err = collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{"text", "text"}},
+			Options: options.Index().SetLanguageOverride("lang"),
+		})
+		require.NoError(t, err)
+
+		// only the English document is stemmed
+		cursor, err := collection.Find(ctx, bson.D{{"$text", bson.D{{"$search", "run"}}}})
+		require.NoError(t, err)
+
+		assert.Equal(t, []any{int32(1)}, CollectIDs(t, FetchAll(t, ctx, cursor)))
+	})
+
+	t.Run("Collation", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, collection := setup.Setup(t)
+
+		_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{"v", "text"}},
+			Options: options.Index().SetCollation(&options.Collation{Locale: "en"}),
+		})
+		AssertMatchesCommandError(t, mongo.CommandError{Code: 67, Name: "CannotCreateIndex"}, err)
+	})
+
+	t.Run("NoIndex", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, collection := setup.Setup(t)
+
+		_, err := collection.Find(ctx, bson.D{{"$text", bson.D{{"$search", "apple"}}}})
+		AssertMatchesCommandError(t, mongo.CommandError{Code: 27, Name: "IndexNotFound"}, err)
+	})
+}
@@ -0,0 +1,155 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/FerretDB/FerretDB/v2/integration/setup"
+)
+
+func TestTimeSeries(t *testing.T) {
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t)
+	db := collection.Database()
+
+	tsOpts := options.TimeSeries().SetTimeField("ts").SetMetaField("sensor").SetGranularity("minutes")
+	opts := options.CreateCollection().SetTimeSeriesOptions(tsOpts).SetExpireAfterSeconds(3600)
+	require.NoError(t, db.CreateCollection(ctx, collection.Name(), opts))
+
+	now := primitive.NewDateTimeFromTime(time.Now().Truncate(time.Millisecond))
+
+	_, err := collection.InsertMany(ctx, bson.A{
+		bson.D{{"_id", "a"}, {"ts", now}, {"sensor", "s1"}, {"v", int32(1)}},
+		bson.D{{"_id", "b"}, {"ts", now}, {"sensor", "s2"}, {"v", int32(2)}},
+	})
+	require.NoError(t, err)
+
+	t.Run("Find", func(t *testing.T) {
+		cursor, err := collection.Find(ctx, bson.D{{"sensor", "s2"}})
+		require.NoError(t, err)
+
+		// MongoDB reconstructs documents from buckets with a different field order
+		res := FetchAll(t, ctx, cursor)
+		require.Len(t, res, 1)
+
+		expected := bson.M{"_id": "b", "ts": now, "sensor": "s2", "v": int32(2)}
+		assert.Equal(t, expected, res[0].Map())
+	})
+
+	t.Run("InsertInvalid", func(t *testing.T) {
+		_, err := collection.InsertMany(ctx, bson.A{
+			bson.D{{"_id", "c"}, {"ts", now}},
+			bson.D{{"_id", "d"}, {"ts", "not a date"}},
+			bson.D{{"_id", "e"}, {"ts", now}},
+		}, options.InsertMany().SetOrdered(false))
+
+		expected := mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{{WriteError: mongo.WriteError{
+			Index:   1,
+			Code:    2,
+			Message: "'ts' must be present and contain a valid BSON UTC datetime value",
+		}}}}
+		AssertMatchesBulkException(t, expected, err)
+
+		n, err := collection.CountDocuments(ctx, bson.D{})
+		require.NoError(t, err)
+		assert.EqualValues(t, 4, n)
+	})
+
+	t.Run("ListCollections", func(t *testing.T) {
+		cursor, err := db.ListCollections(ctx, bson.D{{"name", collection.Name()}})
+		require.NoError(t, err)
+
+		res := FetchAll(t, ctx, cursor)
+		require.Len(t, res, 1)
+
+		m := res[0].Map()
+		assert.Equal(t, "timeseries", m["type"])
+
+		opts, ok := m["options"].(bson.D)
+		require.True(t, ok)
+
+		optsMap := opts.Map()
+		assert.EqualValues(t, 3600, optsMap["expireAfterSeconds"])
+
+		ts, ok := optsMap["timeseries"].(bson.D)
+		require.True(t, ok)
+
+		tsMap := ts.Map()
+		assert.Equal(t, "ts", tsMap["timeField"])
+		assert.Equal(t, "sensor", tsMap["metaField"])
+		assert.Equal(t, "minutes", tsMap["granularity"])
+		assert.Equal(t, int32(86400), tsMap["bucketMaxSpanSeconds"])
+	})
+
+	t.Run("ListIndexes", func(t *testing.T) {
+		names, err := collection.Indexes().ListSpecifications(ctx)
+		require.NoError(t, err)
+
+		var found bool
+		for _, spec := range names {
+			if spec.Name == "sensor_1_ts_1" {
+				found = true
+			}
+		}
+
+		assert.True(t, found)
+	})
+}
+
+func TestTimeSeriesCreateErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t)
+	db := collection.Database()
+
+	for name, tc := range map[string]struct {
+		command bson.D              // required, command to run
+		err     *mongo.CommandError // required, expected error from MongoDB
+	}{
+		"TimeFieldMissing": {
+			command: bson.D{{"create", collection.Name()}, {"timeseries", bson.D{{"metaField", "m"}}}},
+			err: &mongo.CommandError{
+				Code:    40414,
+				Name:    "Location40414",
+				Message: "BSON field 'create.timeseries.timeField' is missing but a required field",
+			},
+		},
+		"Granularity": {
+			command: bson.D{{"create", collection.Name()}, {"timeseries", bson.D{{"timeField", "t"}, {"granularity", "days"}}}},
+			err: &mongo.CommandError{
+				Code:    2,
+				Name:    "BadValue",
+				Message: "Enumeration value 'days' for field 'granularity' is not a valid value.",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := db.RunCommand(ctx, tc.command).Err()
+			AssertMatchesCommandError(t, *tc.err, err)
+		})
+	}
+}
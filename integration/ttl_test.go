@@ -0,0 +1,132 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/FerretDB/FerretDB/v2/integration/setup"
+)
+
+func TestTTLMonitor(t *testing.T) {
+	setup.SkipForMongoDB(t, "MongoDB's TTL monitor interval could not be configured per test")
+
+	t.Parallel()
+
+	s := setup.SetupWithOpts(t, &setup.SetupOpts{
+		ListenerOpts: &setup.ListenerOpts{TTLMonitorInterval: 100 * time.Millisecond},
+	})
+
+	ctx, collection := s.Ctx, s.Collection
+
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{"expiresAt", 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	_, err = collection.InsertMany(ctx, bson.A{
+		bson.D{{"_id", "expired"}, {"expiresAt", now.Add(-time.Hour)}},
+		bson.D{{"_id", "future"}, {"expiresAt", now.Add(time.Hour)}},
+		bson.D{{"_id", "string"}, {"expiresAt", "not a date"}},
+		bson.D{{"_id", "missing"}},
+	})
+	require.NoError(t, err)
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		cursor, err := collection.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{"_id", 1}}))
+		require.NoError(c, err)
+
+		var res []bson.D
+		require.NoError(c, cursor.All(ctx, &res))
+
+		ids := make([]any, len(res))
+		for i, doc := range res {
+			ids[i] = doc.Map()["_id"]
+		}
+
+		assert.Equal(c, []any{"future", "missing", "string"}, ids)
+	}, 10*time.Second, 100*time.Millisecond)
+
+	var res bson.D
+	err = collection.Database().RunCommand(ctx, bson.D{{"serverStatus", 1}}).Decode(&res)
+	require.NoError(t, err)
+
+	metrics, ok := res.Map()["metrics"].(bson.D)
+	require.True(t, ok)
+
+	ttl, ok := metrics.Map()["ttl"].(bson.D)
+	require.True(t, ok)
+
+	ttlMap := ttl.Map()
+	assert.Positive(t, ttlMap["passes"])
+	assert.Positive(t, ttlMap["subPasses"])
+	assert.Positive(t, ttlMap["deletedDocuments"])
+}
+
+func TestTTLMonitorClustered(t *testing.T) {
+	setup.SkipForMongoDB(t, "MongoDB's TTL monitor interval could not be configured per test")
+
+	t.Parallel()
+
+	s := setup.SetupWithOpts(t, &setup.SetupOpts{
+		ListenerOpts: &setup.ListenerOpts{TTLMonitorInterval: 100 * time.Millisecond},
+	})
+
+	ctx, collection := s.Ctx, s.Collection
+
+	command := bson.D{
+		{"create", collection.Name()},
+		{"clusteredIndex", bson.D{{"key", bson.D{{"_id", 1}}}, {"unique", true}}},
+		{"expireAfterSeconds", int64(3600)},
+	}
+	require.NoError(t, collection.Database().RunCommand(ctx, command).Err())
+
+	now := time.Now()
+
+	expiredID := primitive.NewObjectIDFromTimestamp(now.Add(-2 * time.Hour))
+	freshID := primitive.NewObjectIDFromTimestamp(now)
+
+	_, err := collection.InsertMany(ctx, bson.A{
+		bson.D{{"_id", expiredID}},
+		bson.D{{"_id", freshID}},
+		bson.D{{"_id", now.Add(-2 * time.Hour)}},
+		bson.D{{"_id", now}},
+		bson.D{{"_id", "string"}},
+	})
+	require.NoError(t, err)
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		n, err := collection.CountDocuments(ctx, bson.D{})
+		require.NoError(c, err)
+		assert.Equal(c, int64(3), n)
+	}, 10*time.Second, 100*time.Millisecond)
+
+	for _, id := range []any{expiredID, primitive.NewDateTimeFromTime(now.Add(-2 * time.Hour))} {
+		n, err := collection.CountDocuments(ctx, bson.D{{"_id", id}})
+		require.NoError(t, err)
+		assert.Zero(t, n, "%v", id)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/FerretDB/FerretDB/v2/integration/setup"
+)
+
+// testValidator requires documents to have a string field `name`.
+var testValidator = bson.D{{"$jsonSchema", bson.D{
+	{"bsonType", "object"},
+	{"required", bson.A{"name"}},
+	{"properties", bson.D{{"name", bson.D{{"bsonType", "string"}}}}},
+}}}
+
+func TestValidator(t *testing.T) {
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t)
+	db := collection.Database()
+
+	err := db.CreateCollection(ctx, collection.Name(), options.CreateCollection().SetValidator(testValidator))
+	require.NoError(t, err)
+
+	_, err = collection.InsertOne(ctx, bson.D{{"_id", "valid"}, {"name", "foo"}})
+	require.NoError(t, err)
+
+	t.Run("Insert", func(t *testing.T) {
+		_, err := collection.InsertOne(ctx, bson.D{{"_id", "invalid"}, {"name", int32(42)}})
+
+		expected := mongo.WriteException{WriteErrors: []mongo.WriteError{{
+			Code:    121,
+			Message: "Document failed validation",
+		}}}
+		AssertMatchesWriteError(t, expected, err)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		_, err := collection.UpdateOne(ctx, bson.D{{"_id", "valid"}}, bson.D{{"$unset", bson.D{{"name", ""}}}})
+
+		expected := mongo.WriteException{WriteErrors: []mongo.WriteError{{
+			Code:    121,
+			Message: "Document failed validation",
+		}}}
+		AssertMatchesWriteError(t, expected, err)
+	})
+
+	t.Run("FindAndModify", func(t *testing.T) {
+		err := db.RunCommand(ctx, bson.D{
+			{"findAndModify", collection.Name()},
+			{"query", bson.D{{"_id", "valid"}}},
+			{"update", bson.D{{"$set", bson.D{{"name", int32(42)}}}}},
+		}).Err()
+
+		expected := mongo.CommandError{
+			Code:    121,
+			Name:    "DocumentValidationFailure",
+			Message: "Document failed validation",
+		}
+		AssertMatchesCommandError(t, expected, err)
+	})
+
+	t.Run("ListCollections", func(t *testing.T) {
+		cursor, err := db.ListCollections(ctx, bson.D{{"name", collection.Name()}})
+		require.NoError(t, err)
+
+		res := FetchAll(t, ctx, cursor)
+		require.Len(t, res, 1)
+
+		opts, ok := res[0].Map()["options"].(bson.D)
+		require.True(t, ok)
+
+		m := opts.Map()
+		assert.Contains(t, m, "validator")
+		assert.Equal(t, "strict", m["validationLevel"])
+		assert.Equal(t, "error", m["validationAction"])
+	})
+
+	t.Run("CollMod", func(t *testing.T) {
+		err := db.RunCommand(ctx, bson.D{
+			{"collMod", collection.Name()},
+			{"validator", testValidator},
+			{"validationAction", "warn"},
+		}).Err()
+		require.NoError(t, err)
+
+		_, err = collection.InsertOne(ctx, bson.D{{"_id", "warn"}, {"name", int32(42)}})
+		require.NoError(t, err)
+	})
+}
+
+func TestValidatorCreateErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t)
+	db := collection.Database()
+
+	for name, tc := range map[string]struct {
+		command bson.D              // required, command to run
+		err     *mongo.CommandError // required, expected error from MongoDB
+	}{
+		"ValidationLevel": {
+			command: bson.D{{"create", collection.Name()}, {"validationLevel", "foo"}},
+			err: &mongo.CommandError{
+				Code:    2,
+				Name:    "BadValue",
+				Message: "Enumeration value 'foo' for field 'validationLevel' is not a valid value.",
+			},
+		},
+		"ValidationAction": {
+			command: bson.D{{"create", collection.Name()}, {"validationAction", "foo"}},
+			err: &mongo.CommandError{
+				Code:    2,
+				Name:    "BadValue",
+				Message: "Enumeration value 'foo' for field 'validationAction' is not a valid value.",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := db.RunCommand(ctx, tc.command).Err()
+			AssertMatchesCommandError(t, *tc.err, err)
+		})
+	}
+
+	t.Run("UnknownField", func(t *testing.T) {
+		t.Parallel()
+
+		err := db.RunCommand(ctx, bson.D{{"create", collection.Name()}, {"foo", int32(1)}}).Err()
+
+		// MongoDB uses Location40415 as a code name
+		var ce mongo.CommandError
+		require.ErrorAs(t, err, &ce)
+		assert.Equal(t, int32(40415), ce.Code)
+		assert.Equal(t, "BSON field 'create.foo' is an unknown field.", ce.Message)
+	})
+}
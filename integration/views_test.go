@@ -0,0 +1,150 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/FerretDB/FerretDB/v2/integration/setup"
+)
+
+func TestViews(t *testing.T) {
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t)
+	db := collection.Database()
+
+	_, err := collection.InsertMany(ctx, bson.A{
+		bson.D{{"_id", "a"}, {"v", int32(1)}, {"secret", "x"}},
+		bson.D{{"_id", "b"}, {"v", int32(2)}, {"secret", "y"}},
+		bson.D{{"_id", "c"}, {"v", int32(3)}, {"secret", "z"}},
+	})
+	require.NoError(t, err)
+
+	pipeline := bson.A{
+		bson.D{{"$match", bson.D{{"v", bson.D{{"$gt", int32(1)}}}}}},
+		bson.D{{"$project", bson.D{{"secret", int32(0)}}}},
+	}
+
+	viewName := collection.Name() + "_view"
+
+	err = db.CreateView(ctx, viewName, collection.Name(), pipeline)
+	require.NoError(t, err)
+
+	view := db.Collection(viewName)
+
+	t.Run("Find", func(t *testing.T) {
+		cursor, err := view.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{"_id", 1}}))
+		require.NoError(t, err)
+
+		expected := []bson.D{
+			{{"_id", "b"}, {"v", int32(2)}},
+			{{"_id", "c"}, {"v", int32(3)}},
+		}
+		AssertEqualDocumentsSlice(t, expected, FetchAll(t, ctx, cursor))
+	})
+
+	t.Run("ListCollections", func(t *testing.T) {
+		cursor, err := db.ListCollections(ctx, bson.D{{"name", viewName}})
+		require.NoError(t, err)
+
+		res := FetchAll(t, ctx, cursor)
+		require.Len(t, res, 1)
+
+		m := res[0].Map()
+		assert.Equal(t, viewName, m["name"])
+		assert.Equal(t, "view", m["type"])
+
+		opts, ok := m["options"].(bson.D)
+		require.True(t, ok)
+		assert.Equal(t, collection.Name(), opts.Map()["viewOn"])
+	})
+
+	t.Run("Write", func(t *testing.T) {
+		for name, command := range map[string]bson.D{
+			"Insert": {{"insert", viewName}, {"documents", bson.A{bson.D{{"_id", "d"}}}}},
+			"Update": {{"update", viewName}, {"updates", bson.A{bson.D{{"q", bson.D{}}, {"u", bson.D{}}}}}},
+			"Delete": {{"delete", viewName}, {"deletes", bson.A{bson.D{{"q", bson.D{}}, {"limit", 0}}}}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				err := db.RunCommand(ctx, command).Err()
+
+				expected := mongo.CommandError{
+					Code:    166,
+					Name:    "CommandNotSupportedOnView",
+					Message: "Namespace " + db.Name() + "." + viewName + " is a view, not a collection",
+				}
+				AssertMatchesCommandError(t, expected, err)
+			})
+		}
+	})
+
+	t.Run("CollMod", func(t *testing.T) {
+		err := db.RunCommand(ctx, bson.D{
+			{"collMod", viewName},
+			{"viewOn", collection.Name()},
+			{"pipeline", bson.A{bson.D{{"$match", bson.D{{"v", int32(1)}}}}}},
+		}).Err()
+		require.NoError(t, err)
+
+		cursor, err := view.Find(ctx, bson.D{})
+		require.NoError(t, err)
+
+		expected := []bson.D{{{"_id", "a"}, {"v", int32(1)}, {"secret", "x"}}}
+		AssertEqualDocumentsSlice(t, expected, FetchAll(t, ctx, cursor))
+	})
+}
+
+func TestViewsCreateErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx, collection := setup.Setup(t)
+	db := collection.Database()
+
+	for name, tc := range map[string]struct {
+		command bson.D              // required, command to run
+		err     *mongo.CommandError // required, expected error from MongoDB
+	}{
+		"PipelineWithoutViewOn": {
+			command: bson.D{{"create", "view"}, {"pipeline", bson.A{}}},
+			err: &mongo.CommandError{
+				Code:    72,
+				Name:    "InvalidOptions",
+				Message: "'pipeline' requires 'viewOn' to also be specified",
+			},
+		},
+		"ViewOnType": {
+			command: bson.D{{"create", "view"}, {"viewOn", int32(1)}},
+			err: &mongo.CommandError{
+				Code:    14,
+				Name:    "TypeMismatch",
+				Message: "BSON field 'create.viewOn' is the wrong type 'int', expected type 'string'",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := db.RunCommand(ctx, tc.command).Err()
+			AssertMatchesCommandError(t, *tc.err, err)
+		})
+	}
+}
@@ -29,6 +29,7 @@
 	"net/netip"
 	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/AlekSi/lazyerrors"
@@ -45,6 +46,9 @@ type conn struct {
 	l              *slog.Logger
 	m              *middleware.Middleware
 	testRecordsDir string // if empty, no records are created
+
+	// arrival times of recorded requests, stored next to the record file
+	recordTimes []time.Time
 }
 
 // run runs the client connection until ctx is canceled, client disconnects,
@@ -126,6 +130,10 @@ func (c *conn) processRequest(ctx context.Context, bufr *bufio.Reader, bufw *buf
 		return lazyerrors.Error(err)
 	}
 
+	if c.testRecordsDir != "" {
+		c.recordTimes = append(c.recordTimes, time.Now())
+	}
+
 	req, err := middleware.RequestWire(reqHeader, reqBody)
 	if err != nil {
 		return lazyerrors.Error(err)
@@ -150,6 +158,7 @@ func (c *conn) processRequest(ctx context.Context, bufr *bufio.Reader, bufw *buf
 // renamePartialFile takes over an open file `f` and closes it.
 // It uses the given error to check if the connection was closed by the client,
 // if so the given file is renamed to a name generated by hash,
+// and requests' arrival times are written next to it;
 // otherwise, it deletes the given file.
 func (c *conn) renamePartialFile(ctx context.Context, f *os.File, h hash.Hash, err error) {
 	// do not store partial files
@@ -180,4 +189,15 @@ func (c *conn) renamePartialFile(ctx context.Context, f *os.File, h hash.Hash, e
 	if e := os.Rename(f.Name(), path); e != nil {
 		c.l.WarnContext(ctx, "Failed to rename file", logging.Error(e))
 	}
+
+	// one Unix time in microseconds per line, in the order of requests
+	var b []byte
+	for _, t := range c.recordTimes {
+		b = strconv.AppendInt(b, t.UnixMicro(), 10)
+		b = append(b, '\n')
+	}
+
+	if e := os.WriteFile(filepath.Join(hashPath, fileName+".times"), b, 0o666); e != nil {
+		c.l.WarnContext(ctx, "Failed to write times file", logging.Error(e))
+	}
 }
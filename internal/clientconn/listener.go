@@ -25,6 +25,7 @@
 	"net"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/AlekSi/lazyerrors"
@@ -48,13 +49,17 @@ type Listener struct {
 	unixListener net.Listener
 	tlsListener  net.Listener
 
+	// conns is the number of open connections for all listeners.
+	conns atomic.Int64
+
 	listenersClosed chan struct{}
 }
 
 // ListenerOpts represents listener configuration.
 type ListenerOpts struct {
-	M      *middleware.Middleware
-	Logger *slog.Logger
+	M       *middleware.Middleware
+	Metrics *middleware.Metrics // may be nil
+	Logger  *slog.Logger
 
 	TCP  string // empty value disables TCP listener
 	Unix string // empty value disables Unix listener
@@ -64,6 +69,11 @@ type ListenerOpts struct {
 	TLSKeyFile  string
 	TLSCAFile   string
 
+	// MaxConnections is a maximum number of open client connections.
+	// Connections over the limit are closed right after accepting, as MongoDB does.
+	// Zero value means no limit.
+	MaxConnections int
+
 	Mode             middleware.Mode
 	ProxyAddr        string
 	ProxyTLSCertFile string
@@ -127,6 +137,10 @@ func Listen(opts *ListenerOpts) (l *Listener, err error) {
 		listenersClosed: make(chan struct{}),
 	}
 
+	if opts.Metrics != nil {
+		opts.Metrics.SetMaxConnections(int64(opts.MaxConnections))
+	}
+
 	defer func() {
 		if err != nil {
 			l.close()
@@ -275,9 +289,28 @@ func acceptLoop(ctx context.Context, listener net.Listener, wg *sync.WaitGroup,
 			continue
 		}
 
-		wg.Add(1)
 		l.lm.accepts.WithLabelValues("0").Inc()
 
+		if n := l.conns.Add(1); l.MaxConnections > 0 && n > int64(l.MaxConnections) {
+			l.conns.Add(-1)
+			l.lm.rejects.Inc()
+
+			l.ll.WarnContext(
+				ctx, "Connection refused because there are too many open connections",
+				slog.String("remote", netConn.RemoteAddr().String()), slog.Int64("connectionCount", n-1),
+			)
+
+			netConn.Close()
+
+			continue
+		}
+
+		wg.Add(1)
+
+		if l.Metrics != nil {
+			l.Metrics.ConnectionOpened()
+		}
+
 		go func() {
 			var connErr error
 			start := time.Now()
@@ -289,6 +322,12 @@ func acceptLoop(ctx context.Context, listener net.Listener, wg *sync.WaitGroup,
 				}
 
 				l.lm.durations.WithLabelValues(lv).Observe(time.Since(start).Seconds())
+
+				if l.Metrics != nil {
+					l.Metrics.ConnectionClosed()
+				}
+
+				l.conns.Add(-1)
 				netConn.Close()
 				wg.Done()
 			}()
@@ -30,6 +30,7 @@
 // listenerMetrics represents listener metrics.
 type listenerMetrics struct {
 	accepts   *prometheus.CounterVec
+	rejects   prometheus.Counter
 	durations *prometheus.HistogramVec
 }
 
@@ -45,6 +46,14 @@ func NewListenerMetrics() *listenerMetrics {
 			},
 			[]string{"error"},
 		),
+		rejects: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "rejects_total",
+				Help:      "Total number of client connections closed because of the connections limit.",
+			},
+		),
 		durations: prometheus.NewHistogramVec(
 			prometheus.HistogramOpts{
 				Namespace: namespace,
@@ -75,12 +84,14 @@ func NewListenerMetrics() *listenerMetrics {
 // Describe implements [prometheus.Collector].
 func (lm *listenerMetrics) Describe(ch chan<- *prometheus.Desc) {
 	lm.accepts.Describe(ch)
+	lm.rejects.Describe(ch)
 	lm.durations.Describe(ch)
 }
 
 // Collect implements [prometheus.Collector].
 func (lm *listenerMetrics) Collect(ch chan<- prometheus.Metric) {
 	lm.accepts.Collect(ch)
+	lm.rejects.Collect(ch)
 	lm.durations.Collect(ch)
 }
 
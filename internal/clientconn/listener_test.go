@@ -15,12 +15,16 @@
 package clientconn
 
 import (
+	"context"
+	"io"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
 	"github.com/FerretDB/FerretDB/v2/internal/util/testutil"
 )
 
@@ -38,3 +42,54 @@ func TestListener(t *testing.T) {
 
 	assert.Nil(t, l.UnixAddr())
 }
+
+func TestListenerMaxConnections(t *testing.T) {
+	metrics := middleware.NewMetrics()
+
+	l, err := Listen(&ListenerOpts{
+		Metrics:        metrics,
+		Logger:         testutil.Logger(t),
+		TCP:            "127.0.0.1:0",
+		MaxConnections: 1,
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(testutil.Ctx(t))
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		l.Run(ctx)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	conn1, err := net.Dial("tcp", l.TCPAddr().String())
+	require.NoError(t, err)
+
+	defer conn1.Close()
+
+	require.Eventually(t, func() bool {
+		return metrics.GetConnections().Current == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	conn2, err := net.Dial("tcp", l.TCPAddr().String())
+	require.NoError(t, err)
+
+	defer conn2.Close()
+
+	require.NoError(t, conn2.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	_, err = conn2.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, io.EOF)
+
+	expected := middleware.ConnectionMetrics{
+		Current:      1,
+		TotalCreated: 1,
+		Max:          1,
+	}
+	assert.Equal(t, expected, metrics.GetConnections())
+}
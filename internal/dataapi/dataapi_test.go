@@ -399,12 +399,28 @@ func setupDataAPI(tb testing.TB, auth bool) (addr string, dbName string) {
 		PostgreSQLURL:          uri,
 		Auth:                   auth,
 		ReplSetName:            "",
+		ReadOnly:               false,
 		SessionCleanupInterval: 0,
+		TTLMonitorInterval:     0,
+		TTLMonitorBatchSize:    0,
+		DefaultMaxTime:         0,
+		SlowOpThreshold:        0,
+		MaxRequests:            0,
+		MaxUserRequests:        0,
+		AdmissionMaxWait:       0,
 
 		ProxyAddr:        "",
 		ProxyTLSCertFile: "",
 		ProxyTLSKeyFile:  "",
 		ProxyTLSCAFile:   "",
+		ProxyPoolSize:    0,
+		ProxyPoolWait:    0,
+
+		ProxyUsername:      "",
+		ProxyPassword:      "",
+		ProxyAuthDB:        "",
+		ProxyAuthMechanism: "",
+		ProxyUserMapFile:   "",
 
 		TCPAddr:        "127.0.0.1:0",
 		UnixAddr:       "",
@@ -412,9 +428,13 @@ func setupDataAPI(tb testing.TB, auth bool) (addr string, dbName string) {
 		TLSCertFile:    "",
 		TLSKeyFile:     "",
 		TLSCAFile:      "",
+		MaxConnections: 0,
 		Mode:           middleware.NormalMode,
 		TestRecordsDir: "",
 
+		RoutingFile:           "",
+		RoutingReloadInterval: 0,
+
 		DataAPIAddr: "127.0.0.1:0",
 
 		MCPAddr: "",
@@ -0,0 +1,103 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package documentdb
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// backendKey is a named unexported type for the safe use of [context.WithValue].
+type backendKey struct{}
+
+// RunningQuery tracks the currently running PostgreSQL query of some operation,
+// so it could be canceled.
+//
+// The zero value is ready to use.
+type RunningQuery struct {
+	m    sync.Mutex
+	conn *pgconn.PgConn // nil if there is no running query
+}
+
+// WithRunningQuery returns a derived context that makes queries executed with it
+// tracked by q while they are running.
+//
+// It is used to cancel queries of in-progress operations with [RunningQuery.Cancel].
+func WithRunningQuery(ctx context.Context, q *RunningQuery) context.Context {
+	return context.WithValue(ctx, backendKey{}, q)
+}
+
+// startBackendQuery tracks the query on the given connection, if requested by context.
+func startBackendQuery(ctx context.Context, conn *pgx.Conn) {
+	if q, _ := ctx.Value(backendKey{}).(*RunningQuery); q != nil {
+		q.m.Lock()
+		q.conn = conn.PgConn()
+		q.m.Unlock()
+	}
+}
+
+// endBackendQuery stops tracking the query started by [startBackendQuery].
+//
+// It waits for [RunningQuery.Cancel] in progress,
+// so the connection is not returned to the pool and reused before that.
+func endBackendQuery(ctx context.Context, conn *pgx.Conn) {
+	if q, _ := ctx.Value(backendKey{}).(*RunningQuery); q != nil {
+		q.m.Lock()
+		if q.conn == conn.PgConn() {
+			q.conn = nil
+		}
+		q.m.Unlock()
+	}
+}
+
+// PID returns PostgreSQL backend PID of the running query, or zero if there is no running query.
+func (q *RunningQuery) PID() uint32 {
+	q.m.Lock()
+	defer q.m.Unlock()
+
+	if q.conn == nil {
+		return 0
+	}
+
+	return q.conn.PID()
+}
+
+// Cancel cancels the running query, if any.
+//
+// The query's connection stays used by the operation until Cancel returns,
+// so queries of other operations are never canceled.
+func (q *RunningQuery) Cancel(ctx context.Context) error {
+	q.m.Lock()
+	defer q.m.Unlock()
+
+	if q.conn == nil {
+		return nil
+	}
+
+	// do not block the operation for too long if PostgreSQL is unreachable
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	if err := q.conn.CancelRequest(ctx); err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	return nil
+}
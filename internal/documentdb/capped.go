@@ -0,0 +1,572 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package documentdb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb/documentdb_api"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// CappedCollection represents limits of a capped collection.
+//
+// DocumentDB does not support capped collections,
+// so we track their limits and documents' insertion order ourselves.
+type CappedCollection struct {
+	Size int64 // maximum total size of documents in bytes
+	Max  int64 // maximum number of documents; 0 means no limit
+}
+
+// cappedQuery returns limits of all capped collections.
+const cappedQuery = "SELECT database_name, collection_name, max_size, max_documents FROM ferretdb.capped_collections"
+
+// scanCapped is a [metadataScanner] for [cappedQuery].
+func scanCapped(rows pgx.Rows) (string, string, *CappedCollection, error) {
+	var db, coll string
+	var res CappedCollection
+
+	if err := rows.Scan(&db, &coll, &res.Size, &res.Max); err != nil {
+		return "", "", nil, lazyerrors.Error(err)
+	}
+
+	return db, coll, &res, nil
+}
+
+// Capped returns limits of the given capped collection, or nil if the collection is not capped.
+func (p *Pool) Capped(ctx context.Context, db, coll string) (*CappedCollection, error) {
+	res, err := p.capped.get(ctx, p, cappedQuery, scanCapped, db, coll)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return res, nil
+}
+
+// CreateCapped creates a new capped collection with the given limits.
+// It is a part of the implementation of the `create` command.
+//
+// It is not an error if the same capped collection already exists.
+func (p *Pool) CreateCapped(ctx context.Context, db, coll string, limits *CappedCollection) error {
+	ctx, span := otel.Tracer("").Start(ctx, "documentdb.Pool.CreateCapped")
+	defer span.End()
+
+	return createWithMetadata(
+		ctx, p, &p.capped, cappedQuery, scanCapped, db, coll, limits,
+		"INSERT INTO ferretdb.capped_collections (database_name, collection_name, max_size, max_documents) "+
+			"VALUES ($1, $2, $3, $4)",
+		limits.Size, limits.Max,
+	)
+}
+
+// CappedInsert runs the given function that inserts documents into the given capped collection
+// in a transaction.
+// The function should return successfully inserted documents; they should have _id field.
+//
+// In the same transaction, inserted documents are recorded,
+// and the oldest documents are removed if the collection exceeds its limits.
+// Then waiting tailable cursors are notified.
+func (p *Pool) CappedInsert(ctx context.Context, db, coll string, limits *CappedCollection, insert func(conn *pgx.Conn) ([]wirebson.RawDocument, error)) error {
+	ctx, span := otel.Tracer("").Start(ctx, "documentdb.Pool.CappedInsert")
+	defer span.End()
+
+	must.NotBeZero(limits)
+
+	err := p.WithConn(ctx, func(conn *pgx.Conn) error {
+		return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			docs, err := insert(tx.Conn())
+			if err != nil {
+				return err
+			}
+
+			if len(docs) == 0 {
+				return nil
+			}
+
+			ids := make([]wirebson.RawDocument, len(docs))
+			sizes := make([]int64, len(docs))
+
+			for i, doc := range docs {
+				if ids[i], err = cappedObjectID(doc); err != nil {
+					return lazyerrors.Error(err)
+				}
+
+				sizes[i] = int64(len(doc))
+			}
+
+			if err = cappedTrack(ctx, tx.Conn(), db, coll, ids, sizes); err != nil {
+				return lazyerrors.Error(err)
+			}
+
+			return p.cappedInserted(ctx, tx.Conn(), db, coll, limits)
+		})
+	})
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	return nil
+}
+
+// WriteFilter represents a filter of a statement of the command that deletes or updates documents.
+type WriteFilter struct {
+	Filter    wirebson.AnyDocument
+	Collation wirebson.AnyDocument // nil if not set
+	Let       wirebson.AnyDocument // nil if not set
+}
+
+// WithWriteConn is like [Pool.WithConn], but for functions that delete or update documents
+// (including upserts) of the given collection with statements that have given filters.
+// The function should return _id values of upserted documents.
+//
+// For capped collections, the function is run in a transaction.
+// In the same transaction, recorded documents that match given filters
+// and upserted documents are synchronized with the collection:
+// deleted documents are forgotten, sizes of updated documents are changed,
+// and upserted documents are recorded (evicting the oldest documents if needed).
+func (p *Pool) WithWriteConn(ctx context.Context, db, coll string, filters []WriteFilter, f func(conn *pgx.Conn) ([]any, error)) error { //nolint:lll // for readability
+	limits, err := p.Capped(ctx, db, coll)
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	if limits == nil {
+		return p.WithConn(ctx, func(conn *pgx.Conn) error {
+			_, err := f(conn)
+			return err
+		})
+	}
+
+	ctx, span := otel.Tracer("").Start(ctx, "documentdb.Pool.WithWriteConn")
+	defer span.End()
+
+	err = p.WithConn(ctx, func(conn *pgx.Conn) error {
+		return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			ids, err := p.cappedMatch(ctx, tx.Conn(), db, coll, filters)
+			if err != nil {
+				return lazyerrors.Error(err)
+			}
+
+			upserted, err := f(tx.Conn())
+			if err != nil {
+				return err
+			}
+
+			for _, v := range upserted {
+				var id wirebson.RawDocument
+				if id, err = wirebson.MustDocument("_id", v).Encode(); err != nil {
+					return lazyerrors.Error(err)
+				}
+
+				ids = append(ids, id)
+			}
+
+			recorded, err := p.cappedSync(ctx, tx.Conn(), db, coll, ids)
+			if err != nil {
+				return lazyerrors.Error(err)
+			}
+
+			if !recorded {
+				return nil
+			}
+
+			return p.cappedInserted(ctx, tx.Conn(), db, coll, limits)
+		})
+	})
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	return nil
+}
+
+// cappedTrack records documents with given _id fields (see [cappedObjectID]) and sizes
+// as the newest documents of the capped collection.
+func cappedTrack(ctx context.Context, conn *pgx.Conn, db, coll string, ids []wirebson.RawDocument, sizes []int64) error {
+	for i, id := range ids {
+		_, err := conn.Exec(
+			ctx,
+			"INSERT INTO ferretdb.capped_documents (database_name, collection_name, object_id, size) "+
+				"VALUES ($1, $2, $3, $4)",
+			db, coll, []byte(id), sizes[i],
+		)
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+	}
+
+	return nil
+}
+
+// cappedInserted removes the oldest documents if the capped collection exceeds its limits
+// after new documents were recorded, and notifies waiting tailable cursors on commit.
+func (p *Pool) cappedInserted(ctx context.Context, conn *pgx.Conn, db, coll string, limits *CappedCollection) error {
+	if err := p.cappedEvict(ctx, conn, db, coll, limits); err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	if _, err := conn.Exec(ctx, "SELECT pg_notify($1, $2)", cappedChannel, metadataKey(db, coll)); err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	return nil
+}
+
+// cappedMatch returns _id fields (see [cappedObjectID]) of documents of the capped collection
+// that match any of the given filters.
+func (p *Pool) cappedMatch(ctx context.Context, conn *pgx.Conn, db, coll string, filters []WriteFilter) ([]wirebson.RawDocument, error) { //nolint:lll // for readability
+	var res []wirebson.RawDocument
+	seen := map[string]struct{}{}
+
+	for _, f := range filters {
+		spec := wirebson.MustDocument(
+			"find", coll,
+			"filter", f.Filter,
+			"projection", wirebson.MustDocument("_id", int32(1)),
+			"batchSize", ttlListBatchSize,
+		)
+
+		if f.Collation != nil {
+			must.NoError(spec.Add("collation", f.Collation))
+		}
+
+		if f.Let != nil {
+			must.NoError(spec.Add("let", f.Let))
+		}
+
+		err := p.cappedFind(ctx, conn, db, spec, func(doc wirebson.RawDocument) error {
+			id, err := cappedObjectID(doc)
+			if err != nil {
+				return lazyerrors.Error(err)
+			}
+
+			if _, ok := seen[string(id)]; !ok {
+				seen[string(id)] = struct{}{}
+				res = append(res, id)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	return res, nil
+}
+
+// cappedSync synchronizes recorded documents of the capped collection with its actual documents
+// that have given _id fields (see [cappedObjectID]).
+// It returns true if new (upserted) documents were recorded.
+func (p *Pool) cappedSync(ctx context.Context, conn *pgx.Conn, db, coll string, ids []wirebson.RawDocument) (bool, error) {
+	if len(ids) == 0 {
+		return false, nil
+	}
+
+	values := wirebson.MakeArray(len(ids))
+	keys := make([][]byte, len(ids))
+
+	for i, id := range ids {
+		d, err := id.Decode()
+		if err != nil {
+			return false, lazyerrors.Error(err)
+		}
+
+		if err = values.Add(d.Get("_id")); err != nil {
+			return false, lazyerrors.Error(err)
+		}
+
+		keys[i] = id
+	}
+
+	spec := wirebson.MustDocument(
+		"find", coll,
+		"filter", wirebson.MustDocument("_id", wirebson.MustDocument("$in", values)),
+		"batchSize", ttlListBatchSize,
+	)
+
+	actual := map[string]int64{}
+
+	err := p.cappedFind(ctx, conn, db, spec, func(doc wirebson.RawDocument) error {
+		id, err := cappedObjectID(doc)
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		actual[string(id)] = int64(len(doc))
+
+		return nil
+	})
+	if err != nil {
+		return false, lazyerrors.Error(err)
+	}
+
+	rows, err := conn.Query(
+		ctx,
+		"SELECT seq, object_id, size FROM ferretdb.capped_documents "+
+			"WHERE database_name = $1 AND collection_name = $2 AND object_id = ANY($3)",
+		db, coll, keys,
+	)
+	if err != nil {
+		return false, lazyerrors.Error(err)
+	}
+
+	var seq, size int64
+	var objectID []byte
+	var gone []int64
+	resized := map[int64]int64{}
+	tracked := map[string]struct{}{}
+
+	_, err = pgx.ForEachRow(rows, []any{&seq, &objectID, &size}, func() error {
+		actualSize, ok := actual[string(objectID)]
+
+		switch {
+		case !ok:
+			gone = append(gone, seq)
+		case actualSize != size:
+			resized[seq] = actualSize
+		}
+
+		tracked[string(objectID)] = struct{}{}
+
+		return nil
+	})
+	if err != nil {
+		return false, lazyerrors.Error(err)
+	}
+
+	if len(gone) > 0 {
+		_, err = conn.Exec(
+			ctx,
+			"DELETE FROM ferretdb.capped_documents "+
+				"WHERE database_name = $1 AND collection_name = $2 AND seq = ANY($3)",
+			db, coll, gone,
+		)
+		if err != nil {
+			return false, lazyerrors.Error(err)
+		}
+	}
+
+	for seq, size := range resized {
+		_, err = conn.Exec(
+			ctx,
+			"UPDATE ferretdb.capped_documents SET size = $4 "+
+				"WHERE database_name = $1 AND collection_name = $2 AND seq = $3",
+			db, coll, seq, size,
+		)
+		if err != nil {
+			return false, lazyerrors.Error(err)
+		}
+	}
+
+	var newIDs []wirebson.RawDocument
+	var sizes []int64
+
+	// record upserted documents in the order of their _id values
+	for _, id := range ids {
+		size, ok := actual[string(id)]
+		if !ok {
+			continue
+		}
+
+		if _, ok = tracked[string(id)]; ok {
+			continue
+		}
+
+		newIDs = append(newIDs, id)
+		sizes = append(sizes, size)
+	}
+
+	if err = cappedTrack(ctx, conn, db, coll, newIDs, sizes); err != nil {
+		return false, lazyerrors.Error(err)
+	}
+
+	return len(newIDs) > 0, nil
+}
+
+// cappedFind calls f for each document returned by the given `find` command, fetching all pages.
+func (p *Pool) cappedFind(ctx context.Context, conn *pgx.Conn, db string, spec *wirebson.Document, f func(wirebson.RawDocument) error) error { //nolint:lll // for readability
+	raw, err := spec.Encode()
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	page, continuation, _, _, err := documentdb_api.FindCursorFirstPage(ctx, conn, p.l, db, raw, 0)
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	for {
+		docs, cursorID, err := cursorBatch(page)
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		for v := range docs.Values() {
+			doc, ok := v.(wirebson.RawDocument)
+			if !ok {
+				return lazyerrors.Errorf("unexpected document %T", v)
+			}
+
+			if err = f(doc); err != nil {
+				return err
+			}
+		}
+
+		if cursorID == 0 {
+			return nil
+		}
+
+		getMore, err := wirebson.MustDocument(
+			"getMore", cursorID,
+			"collection", spec.Get("find"),
+			"batchSize", spec.Get("batchSize"),
+		).Encode()
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		if page, continuation, err = documentdb_api.CursorGetMore(ctx, conn, p.l, db, getMore, continuation); err != nil {
+			return lazyerrors.Error(err)
+		}
+	}
+}
+
+// cursorBatch returns documents of the first or next batch of the cursor page, and the cursor ID.
+// Documents are not decoded.
+func cursorBatch(page wirebson.RawDocument) (*wirebson.Array, int64, error) {
+	doc, err := page.Decode()
+	if err != nil {
+		return nil, 0, lazyerrors.Error(err)
+	}
+
+	c, ok := doc.Get("cursor").(wirebson.AnyDocument)
+	if !ok {
+		return nil, 0, lazyerrors.Errorf("no cursor in %s", page)
+	}
+
+	cDoc, err := c.Decode()
+	if err != nil {
+		return nil, 0, lazyerrors.Error(err)
+	}
+
+	batch, ok := cDoc.Get("firstBatch").(wirebson.AnyArray)
+	if !ok {
+		if batch, ok = cDoc.Get("nextBatch").(wirebson.AnyArray); !ok {
+			return nil, 0, lazyerrors.Errorf("no batch in %s", page)
+		}
+	}
+
+	res, err := batch.Decode()
+	if err != nil {
+		return nil, 0, lazyerrors.Error(err)
+	}
+
+	id, _ := cDoc.Get("id").(int64)
+
+	return res, id, nil
+}
+
+// cappedEvict removes the oldest documents from the capped collection
+// until it fits into limits.
+func (p *Pool) cappedEvict(ctx context.Context, conn *pgx.Conn, db, coll string, limits *CappedCollection) error {
+	// keep the newest documents while their running totals fit into limits
+	q := `
+	WITH t AS (
+		SELECT seq,
+			count(*) OVER w AS n,
+			sum(size) OVER w AS total
+		FROM ferretdb.capped_documents
+		WHERE database_name = $1 AND collection_name = $2
+		WINDOW w AS (ORDER BY seq DESC ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)
+	)
+	DELETE FROM ferretdb.capped_documents d
+	USING t
+	WHERE d.database_name = $1 AND d.collection_name = $2 AND d.seq = t.seq
+		AND (t.total > $3 OR ($4 > 0 AND t.n > $4))
+	RETURNING d.object_id`
+
+	rows, err := conn.Query(ctx, q, db, coll, limits.Size, limits.Max)
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	var id []byte
+	ids := wirebson.MakeArray(0)
+
+	_, err = pgx.ForEachRow(rows, []any{&id}, func() error {
+		doc, err := wirebson.RawDocument(id).Decode()
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		return ids.Add(doc.Get("_id"))
+	})
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	if ids.Len() == 0 {
+		return nil
+	}
+
+	p.l.DebugContext(
+		ctx, "Evicting documents from capped collection",
+		slog.String("ns", metadataKey(db, coll)), slog.Int("count", ids.Len()),
+	)
+
+	spec, err := wirebson.MustDocument(
+		"delete", coll,
+		"deletes", wirebson.MustArray(wirebson.MustDocument(
+			"q", wirebson.MustDocument("_id", wirebson.MustDocument("$in", ids)),
+			"limit", int32(0),
+		)),
+	).Encode()
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	if _, _, err = documentdb_api.Delete(ctx, conn, p.l, db, spec, nil); err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	return nil
+}
+
+// cappedObjectID returns a document with only _id field of the given document.
+func cappedObjectID(doc wirebson.RawDocument) (wirebson.RawDocument, error) {
+	d, err := doc.Decode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	id := d.Get("_id")
+	if id == nil {
+		return nil, lazyerrors.New("document has no _id")
+	}
+
+	res, err := wirebson.MustDocument("_id", id).Encode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return res, nil
+}
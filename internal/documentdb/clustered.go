@@ -0,0 +1,98 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package documentdb
+
+import (
+	"context"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+// ClusteredCollection represents options of a collection clustered by _id.
+//
+// Clustering is metadata-only: table rows are not physically ordered by _id.
+// DocumentDB stores documents of every collection in a table with a primary key on _id
+// (shard key value is the same for all documents of unsharded collection),
+// so range scans on _id already use that index.
+// We only track options ourselves for listing and expiration.
+type ClusteredCollection struct {
+	Name               string // clustered index name
+	ExpireAfterSeconds int64  // 0 means documents do not expire
+}
+
+// clusteredQuery returns options of all clustered collections.
+const clusteredQuery = "SELECT database_name, collection_name, index_name, expire_after_seconds " +
+	"FROM ferretdb.clustered_collections"
+
+// scanClustered is a [metadataScanner] for [clusteredQuery].
+func scanClustered(rows pgx.Rows) (string, string, *ClusteredCollection, error) {
+	var db, coll string
+	var res ClusteredCollection
+
+	if err := rows.Scan(&db, &coll, &res.Name, &res.ExpireAfterSeconds); err != nil {
+		return "", "", nil, lazyerrors.Error(err)
+	}
+
+	return db, coll, &res, nil
+}
+
+// Clustered returns options of the given clustered collection, or nil if the collection is not clustered.
+func (p *Pool) Clustered(ctx context.Context, db, coll string) (*ClusteredCollection, error) {
+	res, err := p.clustered.get(ctx, p, clusteredQuery, scanClustered, db, coll)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return res, nil
+}
+
+// CountClustered returns the number of clustered collections.
+func (p *Pool) CountClustered(ctx context.Context) (int, error) {
+	all, err := p.allClustered(ctx)
+	if err != nil {
+		return 0, lazyerrors.Error(err)
+	}
+
+	return len(all), nil
+}
+
+// allClustered returns options of all clustered collections keyed by namespace.
+func (p *Pool) allClustered(ctx context.Context) (map[string]ClusteredCollection, error) {
+	res, err := p.clustered.all(ctx, p, clusteredQuery, scanClustered)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return res, nil
+}
+
+// CreateClustered creates a new collection clustered by _id with the given options.
+// It is a part of the implementation of the `create` command.
+//
+// It is not an error if the same clustered collection already exists.
+func (p *Pool) CreateClustered(ctx context.Context, db, coll string, opts *ClusteredCollection) error {
+	ctx, span := otel.Tracer("").Start(ctx, "documentdb.Pool.CreateClustered")
+	defer span.End()
+
+	return createWithMetadata(
+		ctx, p, &p.clustered, clusteredQuery, scanClustered, db, coll, opts,
+		"INSERT INTO ferretdb.clustered_collections "+
+			"(database_name, collection_name, index_name, expire_after_seconds) "+
+			"VALUES ($1, $2, $3, $4)",
+		opts.Name, opts.ExpireAfterSeconds,
+	)
+}
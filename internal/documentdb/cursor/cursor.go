@@ -33,8 +33,10 @@ type cursor struct {
 	// the order of fields is weird to make the struct smaller due to alignment
 
 	created      time.Time
+	lastUsed     time.Time // protected by registry's rw
 	token        *resource.Token
 	conn         *pgx.Conn // only if persisted/hijacked
+	tailable     *Tailable // only for tailable cursors
 	continuation wirebson.RawDocument
 }
 
@@ -49,6 +51,25 @@ func newCursor(continuation wirebson.RawDocument, conn *pgx.Conn) *cursor {
 		created:      time.Now(),
 	}
 
+	res.lastUsed = res.created
+
+	resource.Track(res, res.token)
+
+	return res
+}
+
+// newTailableCursor creates a new tailable cursor with the given state.
+func newTailableCursor(t *Tailable) *cursor {
+	must.NotBeZero(t)
+
+	res := &cursor{
+		tailable: t,
+		token:    resource.NewToken(),
+		created:  time.Now(),
+	}
+
+	res.lastUsed = res.created
+
 	resource.Track(res, res.token)
 
 	return res
@@ -56,6 +77,10 @@ func newCursor(continuation wirebson.RawDocument, conn *pgx.Conn) *cursor {
 
 // Type returns cursor type for logging and Prometheus label value.
 func (c *cursor) Type() string {
+	if c.tailable != nil {
+		return "tailable"
+	}
+
 	if c.conn != nil {
 		return "persistent"
 	}
@@ -65,6 +90,13 @@ func (c *cursor) Type() string {
 
 // LogValue implements [slog.LogValuer] interface.
 func (c *cursor) LogValue() slog.Value {
+	if c.tailable != nil {
+		return slog.GroupValue(
+			slog.String("type", c.Type()),
+			slog.Any("tailable", c.tailable),
+		)
+	}
+
 	return slog.GroupValue(
 		slog.String("type", c.Type()),
 		slog.Any("continuation", logging.LazyDeepDecoder(c.continuation)),
@@ -194,6 +194,7 @@ func (r *Registry) UpdateCursor(ctx context.Context, id int64, continuation wire
 		slog.Int64("id", id), slog.Any("cursor", c), slog.Any("continuation", logging.LazyDeepDecoder(continuation)),
 	)
 	c.continuation = continuation
+	c.lastUsed = time.Now()
 
 	r.rw.Unlock()
 }
@@ -220,6 +221,65 @@ func (r *Registry) CloseCursor(ctx context.Context, id int64) bool {
 	return true
 }
 
+// CloseIdle removes cursors that were not used for longer than the given timeout from the registry
+// and closes them. It returns IDs of closed cursors.
+//
+// See [Registry.CloseCursor] for the ctx usage.
+func (r *Registry) CloseIdle(ctx context.Context, timeout time.Duration) []int64 {
+	var ids []int64
+	var closed []*cursor
+
+	r.rw.Lock()
+
+	for id, c := range r.cursors {
+		if time.Since(c.lastUsed) <= timeout {
+			continue
+		}
+
+		r.l.DebugContext(ctx, "Closing idle cursor", slog.Int64("id", id), slog.Any("cursor", c))
+
+		ids = append(ids, id)
+		closed = append(closed, r.removeCursor(ctx, id))
+	}
+
+	r.rw.Unlock()
+
+	for _, c := range closed {
+		c.close(ctx)
+	}
+
+	return ids
+}
+
+// Stats represents the state of cursors in the registry.
+type Stats struct {
+	Cursors   int // all cursors
+	Persisted int // cursors holding hijacked PostgreSQL connections
+	Tailable  int // tailable cursors
+}
+
+// Stats returns the current state of cursors in the registry.
+func (r *Registry) Stats() *Stats {
+	r.rw.RLock()
+	defer r.rw.RUnlock()
+
+	res := &Stats{
+		Cursors: len(r.cursors),
+	}
+
+	for _, c := range r.cursors {
+		if c.conn != nil {
+			res.Persisted++
+		}
+
+		if c.tailable != nil {
+			res.Tailable++
+		}
+	}
+
+	return res
+}
+
 // removeCursor removes the cursor with the given id from the registry and returns it, if any.
 // The caller is responsible for closing it.
 // Registry's rw also should be held by the caller.
@@ -0,0 +1,112 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// Tailable stores the state of a tailable cursor on a capped collection.
+//
+// Unlike DocumentDB cursors, tailable cursors are not closed when they reach the end of the collection.
+// They remember the position of the last returned document instead.
+type Tailable struct {
+	DB         string
+	Collection string
+	Filter     wirebson.RawDocument // may be nil
+	Projection wirebson.RawDocument // may be nil
+	BatchSize  int64
+	AwaitData  bool
+
+	// LastSeq is the insertion sequence number of the last examined document.
+	LastSeq int64
+}
+
+// LogValue implements [slog.LogValuer] interface.
+func (t *Tailable) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("ns", t.DB+"."+t.Collection),
+		slog.Any("filter", logging.LazyDecoder(t.Filter)),
+		slog.Bool("awaitData", t.AwaitData),
+		slog.Int64("lastSeq", t.LastSeq),
+	)
+}
+
+// NewTailableCursor stores a tailable cursor with the given state.
+//
+// Passed context is used for logging/tracing.
+func (r *Registry) NewTailableCursor(ctx context.Context, id int64, t *Tailable) {
+	must.NotBeZero(id)
+
+	r.rw.Lock()
+	defer r.rw.Unlock()
+
+	// IDs are random, so that should not happen
+	must.BeTrue(r.cursors[id] == nil)
+
+	c := newTailableCursor(t)
+
+	r.l.DebugContext(ctx, "Storing new tailable cursor", slog.Int64("id", id), slog.Any("cursor", c))
+
+	r.created.With(prometheus.Labels{"type": c.Type()}).Inc()
+
+	r.cursors[id] = c
+}
+
+// GetTailable returns a copy of the tailable cursor state for the given cursor id.
+// It returns nil if there is no such cursor or if it is not tailable.
+func (r *Registry) GetTailable(id int64) *Tailable {
+	r.rw.RLock()
+	defer r.rw.RUnlock()
+
+	c := r.cursors[id]
+	if c == nil || c.tailable == nil {
+		return nil
+	}
+
+	res := *c.tailable
+
+	return &res
+}
+
+// UpdateTailable updates the position of the existing tailable cursor.
+//
+// Passed context is used for logging/tracing.
+func (r *Registry) UpdateTailable(ctx context.Context, id, lastSeq int64) {
+	r.rw.Lock()
+	defer r.rw.Unlock()
+
+	c := r.cursors[id]
+	if c == nil || c.tailable == nil {
+		r.l.WarnContext(ctx, "Tailable cursor not found", slog.Int64("id", id))
+		return
+	}
+
+	c.tailable.LastSeq = lastSeq
+	c.lastUsed = time.Now()
+}
+
+// check interfaces
+var (
+	_ slog.LogValuer = (*Tailable)(nil)
+)
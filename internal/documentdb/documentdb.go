@@ -27,6 +27,9 @@
 // TODO https://github.com/documentdb/documentdb/issues/25
 // TODO https://github.com/documentdb/documentdb/issues/333
 //
+// We also create our own ferretdb schema for capped, time series, and clustered collections that DocumentDB does not support;
+// see metadata.go.
+//
 // And we generate code for documentdb_core just to track changes.
 //
 //go:generate go run ./genwrap -debug -schemas=documentdb_api,documentdb_api_catalog,documentdb_api_internal,documentdb_core
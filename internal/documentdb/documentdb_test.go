@@ -103,7 +103,7 @@ func TestError(t *testing.T) {
 
 	var res wirebson.RawDocument
 
-	err = pool.WithConn(func(conn *pgx.Conn) error {
+	err = pool.WithConn(ctx, func(conn *pgx.Conn) error {
 		b := must.NotFail(wirebson.MustDocument(
 			"delete", testutil.CollectionName(t),
 			"deletes", wirebson.MustArray(wirebson.MustDocument(
@@ -159,7 +159,7 @@ func TestSeqNoSeq(t *testing.T) {
 	collName := testutil.CollectionName(t)
 
 	defer func() {
-		_ = pool.WithConn(func(conn *pgx.Conn) error {
+		_ = pool.WithConn(ctx, func(conn *pgx.Conn) error {
 			var drop bool
 			drop, err = documentdb_api.DropCollection(ctx, conn, l, dbName, collName, nil, nil, false)
 			require.NoError(t, err)
@@ -174,7 +174,7 @@ func TestSeqNoSeq(t *testing.T) {
 	var res *wirebson.Document
 
 	// insert document using sequence from [wire.OpMsg.Sections]
-	err = pool.WithConn(func(conn *pgx.Conn) error {
+	err = pool.WithConn(ctx, func(conn *pgx.Conn) error {
 		b := must.NotFail(wirebson.MustDocument(
 			"insert", collName,
 		).Encode())
@@ -196,7 +196,7 @@ func TestSeqNoSeq(t *testing.T) {
 	wiretest.AssertEqual(t, wirebson.MustDocument("n", int32(2), "ok", float64(1)), res)
 
 	// insert document using single document from, for example, Data API
-	err = pool.WithConn(func(conn *pgx.Conn) error {
+	err = pool.WithConn(ctx, func(conn *pgx.Conn) error {
 		b := must.NotFail(wirebson.MustDocument(
 			"insert", collName,
 			"documents", wirebson.MustArray(
@@ -253,7 +253,7 @@ func TestWithConn(t *testing.T) {
 
 		<-start
 
-		_ = pool.WithConn(func(conn *pgx.Conn) error {
+		_ = pool.WithConn(testutil.Ctx(t), func(conn *pgx.Conn) error {
 			must.NotBeZero(conn)
 
 			for range 10 {
@@ -275,3 +275,38 @@ func TestWithConn(t *testing.T) {
 		runtime.Gosched()
 	}
 }
+
+func TestRunningQuery(t *testing.T) {
+	uri := testutil.PostgreSQLURL(t)
+
+	t.Parallel()
+
+	ctx := testutil.Ctx(t)
+
+	sp, err := state.NewProvider("")
+	require.NoError(t, err)
+
+	pool, err := NewPool(uri, testutil.Logger(t), sp)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	var q RunningQuery
+	assert.Zero(t, q.PID())
+
+	err = pool.WithConn(ctx, func(conn *pgx.Conn) error {
+		rows, err := conn.Query(WithRunningQuery(ctx, &q), "SELECT pg_backend_pid()")
+		require.NoError(t, err)
+
+		require.True(t, rows.Next())
+
+		var pid uint32
+		require.NoError(t, rows.Scan(&pid))
+		assert.Equal(t, pid, q.PID())
+
+		rows.Close()
+		assert.Zero(t, q.PID())
+
+		return rows.Err()
+	})
+	require.NoError(t, err)
+}
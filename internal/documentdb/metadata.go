@@ -0,0 +1,503 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package documentdb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb/documentdb_api"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// metadataSchema creates tables for collection types and indexes that DocumentDB does not support (fully).
+// All tables have database_name and collection_name columns.
+const metadataSchema = `
+CREATE SCHEMA IF NOT EXISTS ferretdb;
+
+CREATE TABLE IF NOT EXISTS ferretdb.capped_collections (
+	database_name   text   NOT NULL,
+	collection_name text   NOT NULL,
+	max_size        bigint NOT NULL,
+	max_documents   bigint NOT NULL,
+	PRIMARY KEY (database_name, collection_name)
+);
+
+CREATE TABLE IF NOT EXISTS ferretdb.capped_documents (
+	database_name   text      NOT NULL,
+	collection_name text      NOT NULL,
+	seq             bigserial NOT NULL,
+	object_id       bytea     NOT NULL,
+	size            bigint    NOT NULL,
+	PRIMARY KEY (database_name, collection_name, seq)
+);
+
+CREATE INDEX IF NOT EXISTS capped_documents_object_id
+	ON ferretdb.capped_documents (database_name, collection_name, object_id);
+
+CREATE TABLE IF NOT EXISTS ferretdb.timeseries_collections (
+	database_name        text   NOT NULL,
+	collection_name      text   NOT NULL,
+	time_field           text   NOT NULL,
+	meta_field           text   NOT NULL,
+	granularity          text   NOT NULL,
+	expire_after_seconds bigint NOT NULL,
+	PRIMARY KEY (database_name, collection_name)
+);
+
+CREATE TABLE IF NOT EXISTS ferretdb.clustered_collections (
+	database_name        text   NOT NULL,
+	collection_name      text   NOT NULL,
+	index_name           text   NOT NULL,
+	expire_after_seconds bigint NOT NULL,
+	PRIMARY KEY (database_name, collection_name)
+);
+
+CREATE TABLE IF NOT EXISTS ferretdb.search_indexes (
+	database_name   text  NOT NULL,
+	collection_name text  NOT NULL,
+	index_name      text  NOT NULL,
+	index_id        text  NOT NULL,
+	index_type      text  NOT NULL,
+	definition      bytea NOT NULL,
+	PRIMARY KEY (database_name, collection_name, index_name)
+);
+`
+
+// metadataTables contains all tables created by [metadataSchema].
+var metadataTables = []string{
+	"ferretdb.capped_collections",
+	"ferretdb.capped_documents",
+	"ferretdb.timeseries_collections",
+	"ferretdb.clustered_collections",
+	"ferretdb.search_indexes",
+}
+
+// metadataScanner scans database name, collection name, and metadata from the current row.
+type metadataScanner[T any] func(rows pgx.Rows) (string, string, *T, error)
+
+// metadataCache caches FerretDB-specific metadata of all collections of some type.
+//
+// It is filled on first use and updated by operations of that FerretDB instance.
+// Changes made by other instances are delivered by [notifier] that resets the cache.
+type metadataCache[T any] struct {
+	rw     sync.RWMutex
+	loaded bool
+	colls  map[string]*T // keyed by namespace
+}
+
+// metadataKey returns a cache key for the given collection.
+func metadataKey(db, coll string) string {
+	return db + "." + coll
+}
+
+// get returns metadata for the given collection, or nil.
+// The cache is loaded with the given query if needed.
+// The query should return rows for all collections.
+func (c *metadataCache[T]) get(ctx context.Context, p *Pool, q string, scan metadataScanner[T], db, coll string) (*T, error) {
+	var res *T
+
+	err := c.view(ctx, p, q, scan, func(colls map[string]*T) {
+		res = colls[metadataKey(db, coll)]
+	})
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return res, nil
+}
+
+// all returns metadata of all collections keyed by namespace.
+// The cache is loaded with the given query if needed.
+func (c *metadataCache[T]) all(ctx context.Context, p *Pool, q string, scan metadataScanner[T]) (map[string]T, error) {
+	var res map[string]T
+
+	err := c.view(ctx, p, q, scan, func(colls map[string]*T) {
+		res = make(map[string]T, len(colls))
+		for k, v := range colls {
+			res[k] = *v
+		}
+	})
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return res, nil
+}
+
+// view calls f with loaded metadata of all collections under the read lock.
+//
+// The cache could be reset by [notifier] between loading and reading,
+// so it is loaded again in that case.
+func (c *metadataCache[T]) view(ctx context.Context, p *Pool, q string, scan metadataScanner[T], f func(map[string]*T)) error {
+	for {
+		c.rw.RLock()
+
+		if c.loaded {
+			f(c.colls)
+			c.rw.RUnlock()
+
+			return nil
+		}
+
+		c.rw.RUnlock()
+
+		p.startNotifier()
+
+		err := p.WithConn(ctx, func(conn *pgx.Conn) error {
+			return c.load(ctx, p, conn, q, scan)
+		})
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+	}
+}
+
+// load loads metadata of all collections, if not already loaded.
+func (c *metadataCache[T]) load(ctx context.Context, p *Pool, conn *pgx.Conn, q string, scan metadataScanner[T]) error {
+	c.rw.Lock()
+	defer c.rw.Unlock()
+
+	if c.loaded {
+		return nil
+	}
+
+	exists, err := p.metadataExists(ctx, conn)
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	colls := map[string]*T{}
+
+	if exists {
+		rows, err := conn.Query(ctx, q)
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			db, coll, v, err := scan(rows)
+			if err != nil {
+				return lazyerrors.Error(err)
+			}
+
+			colls[metadataKey(db, coll)] = v
+		}
+
+		if err = rows.Err(); err != nil {
+			return lazyerrors.Error(err)
+		}
+	}
+
+	c.colls = colls
+	c.loaded = true
+
+	return nil
+}
+
+// reset forgets all cached metadata, so it is loaded again on next use.
+func (c *metadataCache[T]) reset() {
+	c.rw.Lock()
+	defer c.rw.Unlock()
+
+	c.loaded = false
+	c.colls = nil
+}
+
+// set stores metadata for the given collection, if the cache is loaded.
+func (c *metadataCache[T]) set(db, coll string, v *T) {
+	c.rw.Lock()
+	defer c.rw.Unlock()
+
+	if c.loaded {
+		c.colls[metadataKey(db, coll)] = v
+	}
+}
+
+// remove removes metadata for the given collection, or for all collections of the database if coll is empty.
+func (c *metadataCache[T]) remove(db, coll string) {
+	c.rw.Lock()
+	defer c.rw.Unlock()
+
+	for k := range c.colls {
+		if (coll == "" && strings.HasPrefix(k, db+".")) || k == metadataKey(db, coll) {
+			delete(c.colls, k)
+		}
+	}
+}
+
+// rename moves metadata of the renamed collection, if the cache is loaded.
+func (c *metadataCache[T]) rename(db, from, to string) {
+	c.rw.Lock()
+	defer c.rw.Unlock()
+
+	if !c.loaded {
+		return
+	}
+
+	delete(c.colls, metadataKey(db, to))
+
+	if v := c.colls[metadataKey(db, from)]; v != nil {
+		delete(c.colls, metadataKey(db, from))
+		c.colls[metadataKey(db, to)] = v
+	}
+}
+
+// metadataExists returns true if [metadataSchema] was created.
+//
+// It is a cheap catalog check, except for the first call for the pool
+// that creates tables added in later versions if needed.
+func (p *Pool) metadataExists(ctx context.Context, conn *pgx.Conn) (bool, error) {
+	var exists bool
+
+	q := "SELECT to_regnamespace('ferretdb') IS NOT NULL"
+	if err := conn.QueryRow(ctx, q).Scan(&exists); err != nil {
+		return false, lazyerrors.Error(err)
+	}
+
+	if !exists {
+		return false, nil
+	}
+
+	if err := p.createMetadata(ctx, conn); err != nil {
+		return false, lazyerrors.Error(err)
+	}
+
+	return true, nil
+}
+
+// createMetadata creates [metadataSchema] if needed.
+//
+// The schema is created (or migrated) only once per pool.
+func (p *Pool) createMetadata(ctx context.Context, conn *pgx.Conn) error {
+	if p.metadataCreated.Load() {
+		return nil
+	}
+
+	if _, err := conn.Exec(ctx, metadataSchema); err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	p.metadataCreated.Store(true)
+
+	return nil
+}
+
+// createWithMetadata creates a new collection with FerretDB-specific metadata v.
+// It is a common implementation of [Pool.CreateCapped], [Pool.CreateTimeSeries], and [Pool.CreateClustered].
+//
+// The collection is created and metadata is inserted with the given statement in a single transaction,
+// so a collection without metadata is never left behind.
+// The statement gets db and coll as the first two arguments, followed by args.
+//
+// It is not an error if the same collection with the same metadata already exists.
+func createWithMetadata[T comparable](ctx context.Context, p *Pool, c *metadataCache[T], q string, scan metadataScanner[T], db, coll string, v *T, insert string, args ...any) error { //nolint:lll // for readability
+	must.NotBeZero(v)
+
+	existing, err := c.get(ctx, p, q, scan, db, coll)
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	if existing != nil && *existing == *v {
+		return nil
+	}
+
+	alreadyExists := mongoerrors.New(
+		mongoerrors.ErrNamespaceExists,
+		fmt.Sprintf("Collection %s.%s already exists with different options", db, coll),
+	)
+
+	if existing != nil {
+		return alreadyExists
+	}
+
+	err = p.WithConn(ctx, func(conn *pgx.Conn) error {
+		// outside of the transaction, so the schema is never marked as created after rollback
+		if err := p.createMetadata(ctx, conn); err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			created, err := documentdb_api.CreateCollection(ctx, tx.Conn(), p.l, db, coll)
+			if err != nil {
+				return err
+			}
+
+			if !created {
+				// the collection could be created by another request or FerretDB instance after the check above
+				if existing, err = metadataGet(ctx, tx.Conn(), q, scan, db, coll); err != nil {
+					return lazyerrors.Error(err)
+				}
+
+				if existing != nil && *existing == *v {
+					return nil
+				}
+
+				return alreadyExists
+			}
+
+			if _, err = tx.Exec(ctx, insert, append([]any{db, coll}, args...)...); err != nil {
+				return lazyerrors.Error(err)
+			}
+
+			return metadataNotify(ctx, tx.Conn())
+		})
+	})
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	c.set(db, coll, v)
+
+	return nil
+}
+
+// metadataGet returns metadata for the given collection directly from the database, or nil.
+// The query should return rows for all collections, like for [metadataCache.get].
+func metadataGet[T any](ctx context.Context, conn *pgx.Conn, q string, scan metadataScanner[T], db, coll string) (*T, error) {
+	rows, err := conn.Query(ctx, q+" WHERE database_name = $1 AND collection_name = $2", db, coll)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	defer rows.Close()
+
+	var res *T
+
+	for rows.Next() {
+		if _, _, res, err = scan(rows); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return res, nil
+}
+
+// metadataNotify notifies all FerretDB instances (including this one) that metadata was changed.
+func metadataNotify(ctx context.Context, conn *pgx.Conn) error {
+	if _, err := conn.Exec(ctx, "SELECT pg_notify($1, '')", metadataChannel); err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	return nil
+}
+
+// metadataInvalidate resets all metadata caches.
+func (p *Pool) metadataInvalidate() {
+	p.capped.reset()
+	p.timeseries.reset()
+	p.clustered.reset()
+	p.uncappedProfiles.Clear()
+}
+
+// DropMetadata removes FerretDB-specific metadata of the dropped collection,
+// or of all collections of the dropped database if coll is empty, in a single transaction.
+// It is a no-op for collections without such metadata.
+func (p *Pool) DropMetadata(ctx context.Context, db, coll string) error {
+	ctx, span := otel.Tracer("").Start(ctx, "documentdb.Pool.DropMetadata")
+	defer span.End()
+
+	p.capped.remove(db, coll)
+	p.timeseries.remove(db, coll)
+	p.clustered.remove(db, coll)
+	p.forgetUncappedProfiles(db, coll)
+
+	// search indexes are not cached, so we always check the schema
+	err := p.WithConn(ctx, func(conn *pgx.Conn) error {
+		exists, err := p.metadataExists(ctx, conn)
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		if !exists {
+			return nil
+		}
+
+		return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			for _, table := range metadataTables {
+				q := "DELETE FROM " + table + " WHERE database_name = $1 AND ($2 = '' OR collection_name = $2)"
+				if _, err = tx.Exec(ctx, q, db, coll); err != nil {
+					return lazyerrors.Error(err)
+				}
+			}
+
+			return metadataNotify(ctx, tx.Conn())
+		})
+	})
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	return nil
+}
+
+// RenameMetadata updates FerretDB-specific metadata of the renamed collection in a single transaction.
+// Metadata of the target collection (that could be dropped by `dropTarget`) is removed.
+func (p *Pool) RenameMetadata(ctx context.Context, db, from, to string) error {
+	ctx, span := otel.Tracer("").Start(ctx, "documentdb.Pool.RenameMetadata")
+	defer span.End()
+
+	err := p.WithConn(ctx, func(conn *pgx.Conn) error {
+		exists, err := p.metadataExists(ctx, conn)
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		if !exists {
+			return nil
+		}
+
+		return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			for _, table := range metadataTables {
+				q := "DELETE FROM " + table + " WHERE database_name = $1 AND collection_name = $2"
+				if _, err = tx.Exec(ctx, q, db, to); err != nil {
+					return lazyerrors.Error(err)
+				}
+
+				q = "UPDATE " + table + " SET collection_name = $3 WHERE database_name = $1 AND collection_name = $2"
+				if _, err = tx.Exec(ctx, q, db, from, to); err != nil {
+					return lazyerrors.Error(err)
+				}
+			}
+
+			return metadataNotify(ctx, tx.Conn())
+		})
+	})
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	p.capped.rename(db, from, to)
+	p.timeseries.rename(db, from, to)
+	p.clustered.rename(db, from, to)
+	p.forgetUncappedProfiles(db, from)
+	p.forgetUncappedProfiles(db, to)
+
+	return nil
+}
@@ -0,0 +1,180 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package documentdb
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/ctxutil"
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+)
+
+// PostgreSQL notification channels.
+const (
+	// cappedChannel is a channel for capped collection inserts.
+	// The payload is the namespace of the collection.
+	cappedChannel = "ferretdb_capped"
+
+	// metadataChannel is a channel for changes of FerretDB-specific metadata made by any FerretDB instance.
+	// The payload is not used.
+	metadataChannel = "ferretdb_metadata"
+)
+
+// notifier delivers PostgreSQL notifications about capped collection inserts to waiting tailable cursors,
+// and invalidates metadata caches on metadata changes.
+//
+// It is started lazily on the first wait or the first metadata cache load.
+//
+//nolint:vet // for readability
+type notifier struct {
+	m       sync.Mutex
+	waiters map[string]chan struct{} // keyed by namespace; closed on notification
+	cancel  context.CancelFunc       // nil if not started
+	done    chan struct{}
+}
+
+// cappedWait returns a channel that is closed when documents are inserted
+// into the given capped collection by any FerretDB instance.
+func (p *Pool) cappedWait(db, coll string) <-chan struct{} {
+	p.n.m.Lock()
+	defer p.n.m.Unlock()
+
+	p.startNotifierLocked()
+
+	if p.n.waiters == nil {
+		p.n.waiters = map[string]chan struct{}{}
+	}
+
+	ns := metadataKey(db, coll)
+
+	ch := p.n.waiters[ns]
+	if ch == nil {
+		ch = make(chan struct{})
+		p.n.waiters[ns] = ch
+	}
+
+	return ch
+}
+
+// startNotifier starts listening for notifications, if not started yet.
+func (p *Pool) startNotifier() {
+	p.n.m.Lock()
+	defer p.n.m.Unlock()
+
+	p.startNotifierLocked()
+}
+
+// startNotifierLocked is [Pool.startNotifier] that should be called with notifier's mutex held.
+func (p *Pool) startNotifierLocked() {
+	if p.n.cancel != nil {
+		return
+	}
+
+	var ctx context.Context
+	ctx, p.n.cancel = context.WithCancel(context.Background())
+	p.n.done = make(chan struct{})
+
+	go func() {
+		defer close(p.n.done)
+		p.listen(ctx)
+	}()
+}
+
+// cappedNotify wakes up all waiters for the given namespace.
+func (p *Pool) cappedNotify(ns string) {
+	p.n.m.Lock()
+	defer p.n.m.Unlock()
+
+	if ch := p.n.waiters[ns]; ch != nil {
+		close(ch)
+		delete(p.n.waiters, ns)
+	}
+}
+
+// listen receives notifications until ctx is canceled, reconnecting on errors.
+func (p *Pool) listen(ctx context.Context) {
+	for ctx.Err() == nil {
+		err := p.listenConn(ctx)
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+
+		p.l.WarnContext(ctx, "Failed to listen for notifications, retrying", logging.Error(err))
+		ctxutil.Sleep(ctx, time.Second)
+	}
+}
+
+// listenConn receives notifications using a dedicated connection.
+func (p *Pool) listenConn(ctx context.Context) error {
+	poolConn, err := p.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	// LISTEN is bound to the session, so the connection should not be returned to the pool
+	conn := poolConn.hijack()
+	poolConn.Release()
+
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+
+		_ = conn.Close(closeCtx)
+	}()
+
+	for _, channel := range []string{cappedChannel, metadataChannel} {
+		if _, err = conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+			return err
+		}
+
+		p.l.DebugContext(ctx, "Listening for notifications", slog.String("channel", channel))
+	}
+
+	// metadata could be changed while we were not listening
+	p.metadataInvalidate()
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		switch n.Channel {
+		case cappedChannel:
+			p.cappedNotify(n.Payload)
+		case metadataChannel:
+			p.metadataInvalidate()
+		}
+	}
+}
+
+// stopNotifier stops listening for notifications, if started.
+func (p *Pool) stopNotifier() {
+	p.n.m.Lock()
+	cancel, done := p.n.cancel, p.n.done
+	p.n.m.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	<-done
+}
@@ -15,7 +15,10 @@
 package documentdb
 
 import (
+	"context"
 	"log/slog"
+	"sync"
+	"sync/atomic"
 
 	"github.com/AlekSi/lazyerrors"
 	"github.com/jackc/pgx/v5"
@@ -42,6 +45,17 @@ type Pool struct {
 	l      *slog.Logger
 	tracer *tracer
 	token  *resource.Token
+
+	capped     metadataCache[CappedCollection]
+	timeseries metadataCache[TimeSeries]
+	clustered  metadataCache[ClusteredCollection]
+	n          notifier
+
+	// set after [metadataSchema] is created
+	metadataCreated atomic.Bool
+
+	// databases with profile collections that exist, but are not capped
+	uncappedProfiles sync.Map
 }
 
 // NewPool creates a new pool of PostgreSQL connections.
@@ -75,17 +89,20 @@ func NewPool(uri string, l *slog.Logger, sp *state.Provider) (*Pool, error) {
 func (p *Pool) Close() {
 	p.r.Close(todoCtx)
 
+	p.stopNotifier()
+
 	p.p.Close()
 
 	resource.Untrack(p, p.token)
 }
 
 // Acquire acquires a connection from the pool.
+// It waits for a free connection until ctx is canceled or its deadline is exceeded.
 //
 // It is caller's responsibility to call [Conn.Release].
 // Most callers should use [Pool.WithConn] instead.
-func (p *Pool) Acquire() (*Conn, error) {
-	conn, err := p.p.Acquire(todoCtx)
+func (p *Pool) Acquire(ctx context.Context) (*Conn, error) {
+	conn, err := p.p.Acquire(ctx)
 	if err != nil {
 		return nil, lazyerrors.Error(err)
 	}
@@ -95,8 +112,10 @@ func (p *Pool) Acquire() (*Conn, error) {
 
 // WithConn acquires a connection from the pool and calls the provided function with it.
 // The connection is automatically released after the function returns.
-func (p *Pool) WithConn(f func(*pgx.Conn) error) error {
-	conn, err := p.Acquire()
+//
+// The context is used only for acquiring; the function should pass it (or derived context) to queries.
+func (p *Pool) WithConn(ctx context.Context, f func(*pgx.Conn) error) error {
+	conn, err := p.Acquire(ctx)
 	if err != nil {
 		return lazyerrors.Error(err)
 	}
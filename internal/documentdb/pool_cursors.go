@@ -18,6 +18,7 @@
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/AlekSi/lazyerrors"
 	"github.com/FerretDB/wire/wirebson"
@@ -36,6 +37,10 @@ func (p *Pool) GetMore(ctx context.Context, db string, spec wirebson.RawDocument
 	ctx, span := otel.Tracer("").Start(ctx, "documentdb.Pool.GetMore")
 	defer span.End()
 
+	if t := p.r.GetTailable(cursorID); t != nil {
+		return p.tailableGetMore(ctx, spec, cursorID, t)
+	}
+
 	continuation, conn := p.r.GetCursor(cursorID)
 	if continuation == nil {
 		return nil, mongoerrors.New(
@@ -45,7 +50,7 @@ func (p *Pool) GetMore(ctx context.Context, db string, spec wirebson.RawDocument
 	}
 
 	if conn == nil {
-		poolConn, err := p.Acquire()
+		poolConn, err := p.Acquire(ctx)
 		if err != nil {
 			return nil, lazyerrors.Error(err)
 		}
@@ -85,12 +90,23 @@ func (p *Pool) KillCursor(ctx context.Context, id int64) bool {
 	return p.r.CloseCursor(ctx, id)
 }
 
+// KillIdleCursors closes cursors that were not used for longer than the given timeout.
+// It returns IDs of closed cursors.
+//
+// See [Pool.KillCursor] for the ctx usage.
+func (p *Pool) KillIdleCursors(ctx context.Context, timeout time.Duration) []int64 {
+	ctx, span := otel.Tracer("").Start(ctx, "documentdb.Pool.KillIdleCursors")
+	defer span.End()
+
+	return p.r.CloseIdle(ctx, timeout)
+}
+
 // ListCollections returns the first page of the `listCollections` cursor and the cursor ID.
 func (p *Pool) ListCollections(ctx context.Context, db string, spec wirebson.RawDocument) (wirebson.RawDocument, int64, error) {
 	ctx, span := otel.Tracer("").Start(ctx, "documentdb.Pool.ListCollections")
 	defer span.End()
 
-	poolConn, err := p.Acquire()
+	poolConn, err := p.Acquire(ctx)
 	if err != nil {
 		return nil, 0, lazyerrors.Error(err)
 	}
@@ -127,7 +143,7 @@ func (p *Pool) Find(ctx context.Context, db string, spec wirebson.RawDocument) (
 	ctx, span := otel.Tracer("").Start(ctx, "documentdb.Pool.Find")
 	defer span.End()
 
-	poolConn, err := p.Acquire()
+	poolConn, err := p.Acquire(ctx)
 	if err != nil {
 		return nil, 0, lazyerrors.Error(err)
 	}
@@ -164,7 +180,7 @@ func (p *Pool) Aggregate(ctx context.Context, db string, spec wirebson.RawDocume
 	ctx, span := otel.Tracer("").Start(ctx, "documentdb.Pool.Aggregate")
 	defer span.End()
 
-	poolConn, err := p.Acquire()
+	poolConn, err := p.Acquire(ctx)
 	if err != nil {
 		return nil, 0, lazyerrors.Error(err)
 	}
@@ -201,7 +217,7 @@ func (p *Pool) ListIndexes(ctx context.Context, db string, spec wirebson.RawDocu
 	ctx, span := otel.Tracer("").Start(ctx, "documentdb.Pool.ListIndexes")
 	defer span.End()
 
-	poolConn, err := p.Acquire()
+	poolConn, err := p.Acquire(ctx)
 	if err != nil {
 		return nil, 0, lazyerrors.Error(err)
 	}
@@ -151,11 +151,21 @@ func newPgxPoolCheckConn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, sp
 	var name, setting, description string
 	scans := []any{&name, &setting, &description}
 
+	var schemaValidation, collation string
+
 	_, err = pgx.ForEachRow(rows, scans, func() error {
 		switch name {
 		case "client_encoding", "server_encoding", "lc_collate", "lc_ctype", "standard_conforming_strings",
 			"documentdb.enableUserCrud", "documentdb.maxUserLimit":
 			l.DebugContext(ctx, "newPgxPoolCheckConn", slog.String(name, setting))
+
+		case "documentdb.enableSchemaValidation":
+			l.DebugContext(ctx, "newPgxPoolCheckConn", slog.String(name, setting))
+			schemaValidation = setting
+
+		case "documentdb.enableCollation":
+			l.DebugContext(ctx, "newPgxPoolCheckConn", slog.String(name, setting))
+			collation = setting
 		}
 
 		return nil
@@ -164,5 +174,21 @@ func newPgxPoolCheckConn(ctx context.Context, conn *pgx.Conn, l *slog.Logger, sp
 		return lazyerrors.Error(err)
 	}
 
+	// validators set by `create` and `collMod` are not enforced without that setting;
+	// older DocumentDB versions do not have it at all
+	if schemaValidation == "off" {
+		if _, err = conn.Exec(ctx, "SET documentdb.enableSchemaValidation TO true"); err != nil {
+			return lazyerrors.Error(err)
+		}
+	}
+
+	// `collation` parameters of queries and indexes are rejected without that setting;
+	// with it, DocumentDB maps them onto ICU collations
+	if collation == "off" {
+		if _, err = conn.Exec(ctx, "SET documentdb.enableCollation TO true"); err != nil {
+			return lazyerrors.Error(err)
+		}
+	}
+
 	return nil
 }
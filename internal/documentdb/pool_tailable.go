@@ -0,0 +1,416 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package documentdb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb/cursor"
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb/documentdb_api"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+)
+
+const (
+	// defaultTailableBatchSize is the default number of examined documents per batch.
+	defaultTailableBatchSize = 101
+
+	// defaultAwaitDataTimeout is the default time to wait for new documents in `getMore`.
+	defaultAwaitDataTimeout = time.Second
+)
+
+// FindTailable returns the first page of the tailable `find` cursor on the capped collection and the cursor ID.
+// Unlike other cursors, the cursor is not closed when it reaches the end of the collection.
+func (p *Pool) FindTailable(ctx context.Context, db string, spec wirebson.RawDocument) (wirebson.RawDocument, int64, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "documentdb.Pool.FindTailable")
+	defer span.End()
+
+	doc, err := spec.Decode()
+	if err != nil {
+		return nil, 0, lazyerrors.Error(err)
+	}
+
+	t := &cursor.Tailable{
+		DB:        db,
+		BatchSize: defaultTailableBatchSize,
+	}
+
+	t.Collection, _ = doc.Get("find").(string)
+	t.AwaitData, _ = doc.Get("awaitData").(bool)
+
+	if t.Filter, err = tailableDocumentParam(doc, "filter"); err != nil {
+		return nil, 0, lazyerrors.Error(err)
+	}
+
+	if t.Projection, err = tailableDocumentParam(doc, "projection"); err != nil {
+		return nil, 0, lazyerrors.Error(err)
+	}
+
+	if v := doc.Get("batchSize"); v != nil {
+		if bs, ok := tailableInt(v); ok && bs > 0 {
+			t.BatchSize = bs
+		}
+	}
+
+	limits, err := p.Capped(ctx, db, t.Collection)
+	if err != nil {
+		return nil, 0, lazyerrors.Error(err)
+	}
+
+	if limits == nil {
+		return nil, 0, mongoerrors.New(
+			mongoerrors.ErrBadValue,
+			"error processing query: "+db+"."+t.Collection+" tailable cursor requested on non capped collection",
+		)
+	}
+
+	batch, lastSeq, err := p.tailableBatch(ctx, t)
+	if err != nil {
+		return nil, 0, lazyerrors.Error(err)
+	}
+
+	t.LastSeq = lastSeq
+
+	cursorID := rand.Int64N(1<<63-1) + 1
+	p.r.NewTailableCursor(ctx, cursorID, t)
+
+	page, err := tailablePage(t, cursorID, "firstBatch", batch)
+	if err != nil {
+		return nil, 0, lazyerrors.Error(err)
+	}
+
+	return page, cursorID, nil
+}
+
+// AwaitData returns true if the given cursor is a tailable cursor
+// that waits for new documents in `getMore`.
+func (p *Pool) AwaitData(cursorID int64) bool {
+	t := p.r.GetTailable(cursorID)
+	return t != nil && t.AwaitData
+}
+
+// tailableGetMore returns the next page of the tailable cursor.
+// If there are no new documents and awaitData was set, it waits for them
+// up to `maxTimeMS` of `getMore` command (1 second by default).
+func (p *Pool) tailableGetMore(ctx context.Context, spec wirebson.RawDocument, cursorID int64, t *cursor.Tailable) (wirebson.RawDocument, error) {
+	doc, err := spec.Decode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if v := doc.Get("batchSize"); v != nil {
+		if bs, ok := tailableInt(v); ok && bs > 0 {
+			t.BatchSize = bs
+		}
+	}
+
+	wait := defaultAwaitDataTimeout
+	if v := doc.Get("maxTimeMS"); v != nil {
+		if ms, ok := tailableInt(v); ok && ms > 0 {
+			wait = time.Duration(ms) * time.Millisecond
+		}
+	}
+
+	limits, err := p.Capped(ctx, t.DB, t.Collection)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if limits == nil {
+		p.r.CloseCursor(ctx, cursorID)
+
+		return nil, mongoerrors.New(
+			mongoerrors.ErrCursorNotFound,
+			fmt.Sprintf("cursor id %d not found", cursorID),
+		)
+	}
+
+	deadline := time.NewTimer(wait)
+	defer deadline.Stop()
+
+	for {
+		// subscribe before querying to not miss inserts made in between
+		notified := p.cappedWait(t.DB, t.Collection)
+
+		batch, lastSeq, err := p.tailableBatch(ctx, t)
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		t.LastSeq = lastSeq
+		p.r.UpdateTailable(ctx, cursorID, lastSeq)
+
+		if len(batch) > 0 || !t.AwaitData {
+			return tailablePage(t, cursorID, "nextBatch", batch)
+		}
+
+		select {
+		case <-notified:
+		case <-deadline.C:
+			return tailablePage(t, cursorID, "nextBatch", nil)
+		case <-ctx.Done():
+			return nil, lazyerrors.Error(context.Cause(ctx))
+		}
+	}
+}
+
+// tailableBatch returns documents inserted after the last examined one that match the filter,
+// in insertion order, and the new position of the cursor.
+func (p *Pool) tailableBatch(ctx context.Context, t *cursor.Tailable) ([]wirebson.RawDocument, int64, error) {
+	lastSeq := t.LastSeq
+
+	var seqs []int64
+	var ids []wirebson.RawDocument
+
+	err := p.WithConn(ctx, func(conn *pgx.Conn) error {
+		rows, err := conn.Query(
+			ctx,
+			"SELECT seq, object_id FROM ferretdb.capped_documents "+
+				"WHERE database_name = $1 AND collection_name = $2 AND seq > $3 ORDER BY seq LIMIT $4",
+			t.DB, t.Collection, t.LastSeq, t.BatchSize,
+		)
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		var seq int64
+		var id []byte
+
+		_, err = pgx.ForEachRow(rows, []any{&seq, &id}, func() error {
+			seqs = append(seqs, seq)
+			ids = append(ids, slices.Clone(id))
+			return nil
+		})
+
+		return err
+	})
+	if err != nil {
+		return nil, 0, lazyerrors.Error(err)
+	}
+
+	if len(seqs) == 0 {
+		return nil, lastSeq, nil
+	}
+
+	lastSeq = seqs[len(seqs)-1]
+
+	docs, err := p.tailableFetch(ctx, t, ids)
+	if err != nil {
+		return nil, 0, lazyerrors.Error(err)
+	}
+
+	return docs, lastSeq, nil
+}
+
+// tailableFetch returns documents with the given _id values (in the same order)
+// that match the filter, with the projection applied.
+func (p *Pool) tailableFetch(ctx context.Context, t *cursor.Tailable, ids []wirebson.RawDocument) ([]wirebson.RawDocument, error) {
+	in := wirebson.MakeArray(len(ids))
+
+	for _, id := range ids {
+		d, err := id.Decode()
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		if err = in.Add(d.Get("_id")); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	var filter any = wirebson.MustDocument("_id", wirebson.MustDocument("$in", in))
+	if len(t.Filter) > 0 {
+		filter = wirebson.MustDocument("$and", wirebson.MustArray(t.Filter, filter))
+	}
+
+	// _id is needed to restore insertion order
+	var excludeID bool
+
+	find := wirebson.MustDocument(
+		"find", t.Collection,
+		"filter", filter,
+		"batchSize", int32(len(ids)),
+		"singleBatch", true,
+	)
+
+	if len(t.Projection) > 0 {
+		projection, err := t.Projection.Decode()
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		switch v := projection.Get("_id").(type) {
+		case bool:
+			excludeID = !v
+		case int32:
+			excludeID = v == 0
+		case int64:
+			excludeID = v == 0
+		case float64:
+			excludeID = v == 0
+		}
+
+		if excludeID {
+			projection.Remove("_id")
+		}
+
+		if projection.Len() > 0 {
+			if err = find.Add("projection", projection); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+		}
+	}
+
+	spec, err := find.Encode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	var page wirebson.RawDocument
+
+	err = p.WithConn(ctx, func(conn *pgx.Conn) error {
+		page, _, _, _, err = documentdb_api.FindCursorFirstPage(ctx, conn, p.l, t.DB, spec, 0)
+		return err
+	})
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	p.l.DebugContext(ctx, "Tailable fetch result", slog.Any("page", logging.LazyDecoder(page)))
+
+	pageDoc, err := page.DecodeDeep()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	c, _ := pageDoc.Get("cursor").(*wirebson.Document)
+	if c == nil {
+		return nil, lazyerrors.Errorf("no cursor in %s", page)
+	}
+
+	firstBatch, _ := c.Get("firstBatch").(*wirebson.Array)
+
+	found := make(map[string]*wirebson.Document, firstBatch.Len())
+
+	for v := range firstBatch.Values() {
+		d, ok := v.(*wirebson.Document)
+		if !ok {
+			return nil, lazyerrors.Errorf("unexpected document %T", v)
+		}
+
+		id, err := wirebson.MustDocument("_id", d.Get("_id")).Encode()
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		found[string(id)] = d
+	}
+
+	res := make([]wirebson.RawDocument, 0, len(found))
+
+	for _, id := range ids {
+		d := found[string(id)]
+		if d == nil {
+			continue
+		}
+
+		if excludeID {
+			d.Remove("_id")
+		}
+
+		raw, err := d.Encode()
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		res = append(res, raw)
+	}
+
+	return res, nil
+}
+
+// tailablePage returns a `find` or `getMore` response for the tailable cursor.
+func tailablePage(t *cursor.Tailable, cursorID int64, batchField string, batch []wirebson.RawDocument) (wirebson.RawDocument, error) {
+	arr := wirebson.MakeArray(len(batch))
+
+	for _, d := range batch {
+		if err := arr.Add(d); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	res, err := wirebson.MustDocument(
+		"cursor", wirebson.MustDocument(
+			batchField, arr,
+			"id", cursorID,
+			"ns", t.DB+"."+t.Collection,
+		),
+		"ok", float64(1),
+	).Encode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return res, nil
+}
+
+// tailableDocumentParam returns a non-empty document parameter, or nil.
+func tailableDocumentParam(doc *wirebson.Document, name string) (wirebson.RawDocument, error) {
+	switch v := doc.Get(name).(type) {
+	case nil:
+		return nil, nil
+	case wirebson.RawDocument:
+		if len(v) <= 5 {
+			return nil, nil
+		}
+
+		return v, nil
+	case *wirebson.Document:
+		if v.Len() == 0 {
+			return nil, nil
+		}
+
+		return v.Encode()
+	default:
+		return nil, mongoerrors.New(
+			mongoerrors.ErrTypeMismatch,
+			fmt.Sprintf("BSON field 'find.%s' is the wrong type '%T', expected type 'object'", name, v),
+		)
+	}
+}
+
+// tailableInt returns an integer value of the numeric parameter.
+func tailableInt(v any) (int64, bool) {
+	switch v := v.(type) {
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case float64:
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package documentdb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/jackc/pgx/v5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.opentelemetry.io/otel"
+
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb/documentdb_api"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+)
+
+// ProfileCollection is the name of the collection that stores database profiler entries.
+const ProfileCollection = "system.profile"
+
+// profileSize is the maximum size of the profile collection in bytes, the same as MongoDB's.
+const profileSize = 1024 * 1024
+
+// InsertProfile inserts the given database profiler entry into the profile collection of the given database.
+// If the collection does not exist, it is created as a capped collection.
+// Existing profile collections are remembered, so creation is not attempted for every entry.
+//
+// The entry should not have _id field; a new ObjectID is added.
+func (p *Pool) InsertProfile(ctx context.Context, db string, entry *wirebson.Document) error {
+	ctx, span := otel.Tracer("").Start(ctx, "documentdb.Pool.InsertProfile")
+	defer span.End()
+
+	limits, err := p.Capped(ctx, db, ProfileCollection)
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	_, uncapped := p.uncappedProfiles.Load(db)
+
+	if limits == nil && !uncapped {
+		limits = &CappedCollection{Size: profileSize}
+
+		// the collection could be created by the user with different options; use it as is
+		err = p.CreateCapped(ctx, db, ProfileCollection, limits)
+
+		var mErr *mongoerrors.Error
+		if errors.As(err, &mErr) && mErr.Code == int32(mongoerrors.ErrNamespaceExists) {
+			p.uncappedProfiles.Store(db, struct{}{})
+			limits, err = nil, nil
+		}
+
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+	}
+
+	id, err := wirebson.FromDriver(bson.NewObjectID())
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	doc := wirebson.MakeDocument(entry.Len() + 1)
+	if err = doc.Add("_id", id); err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	for k, v := range entry.All() {
+		if err = doc.Add(k, v); err != nil {
+			return lazyerrors.Error(err)
+		}
+	}
+
+	raw, err := doc.Encode()
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	spec, err := wirebson.MustDocument(
+		"insert", ProfileCollection,
+		"documents", wirebson.MustArray(raw),
+	).Encode()
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	insert := func(conn *pgx.Conn) error {
+		_, _, err := documentdb_api.Insert(ctx, conn, p.l, db, spec, nil)
+		return err
+	}
+
+	if limits == nil {
+		err = p.WithConn(ctx, insert)
+	} else {
+		err = p.CappedInsert(ctx, db, ProfileCollection, limits, func(conn *pgx.Conn) ([]wirebson.RawDocument, error) {
+			if err := insert(conn); err != nil {
+				return nil, err
+			}
+
+			return []wirebson.RawDocument{raw}, nil
+		})
+	}
+
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	return nil
+}
+
+// forgetUncappedProfiles forgets that the profile collection of the given database is not capped
+// if the given collection (or the whole database if coll is empty) was dropped or renamed.
+func (p *Pool) forgetUncappedProfiles(db, coll string) {
+	if coll != "" && coll != ProfileCollection {
+		return
+	}
+
+	p.uncappedProfiles.Delete(db)
+}
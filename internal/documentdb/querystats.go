@@ -0,0 +1,128 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package documentdb
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// maxQueryStats is the maximum number of queries recorded by [QueryStats].
+// Queries after that are only counted.
+const maxQueryStats = 100
+
+// QueryStats collects PostgreSQL queries executed and pool wait time for a single operation.
+//
+// It is safe for concurrent use.
+type QueryStats struct {
+	m        sync.Mutex
+	queries  []Query
+	total    int
+	poolWait time.Duration
+}
+
+// Query represents a single PostgreSQL query recorded by [QueryStats].
+//
+// Arguments are not recorded as they may contain user data.
+type Query struct {
+	SQL      string
+	Duration time.Duration
+	Err      error
+}
+
+// queryStatsKey is a named unexported type for the safe use of [context.WithValue].
+type queryStatsKey struct{}
+
+// querySQLKey is a named unexported type for the safe use of [context.WithValue].
+type querySQLKey struct{}
+
+// acquireStartKey is a named unexported type for the safe use of [context.WithValue].
+type acquireStartKey struct{}
+
+// WithQueryStats returns a derived context that makes the pool record queries and pool wait time
+// of operations executed with it into s.
+func WithQueryStats(ctx context.Context, s *QueryStats) context.Context {
+	return context.WithValue(ctx, queryStatsKey{}, s)
+}
+
+// Queries returns recorded queries and the total number of executed queries.
+func (s *QueryStats) Queries() ([]Query, int) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return slices.Clone(s.queries), s.total
+}
+
+// PoolWait returns the total time spent waiting for pool connections.
+func (s *QueryStats) PoolWait() time.Duration {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.poolWait
+}
+
+// startAcquireStats stores connection acquisition start time, if requested by context.
+func startAcquireStats(ctx context.Context) context.Context {
+	if s, _ := ctx.Value(queryStatsKey{}).(*QueryStats); s == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, acquireStartKey{}, time.Now())
+}
+
+// endAcquireStats records connection acquisition time stored by [startAcquireStats].
+func endAcquireStats(ctx context.Context) {
+	s, _ := ctx.Value(queryStatsKey{}).(*QueryStats)
+	start, _ := ctx.Value(acquireStartKey{}).(time.Time)
+
+	if s == nil || start.IsZero() {
+		return
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.poolWait += time.Since(start)
+}
+
+// startQueryStats stores query's SQL, if requested by context.
+func startQueryStats(ctx context.Context, sql string) context.Context {
+	if s, _ := ctx.Value(queryStatsKey{}).(*QueryStats); s == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, querySQLKey{}, sql)
+}
+
+// endQueryStats records query stored by [startQueryStats].
+func endQueryStats(ctx context.Context, duration time.Duration, err error) {
+	s, _ := ctx.Value(queryStatsKey{}).(*QueryStats)
+	sql, ok := ctx.Value(querySQLKey{}).(string)
+
+	if s == nil || !ok {
+		return
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.total++
+
+	if len(s.queries) < maxQueryStats {
+		s.queries = append(s.queries, Query{SQL: sql, Duration: duration, Err: err})
+	}
+}
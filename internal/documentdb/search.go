@@ -0,0 +1,143 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package documentdb
+
+import (
+	"context"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+// SearchIndex represents an Atlas-style search index.
+//
+// DocumentDB supports vector indexes as regular indexes with `cosmosSearch` key;
+// we store the original definition ourselves for listing.
+type SearchIndex struct {
+	ID         string
+	Name       string
+	Type       string
+	Definition wirebson.RawDocument
+}
+
+// CreateSearchIndex stores the definition of a search index.
+// It is a part of the implementation of the `createSearchIndexes` command;
+// the index itself should be created with `createIndexes` first.
+func (p *Pool) CreateSearchIndex(ctx context.Context, db, coll string, index *SearchIndex) error {
+	ctx, span := otel.Tracer("").Start(ctx, "documentdb.Pool.CreateSearchIndex")
+	defer span.End()
+
+	err := p.WithConn(ctx, func(conn *pgx.Conn) error {
+		if err := p.createMetadata(ctx, conn); err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		_, err := conn.Exec(
+			ctx,
+			"INSERT INTO ferretdb.search_indexes "+
+				"(database_name, collection_name, index_name, index_id, index_type, definition) "+
+				"VALUES ($1, $2, $3, $4, $5, $6) "+
+				"ON CONFLICT (database_name, collection_name, index_name) DO UPDATE "+
+				"SET index_id = $4, index_type = $5, definition = $6",
+			db, coll, index.Name, index.ID, index.Type, []byte(index.Definition),
+		)
+
+		return err
+	})
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	return nil
+}
+
+// ListSearchIndexes returns search indexes of the given collection sorted by name.
+func (p *Pool) ListSearchIndexes(ctx context.Context, db, coll string) ([]SearchIndex, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "documentdb.Pool.ListSearchIndexes")
+	defer span.End()
+
+	var res []SearchIndex
+
+	err := p.WithConn(ctx, func(conn *pgx.Conn) error {
+		exists, err := p.metadataExists(ctx, conn)
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		if !exists {
+			return nil
+		}
+
+		rows, err := conn.Query(
+			ctx,
+			"SELECT index_id, index_name, index_type, definition FROM ferretdb.search_indexes "+
+				"WHERE database_name = $1 AND collection_name = $2 ORDER BY index_name",
+			db, coll,
+		)
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		var index SearchIndex
+		var definition []byte
+
+		_, err = pgx.ForEachRow(rows, []any{&index.ID, &index.Name, &index.Type, &definition}, func() error {
+			index.Definition = wirebson.RawDocument(definition)
+			res = append(res, index)
+
+			return nil
+		})
+
+		return err
+	})
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return res, nil
+}
+
+// DropSearchIndex removes the definition of a search index with the given name.
+// It is a no-op if it does not exist.
+func (p *Pool) DropSearchIndex(ctx context.Context, db, coll, name string) error {
+	ctx, span := otel.Tracer("").Start(ctx, "documentdb.Pool.DropSearchIndex")
+	defer span.End()
+
+	err := p.WithConn(ctx, func(conn *pgx.Conn) error {
+		exists, err := p.metadataExists(ctx, conn)
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		if !exists {
+			return nil
+		}
+
+		_, err = conn.Exec(
+			ctx,
+			"DELETE FROM ferretdb.search_indexes WHERE database_name = $1 AND collection_name = $2 AND index_name = $3",
+			db, coll, name,
+		)
+
+		return err
+	})
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package documentdb
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+// PoolStats represents the state of PostgreSQL connection pool.
+type PoolStats struct {
+	Addr string // PostgreSQL host and port
+
+	Total        int32
+	Acquired     int32
+	Idle         int32
+	Constructing int32
+	Max          int32
+
+	Created          int64
+	Acquires         int64
+	EmptyAcquires    int64
+	CanceledAcquires int64
+	AcquireDuration  time.Duration
+
+	// Connections hijacked from the pool by persisted cursors, and all cursors.
+	CursorConns int32
+	Cursors     int32
+}
+
+// Stats returns the current state of PostgreSQL connection pool.
+func (p *Pool) Stats() *PoolStats {
+	stats := p.p.Stat()
+	cursors := p.r.Stats()
+	config := p.p.Config().ConnConfig
+
+	return &PoolStats{
+		Addr:             net.JoinHostPort(config.Host, strconv.Itoa(int(config.Port))),
+		Total:            stats.TotalConns(),
+		Acquired:         stats.AcquiredConns(),
+		Idle:             stats.IdleConns(),
+		Constructing:     stats.ConstructingConns(),
+		Max:              stats.MaxConns(),
+		Created:          stats.NewConnsCount(),
+		Acquires:         stats.AcquireCount(),
+		EmptyAcquires:    stats.EmptyAcquireCount(),
+		CanceledAcquires: stats.CanceledAcquireCount(),
+		AcquireDuration:  stats.AcquireDuration(),
+		CursorConns:      int32(cursors.Persisted),
+		Cursors:          int32(cursors.Cursors),
+	}
+}
+
+// CatalogStats represents the numbers of collections and views in DocumentDB catalog.
+//
+// Collections and views in `admin`, `config`, and `local` databases,
+// and `system.*` collections are internal.
+type CatalogStats struct {
+	Collections         int32
+	Views               int32
+	InternalCollections int32
+	InternalViews       int32
+}
+
+// CatalogStats returns the numbers of collections and views.
+func (p *Pool) CatalogStats(ctx context.Context) (*CatalogStats, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "documentdb.Pool.CatalogStats")
+	defer span.End()
+
+	q := `
+	SELECT
+		count(*) FILTER (WHERE NOT is_internal AND NOT is_view)::integer,
+		count(*) FILTER (WHERE NOT is_internal AND is_view)::integer,
+		count(*) FILTER (WHERE is_internal AND NOT is_view)::integer,
+		count(*) FILTER (WHERE is_internal AND is_view)::integer
+	FROM (
+		SELECT
+			database_name IN ('admin', 'config', 'local') OR collection_name LIKE 'system.%' AS is_internal,
+			view_definition IS NOT NULL AS is_view
+		FROM documentdb_api_catalog.collections
+	) AS c`
+
+	var res CatalogStats
+
+	err := p.WithConn(ctx, func(conn *pgx.Conn) error {
+		return conn.QueryRow(ctx, q).Scan(&res.Collections, &res.Views, &res.InternalCollections, &res.InternalViews)
+	})
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return &res, nil
+}
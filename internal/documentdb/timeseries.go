@@ -0,0 +1,99 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package documentdb
+
+import (
+	"context"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+// TimeSeries represents options of a time series collection.
+//
+// DocumentDB does not support time series collections.
+// We store their documents as is (not bucketed) in a regular collection,
+// so reads do not need any special handling,
+// and track options ourselves for validation, listing, and expiration.
+type TimeSeries struct {
+	TimeField          string
+	MetaField          string // empty if not set
+	Granularity        string
+	ExpireAfterSeconds int64 // 0 means documents do not expire
+}
+
+// timeseriesQuery returns options of all time series collections.
+const timeseriesQuery = "SELECT database_name, collection_name, time_field, meta_field, granularity, expire_after_seconds " +
+	"FROM ferretdb.timeseries_collections"
+
+// scanTimeSeries is a [metadataScanner] for [timeseriesQuery].
+func scanTimeSeries(rows pgx.Rows) (string, string, *TimeSeries, error) {
+	var db, coll string
+	var res TimeSeries
+
+	if err := rows.Scan(&db, &coll, &res.TimeField, &res.MetaField, &res.Granularity, &res.ExpireAfterSeconds); err != nil {
+		return "", "", nil, lazyerrors.Error(err)
+	}
+
+	return db, coll, &res, nil
+}
+
+// TimeSeries returns options of the given time series collection, or nil if the collection is not a time series.
+func (p *Pool) TimeSeries(ctx context.Context, db, coll string) (*TimeSeries, error) {
+	res, err := p.timeseries.get(ctx, p, timeseriesQuery, scanTimeSeries, db, coll)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return res, nil
+}
+
+// CountTimeSeries returns the number of time series collections.
+func (p *Pool) CountTimeSeries(ctx context.Context) (int, error) {
+	all, err := p.allTimeSeries(ctx)
+	if err != nil {
+		return 0, lazyerrors.Error(err)
+	}
+
+	return len(all), nil
+}
+
+// allTimeSeries returns options of all time series collections keyed by namespace.
+func (p *Pool) allTimeSeries(ctx context.Context) (map[string]TimeSeries, error) {
+	res, err := p.timeseries.all(ctx, p, timeseriesQuery, scanTimeSeries)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return res, nil
+}
+
+// CreateTimeSeries creates a new time series collection with the given options.
+// It is a part of the implementation of the `create` command.
+//
+// It is not an error if the same time series collection already exists.
+func (p *Pool) CreateTimeSeries(ctx context.Context, db, coll string, ts *TimeSeries) error {
+	ctx, span := otel.Tracer("").Start(ctx, "documentdb.Pool.CreateTimeSeries")
+	defer span.End()
+
+	return createWithMetadata(
+		ctx, p, &p.timeseries, timeseriesQuery, scanTimeSeries, db, coll, ts,
+		"INSERT INTO ferretdb.timeseries_collections "+
+			"(database_name, collection_name, time_field, meta_field, granularity, expire_after_seconds) "+
+			"VALUES ($1, $2, $3, $4, $5, $6)",
+		ts.TimeField, ts.MetaField, ts.Granularity, ts.ExpireAfterSeconds,
+	)
+}
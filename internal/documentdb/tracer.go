@@ -105,6 +105,8 @@ func newTracer(l *slog.Logger) *tracer {
 // It is called at the beginning of [pgxpool.Pool.Acquire].
 // The returned context is used for the rest of the call and will be passed to the [tracer.TraceAcquireEnd].
 func (t *tracer) TraceAcquireStart(ctx context.Context, pool *pgxpool.Pool, data pgxpool.TraceAcquireStartData) context.Context {
+	ctx = startAcquireStats(ctx)
+
 	return t.tl.TraceAcquireStart(ctx, pool, data)
 }
 
@@ -112,6 +114,8 @@ func (t *tracer) TraceAcquireStart(ctx context.Context, pool *pgxpool.Pool, data
 //
 // It is called when a connection has been acquired.
 func (t *tracer) TraceAcquireEnd(ctx context.Context, pool *pgxpool.Pool, data pgxpool.TraceAcquireEndData) {
+	endAcquireStats(ctx)
+
 	t.tl.TraceAcquireEnd(ctx, pool, data)
 }
 
@@ -175,6 +179,9 @@ func (t *tracer) TracePrepareEnd(ctx context.Context, conn *pgx.Conn, data pgx.T
 func (t *tracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
 	ctx = context.WithValue(ctx, queryKey, time.Now())
 
+	startBackendQuery(ctx, conn)
+	ctx = startQueryStats(ctx, data.SQL)
+
 	t.requests.With(prometheus.Labels{}).Inc()
 
 	ctx, _ = otel.Tracer("").Start(
@@ -193,6 +200,9 @@ func (t *tracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.T
 func (t *tracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
 	duration := time.Since(ctx.Value(queryKey).(time.Time))
 
+	endBackendQuery(ctx, conn)
+	endQueryStats(ctx, duration, data.Err)
+
 	t.duration.With(prometheus.Labels{}).Observe(duration.Seconds())
 
 	t.tl.TraceQueryEnd(ctx, conn, data)
@@ -0,0 +1,376 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package documentdb
+
+import (
+	"context"
+	"encoding/binary"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb/documentdb_api"
+)
+
+// TTL represents a TTL index, or an expiration option of a time series or clustered collection.
+type TTL struct {
+	DB                 string
+	Collection         string
+	Field              string
+	ExpireAfterSeconds int64
+}
+
+// ttlListBatchSize is a batch size for listing collections and indexes;
+// it is large enough to get all of them in the first page.
+const ttlListBatchSize = int32(100_000)
+
+// ListTTL returns all TTL indexes of all collections in all databases,
+// and expiration options of time series and clustered collections.
+//
+// Indexes are listed with the same DocumentDB functions as `listIndexes` command.
+func (p *Pool) ListTTL(ctx context.Context) ([]TTL, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "documentdb.Pool.ListTTL")
+	defer span.End()
+
+	var res []TTL
+
+	err := p.WithConn(ctx, func(conn *pgx.Conn) error {
+		dbs, err := p.listDatabaseNames(ctx, conn)
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		for _, db := range dbs {
+			colls, err := p.listCollectionNames(ctx, conn, db)
+			if err != nil {
+				return lazyerrors.Error(err)
+			}
+
+			for _, coll := range colls {
+				indexes, err := p.listTTLIndexes(ctx, conn, db, coll)
+				if err != nil {
+					return lazyerrors.Error(err)
+				}
+
+				res = append(res, indexes...)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	timeseries, err := p.allTimeSeries(ctx)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	for ns, ts := range timeseries {
+		if ts.ExpireAfterSeconds == 0 {
+			continue
+		}
+
+		db, coll, _ := strings.Cut(ns, ".")
+
+		res = append(res, TTL{
+			DB:                 db,
+			Collection:         coll,
+			Field:              ts.TimeField,
+			ExpireAfterSeconds: ts.ExpireAfterSeconds,
+		})
+	}
+
+	clustered, err := p.allClustered(ctx)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	for ns, c := range clustered {
+		if c.ExpireAfterSeconds == 0 {
+			continue
+		}
+
+		db, coll, _ := strings.Cut(ns, ".")
+
+		res = append(res, TTL{
+			DB:                 db,
+			Collection:         coll,
+			Field:              "_id",
+			ExpireAfterSeconds: c.ExpireAfterSeconds,
+		})
+	}
+
+	return res, nil
+}
+
+// DeleteExpired removes up to batchSize expired documents using the given TTL index.
+// It returns the number of removed documents.
+//
+// Like in MongoDB, documents expire when the indexed field is a date (or an array with a date)
+// older than expireAfterSeconds.
+// For clustered collections, documents with ObjectID _id values expire using their timestamps.
+func (p *Pool) DeleteExpired(ctx context.Context, ttl *TTL, batchSize int32) (int32, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "documentdb.Pool.DeleteExpired")
+	defer span.End()
+
+	expired := time.Now().Add(-time.Duration(ttl.ExpireAfterSeconds) * time.Second)
+
+	filter := wirebson.MustDocument(ttl.Field, wirebson.MustDocument("$lt", expired))
+
+	// TTL indexes on _id are not allowed, so that's a clustered collection;
+	// ObjectIDs are never less than dates, so they are compared with the ObjectID
+	// that has the same timestamp and zeros in other bytes
+	if ttl.Field == "_id" {
+		var id wirebson.ObjectID
+		binary.BigEndian.PutUint32(id[:4], uint32(expired.Unix()))
+
+		filter = wirebson.MustDocument("$or", wirebson.MustArray(
+			filter,
+			wirebson.MustDocument("_id", wirebson.MustDocument("$lt", id)),
+		))
+	}
+
+	findSpec, err := wirebson.MustDocument(
+		"find", ttl.Collection,
+		"filter", filter,
+		"projection", wirebson.MustDocument("_id", int32(1)),
+		"limit", batchSize,
+		"batchSize", batchSize,
+		"singleBatch", true,
+	).Encode()
+	if err != nil {
+		return 0, lazyerrors.Error(err)
+	}
+
+	var n int32
+
+	err = p.WithConn(ctx, func(conn *pgx.Conn) error {
+		page, _, _, _, err := documentdb_api.FindCursorFirstPage(ctx, conn, p.l, ttl.DB, findSpec, 0)
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		docs, err := firstBatch(page)
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		if docs.Len() == 0 {
+			return nil
+		}
+
+		ids := wirebson.MakeArray(docs.Len())
+
+		for v := range docs.Values() {
+			if d, ok := v.(*wirebson.Document); ok {
+				if err = ids.Add(d.Get("_id")); err != nil {
+					return lazyerrors.Error(err)
+				}
+			}
+		}
+
+		deleteSpec, err := wirebson.MustDocument(
+			"delete", ttl.Collection,
+			"deletes", wirebson.MustArray(wirebson.MustDocument(
+				"q", wirebson.MustDocument("_id", wirebson.MustDocument("$in", ids)),
+				"limit", int32(0),
+			)),
+		).Encode()
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		res, _, err := documentdb_api.Delete(ctx, conn, p.l, ttl.DB, deleteSpec, nil)
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		resDoc, err := res.Decode()
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		n, _ = resDoc.Get("n").(int32)
+
+		return nil
+	})
+	if err != nil {
+		return 0, lazyerrors.Error(err)
+	}
+
+	if n > 0 {
+		p.l.DebugContext(
+			ctx, "Removed expired documents",
+			slog.String("ns", metadataKey(ttl.DB, ttl.Collection)), slog.String("field", ttl.Field), slog.Int("count", int(n)),
+		)
+	}
+
+	return n, nil
+}
+
+// listDatabaseNames returns names of all databases.
+func (p *Pool) listDatabaseNames(ctx context.Context, conn *pgx.Conn) ([]string, error) {
+	spec, err := wirebson.MustDocument("listDatabases", int32(1), "nameOnly", true).Encode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	raw, err := documentdb_api.ListDatabases(ctx, conn, p.l, spec)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	doc, err := raw.DecodeDeep()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	dbs, _ := doc.Get("databases").(*wirebson.Array)
+	if dbs == nil {
+		return nil, lazyerrors.Errorf("no databases in %s", raw)
+	}
+
+	var res []string
+
+	for v := range dbs.Values() {
+		if d, ok := v.(*wirebson.Document); ok {
+			if name, _ := d.Get("name").(string); name != "" {
+				res = append(res, name)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// listCollectionNames returns names of all collections (but not views) in the given database.
+func (p *Pool) listCollectionNames(ctx context.Context, conn *pgx.Conn, db string) ([]string, error) {
+	spec, err := wirebson.MustDocument(
+		"listCollections", int32(1),
+		"filter", wirebson.MustDocument("type", "collection"),
+		"nameOnly", true,
+		"cursor", wirebson.MustDocument("batchSize", ttlListBatchSize),
+	).Encode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	page, _, _, _, err := documentdb_api.ListCollectionsCursorFirstPage(ctx, conn, p.l, db, spec, 0)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	colls, err := firstBatch(page)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	var res []string
+
+	for v := range colls.Values() {
+		if d, ok := v.(*wirebson.Document); ok {
+			if name, _ := d.Get("name").(string); name != "" {
+				res = append(res, name)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// listTTLIndexes returns TTL indexes of the given collection.
+func (p *Pool) listTTLIndexes(ctx context.Context, conn *pgx.Conn, db, coll string) ([]TTL, error) {
+	spec, err := wirebson.MustDocument(
+		"listIndexes", coll,
+		"cursor", wirebson.MustDocument("batchSize", ttlListBatchSize),
+	).Encode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	page, _, _, _, err := documentdb_api.ListIndexesCursorFirstPage(ctx, conn, p.l, db, spec, 0)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	indexes, err := firstBatch(page)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	var res []TTL
+
+	for v := range indexes.Values() {
+		index, ok := v.(*wirebson.Document)
+		if !ok {
+			continue
+		}
+
+		var expireAfterSeconds int64
+
+		switch v := index.Get("expireAfterSeconds").(type) {
+		case int32:
+			expireAfterSeconds = int64(v)
+		case int64:
+			expireAfterSeconds = v
+		case float64:
+			expireAfterSeconds = int64(v)
+		default:
+			continue
+		}
+
+		// TTL indexes are single-field indexes
+		key, _ := index.Get("key").(*wirebson.Document)
+		if key == nil || key.Len() != 1 {
+			continue
+		}
+
+		res = append(res, TTL{
+			DB:                 db,
+			Collection:         coll,
+			Field:              key.FieldNames()[0],
+			ExpireAfterSeconds: expireAfterSeconds,
+		})
+	}
+
+	return res, nil
+}
+
+// firstBatch returns the first batch of the cursor page.
+func firstBatch(page wirebson.RawDocument) (*wirebson.Array, error) {
+	doc, err := page.DecodeDeep()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	c, _ := doc.Get("cursor").(*wirebson.Document)
+	if c == nil {
+		return nil, lazyerrors.Errorf("no cursor in %s", page)
+	}
+
+	res, _ := c.Get("firstBatch").(*wirebson.Array)
+	if res == nil {
+		return nil, lazyerrors.Errorf("no firstBatch in %s", page)
+	}
+
+	return res, nil
+}
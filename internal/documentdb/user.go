@@ -16,12 +16,14 @@
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
 	"github.com/AlekSi/lazyerrors"
 	"github.com/FerretDB/wire/wirebson"
 	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
 
 	"github.com/FerretDB/FerretDB/v2/internal/documentdb/documentdb_api"
 )
@@ -92,3 +94,26 @@ func CreateUser(ctx context.Context, conn *pgx.Conn, l *slog.Logger, docV wirebs
 
 	return res, nil
 }
+
+// Superuser returns true if the given user has PostgreSQL's SUPERUSER privileges,
+// like users with the `clusterAdmin` role (see [CreateUser]).
+// It returns false for unknown users.
+func (p *Pool) Superuser(ctx context.Context, username string) (bool, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "documentdb.Pool.Superuser")
+	defer span.End()
+
+	var res bool
+
+	err := p.WithConn(ctx, func(conn *pgx.Conn) error {
+		return conn.QueryRow(ctx, "SELECT rolsuper FROM pg_roles WHERE rolname = $1", username).Scan(&res)
+	})
+
+	switch {
+	case err == nil:
+		return res, nil
+	case errors.Is(err, pgx.ErrNoRows):
+		return false, nil
+	default:
+		return false, lazyerrors.Error(err)
+	}
+}
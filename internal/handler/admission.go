@@ -0,0 +1,233 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+)
+
+// admissionExempt contains commands that are never queued by [admission],
+// in addition to commands that do not require authentication.
+// They are needed to diagnose and fix overload.
+var admissionExempt = map[string]struct{}{
+	"connPoolStats": {},
+	"currentOp":     {},
+	"getLog":        {},
+	"killOp":        {},
+	"serverStatus":  {},
+	"top":           {},
+}
+
+// admission limits the number of concurrently handled requests
+// to avoid cascading PostgreSQL connection pool exhaustion.
+//
+// Requests over the limit wait in the queue for a bounded time.
+type admission struct {
+	global  chan struct{}
+	perUser int
+	maxWait atomic.Int64 // time.Duration; could be changed at runtime
+
+	m     sync.Mutex
+	users map[string]*admissionUser
+
+	active   prometheus.Gauge
+	queued   prometheus.Gauge
+	rejected *prometheus.CounterVec
+}
+
+// admissionUser tracks concurrently handled requests of a single user.
+type admissionUser struct {
+	sem  chan struct{}
+	refs int // number of requests holding or waiting for sem
+}
+
+// newAdmission creates a new admission queue.
+//
+// maxRequests is a maximum number of concurrently handled requests; it must be positive.
+// maxUserRequests is a maximum number of concurrently handled requests of a single user;
+// zero value means no limit.
+// maxWait is a maximum time to wait in the queue; zero value means no limit.
+func newAdmission(maxRequests, maxUserRequests int, maxWait time.Duration) *admission {
+	a := &admission{
+		global:  make(chan struct{}, maxRequests),
+		perUser: maxUserRequests,
+		users:   map[string]*admissionUser{},
+		active: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "ferretdb",
+			Subsystem: "admission",
+			Name:      "active",
+			Help:      "The number of currently handled requests.",
+		}),
+		queued: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "ferretdb",
+			Subsystem: "admission",
+			Name:      "queued",
+			Help:      "The number of requests waiting in the admission queue.",
+		}),
+		rejected: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "ferretdb",
+				Subsystem: "admission",
+				Name:      "rejected_total",
+				Help:      "Total number of requests rejected by the admission queue.",
+			},
+			[]string{"reason"},
+		),
+	}
+
+	a.setMaxWait(maxWait)
+
+	return a
+}
+
+// getMaxWait returns the maximum time to wait in the queue.
+func (a *admission) getMaxWait() time.Duration {
+	return time.Duration(a.maxWait.Load())
+}
+
+// setMaxWait changes the maximum time to wait in the queue for new requests.
+func (a *admission) setMaxWait(d time.Duration) {
+	a.maxWait.Store(int64(d))
+}
+
+// admit waits until the request of the given user could be handled.
+// Empty user means that per-user limit is not applied.
+//
+// If the request is admitted, the returned function must be called when it is handled.
+// Otherwise, an error is returned: the context error or ExceededTimeLimit.
+func (a *admission) admit(ctx context.Context, user string) (func(), error) {
+	a.queued.Inc()
+	defer a.queued.Dec()
+
+	var timeout <-chan time.Time
+
+	maxWait := a.getMaxWait()
+	if maxWait > 0 {
+		t := time.NewTimer(maxWait)
+		defer t.Stop()
+
+		timeout = t.C
+	}
+
+	var u *admissionUser
+
+	if a.perUser > 0 && user != "" {
+		u = a.getUser(user)
+
+		select {
+		case u.sem <- struct{}{}:
+		case <-timeout:
+			a.putUser(user)
+			return nil, a.reject("user", maxWait)
+		case <-ctx.Done():
+			a.putUser(user)
+			return nil, lazyerrors.Error(ctx.Err())
+		}
+	}
+
+	release := func() {
+		if u != nil {
+			<-u.sem
+			a.putUser(user)
+		}
+	}
+
+	select {
+	case a.global <- struct{}{}:
+	case <-timeout:
+		release()
+		return nil, a.reject("global", maxWait)
+	case <-ctx.Done():
+		release()
+		return nil, lazyerrors.Error(ctx.Err())
+	}
+
+	a.active.Inc()
+
+	return func() {
+		a.active.Dec()
+		<-a.global
+		release()
+	}, nil
+}
+
+// reject counts rejection for the given reason and returns an error for the client.
+func (a *admission) reject(reason string, maxWait time.Duration) error {
+	a.rejected.WithLabelValues(reason).Inc()
+
+	msg := fmt.Sprintf("Request was not admitted within %s: too many concurrent requests", maxWait)
+	if reason == "user" {
+		msg = fmt.Sprintf("Request was not admitted within %s: too many concurrent requests for user", maxWait)
+	}
+
+	return mongoerrors.NewWithArgument(mongoerrors.ErrExceededTimeLimit, msg, "admission")
+}
+
+// getUser returns the user's state, creating it if needed.
+// [admission.putUser] must be called after that.
+func (a *admission) getUser(user string) *admissionUser {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	u := a.users[user]
+	if u == nil {
+		u = &admissionUser{sem: make(chan struct{}, a.perUser)}
+		a.users[user] = u
+	}
+
+	u.refs++
+
+	return u
+}
+
+// putUser releases the user's state returned by [admission.getUser].
+func (a *admission) putUser(user string) {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	u := a.users[user]
+
+	if u.refs--; u.refs == 0 {
+		delete(a.users, user)
+	}
+}
+
+// Describe implements [prometheus.Collector].
+func (a *admission) Describe(ch chan<- *prometheus.Desc) {
+	a.active.Describe(ch)
+	a.queued.Describe(ch)
+	a.rejected.Describe(ch)
+}
+
+// Collect implements [prometheus.Collector].
+func (a *admission) Collect(ch chan<- prometheus.Metric) {
+	a.active.Collect(ch)
+	a.queued.Collect(ch)
+	a.rejected.Collect(ch)
+}
+
+// check interfaces
+var (
+	_ prometheus.Collector = (*admission)(nil)
+)
@@ -0,0 +1,102 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+)
+
+func TestAdmission(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("Global", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAdmission(1, 0, 10*time.Millisecond)
+
+		release, err := a.admit(ctx, "a")
+		require.NoError(t, err)
+
+		_, err = a.admit(ctx, "b")
+		require.Error(t, err)
+
+		var mErr *mongoerrors.Error
+		require.ErrorAs(t, err, &mErr)
+		assert.Equal(t, int32(mongoerrors.ErrExceededTimeLimit), mErr.Code)
+		assert.Equal(t, 1.0, testutil.ToFloat64(a.rejected.WithLabelValues("global")))
+
+		release()
+
+		release, err = a.admit(ctx, "b")
+		require.NoError(t, err)
+		release()
+
+		assert.Zero(t, testutil.ToFloat64(a.active))
+		assert.Zero(t, testutil.ToFloat64(a.queued))
+	})
+
+	t.Run("User", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAdmission(2, 1, 10*time.Millisecond)
+
+		release, err := a.admit(ctx, "a")
+		require.NoError(t, err)
+
+		_, err = a.admit(ctx, "a")
+		require.Error(t, err)
+		assert.Equal(t, 1.0, testutil.ToFloat64(a.rejected.WithLabelValues("user")))
+
+		releaseB, err := a.admit(ctx, "b")
+		require.NoError(t, err)
+
+		releaseB()
+		release()
+
+		assert.Empty(t, a.users)
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAdmission(1, 1, 0)
+
+		release, err := a.admit(ctx, "a")
+		require.NoError(t, err)
+
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err = a.admit(cctx, "a")
+		assert.ErrorIs(t, err, context.Canceled)
+
+		_, err = a.admit(cctx, "b")
+		assert.ErrorIs(t, err, context.Canceled)
+
+		release()
+
+		assert.Empty(t, a.users)
+	})
+}
@@ -0,0 +1,223 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+)
+
+// collationEnums contains valid values of collation fields with enumerated values.
+var collationEnums = map[string][]any{
+	"strength":    {int32(1), int32(2), int32(3), int32(4), int32(5)},
+	"caseFirst":   {"upper", "lower", "off"},
+	"alternate":   {"non-ignorable", "shifted"},
+	"maxVariable": {"punct", "space"},
+}
+
+// collationUnsupported contains collation fields that DocumentDB does not map onto ICU collations,
+// and their default values that are accepted.
+var collationUnsupported = map[string]any{
+	"alternate":     "non-ignorable",
+	"maxVariable":   "punct",
+	"normalization": false,
+	"backwards":     false,
+}
+
+// checkCollation validates the top-level `collation` parameter of the given command, if present.
+//
+// Collation itself is applied by DocumentDB with ICU collations
+// for comparisons, sorting, and index builds.
+func checkCollation(doc *wirebson.Document) error {
+	v := doc.Get("collation")
+	if v == nil {
+		return nil
+	}
+
+	return validateCollation(doc.Command()+".collation", v)
+}
+
+// checkStatementsCollation validates `collation` parameters of `update` or `delete` statements
+// from the given field (see [writeStatements]).
+func checkStatementsCollation(command, field string, statements []*wirebson.Document) error {
+	// DocumentDB reports other problems of statements
+	for i, s := range statements {
+		v := s.Get("collation")
+		if v == nil {
+			continue
+		}
+
+		if err := validateCollation(fmt.Sprintf("%s.%s.%d.collation", command, field, i), v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkIndexesCollation validates `collation` options of `createIndexes` index specifications.
+// Text indexes do not support non-simple collations.
+func checkIndexesCollation(indexes wirebson.AnyArray) error {
+	arr, err := indexes.Decode()
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	// DocumentDB reports other problems of index specifications
+	for i, v := range arr.All() {
+		index, ok := v.(wirebson.AnyDocument)
+		if !ok {
+			continue
+		}
+
+		indexDoc, err := index.Decode()
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		c := indexDoc.Get("collation")
+		if c == nil {
+			continue
+		}
+
+		if err = validateCollation(fmt.Sprintf("createIndexes.indexes.%d.collation", i), c); err != nil {
+			return err
+		}
+
+		collation, err := c.(wirebson.AnyDocument).Decode()
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		// text indexes use their own language rules, so only the simple collation is allowed
+		if collation.Get("locale") == "simple" {
+			continue
+		}
+
+		key, ok := indexDoc.Get("key").(wirebson.AnyDocument)
+		if !ok {
+			continue
+		}
+
+		keyDoc, err := key.Decode()
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		for _, v := range keyDoc.All() {
+			if v == "text" {
+				msg := "Index type 'text' does not support collation"
+				return mongoerrors.NewWithArgument(mongoerrors.ErrCannotCreateIndex, msg, "createIndexes")
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateCollation validates the collation specification with the given field path (used in error messages).
+func validateCollation(path string, v any) error {
+	collation, ok := v.(wirebson.AnyDocument)
+	if !ok {
+		msg := fmt.Sprintf("BSON field '%s' is the wrong type '%s', expected type 'object'", path, aliasFromType(v))
+		return mongoerrors.NewWithArgument(mongoerrors.ErrTypeMismatch, msg, path)
+	}
+
+	doc, err := collation.Decode()
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	for k, v := range doc.All() {
+		var expected string
+
+		switch k {
+		case "locale", "caseFirst", "alternate", "maxVariable", "version":
+			if _, ok := v.(string); !ok {
+				expected = "string"
+			}
+		case "caseLevel", "numericOrdering", "normalization", "backwards":
+			if _, ok := v.(bool); !ok {
+				expected = "bool"
+			}
+		case "strength":
+			switch s := v.(type) {
+			case int32:
+			case int64:
+				if s < math.MinInt32 || s > math.MaxInt32 {
+					msg := fmt.Sprintf("Expected field %s.%s to be an integer, got %d", path, k, s)
+					return mongoerrors.NewWithArgument(mongoerrors.ErrBadValue, msg, path)
+				}
+
+				v = int32(s)
+			case float64:
+				if s != math.Trunc(s) || s < math.MinInt32 || s > math.MaxInt32 {
+					msg := fmt.Sprintf("Expected field %s.%s to be an integer, got %v", path, k, s)
+					return mongoerrors.NewWithArgument(mongoerrors.ErrBadValue, msg, path)
+				}
+
+				v = int32(s)
+			default:
+				expected = "int"
+			}
+		default:
+			msg := fmt.Sprintf("BSON field '%s.%s' is an unknown field.", path, k)
+			return mongoerrors.NewWithArgument(mongoerrors.ErrUnknownBsonField, msg, path)
+		}
+
+		if expected != "" {
+			msg := fmt.Sprintf(
+				"BSON field '%s.%s' is the wrong type '%s', expected type '%s'",
+				path, k, aliasFromType(v), expected,
+			)
+
+			return mongoerrors.NewWithArgument(mongoerrors.ErrTypeMismatch, msg, path)
+		}
+
+		if valid, ok := collationEnums[k]; ok && !slices.Contains(valid, v) {
+			msg := fmt.Sprintf("Enumeration value '%v' for field '%s.%s' is not a valid value.", v, path, k)
+			return mongoerrors.NewWithArgument(mongoerrors.ErrBadValue, msg, path)
+		}
+
+		if def, ok := collationUnsupported[k]; ok && v != def {
+			msg := fmt.Sprintf("Collation option '%s' is not supported yet", k)
+			return mongoerrors.NewWithArgument(mongoerrors.ErrNotImplemented, msg, path)
+		}
+	}
+
+	locale, ok := doc.Get("locale").(string)
+	if !ok {
+		msg := fmt.Sprintf("BSON field '%s.locale' is missing but a required field", path)
+		return mongoerrors.NewWithArgument(mongoerrors.ErrLocation40414, msg, path)
+	}
+
+	if locale == "" {
+		msg := "Field 'locale' cannot be empty"
+		return mongoerrors.NewWithArgument(mongoerrors.ErrBadValue, msg, path)
+	}
+
+	if locale == "simple" && doc.Len() > 1 {
+		msg := "If 'locale' is set to 'simple', no other collation options may be specified"
+		return mongoerrors.NewWithArgument(mongoerrors.ErrBadValue, msg, path)
+	}
+
+	return nil
+}
@@ -33,6 +33,10 @@ type command struct {
 	// anonymous indicates that the command does not require authentication.
 	anonymous bool
 
+	// mutating indicates that the command modifies data, collections, indexes, or users.
+	// Such commands are rejected in read-only mode.
+	mutating bool
+
 	// handler processes this command.
 	//
 	// The passed context is canceled when the client disconnects.
@@ -72,21 +76,23 @@ func (h *Handler) initCommands() {
 			Help: "", // hidden while not implemented
 		},
 		"collMod": {
-			handler: h.msgCollMod,
-			Help:    "Adds options to a collection or modify view definitions.",
+			handler:  h.msgCollMod,
+			mutating: true,
+			Help:     "Adds options to a collection or modify view definitions.",
 		},
 		"collStats": {
 			handler: h.msgCollStats,
 			Help:    "Returns storage data for a collection.",
 		},
 		"compact": {
-			handler: h.msgCompact,
-			Help:    "Reduces the disk space collection takes and refreshes its statistics.",
+			handler:  h.msgCompact,
+			mutating: true,
+			Help:     "Reduces the disk space collection takes and refreshes its statistics.",
 		},
 		"connPoolStats": {
-			// TODO https://github.com/FerretDB/FerretDB/issues/4909
+			handler:   h.msgConnPoolStats,
 			anonymous: true,
-			Help:      "", // hidden while not implemented
+			Help:      "Returns information about outgoing connections.",
 		},
 		"connectionStatus": {
 			handler:   h.msgConnectionStatus,
@@ -99,16 +105,24 @@ func (h *Handler) initCommands() {
 			Help:    "Returns the count of documents that's matched by the query.",
 		},
 		"create": {
-			handler: h.msgCreate,
-			Help:    "Creates the collection.",
+			handler:  h.msgCreate,
+			mutating: true,
+			Help:     "Creates the collection.",
 		},
 		"createIndexes": {
-			handler: h.msgCreateIndexes,
-			Help:    "Creates indexes on a collection.",
+			handler:  h.msgCreateIndexes,
+			mutating: true,
+			Help:     "Creates indexes on a collection.",
+		},
+		"createSearchIndexes": {
+			handler:  h.msgCreateSearchIndexes,
+			mutating: true,
+			Help:     "Creates search indexes on a collection.",
 		},
 		"createUser": {
-			handler: h.msgCreateUser,
-			Help:    "Creates a new user.",
+			handler:  h.msgCreateUser,
+			mutating: true,
+			Help:     "Creates a new user.",
 		},
 		"currentOp": {
 			handler: h.msgCurrentOp,
@@ -127,32 +141,43 @@ func (h *Handler) initCommands() {
 			Help:    "", // hidden
 		},
 		"delete": {
-			handler: h.msgDelete,
-			Help:    "Deletes documents matched by the query.",
+			handler:  h.msgDelete,
+			mutating: true,
+			Help:     "Deletes documents matched by the query.",
 		},
 		"distinct": {
 			handler: h.msgDistinct,
 			Help:    "Returns an array of distinct values for the given field.",
 		},
 		"drop": {
-			handler: h.msgDrop,
-			Help:    "Drops the collection.",
+			handler:  h.msgDrop,
+			mutating: true,
+			Help:     "Drops the collection.",
 		},
 		"dropAllUsersFromDatabase": {
-			handler: h.msgDropAllUsersFromDatabase,
-			Help:    "Drops all user from database.",
+			handler:  h.msgDropAllUsersFromDatabase,
+			mutating: true,
+			Help:     "Drops all user from database.",
 		},
 		"dropDatabase": {
-			handler: h.msgDropDatabase,
-			Help:    "Drops production database.",
+			handler:  h.msgDropDatabase,
+			mutating: true,
+			Help:     "Drops production database.",
 		},
 		"dropIndexes": {
-			handler: h.msgDropIndexes,
-			Help:    "Drops indexes on a collection.",
+			handler:  h.msgDropIndexes,
+			mutating: true,
+			Help:     "Drops indexes on a collection.",
+		},
+		"dropSearchIndex": {
+			handler:  h.msgDropSearchIndex,
+			mutating: true,
+			Help:     "Drops a search index on a collection.",
 		},
 		"dropUser": {
-			handler: h.msgDropUser,
-			Help:    "Drops user.",
+			handler:  h.msgDropUser,
+			mutating: true,
+			Help:     "Drops user.",
 		},
 		"endSessions": {
 			handler: h.msgEndSessions,
@@ -171,12 +196,14 @@ func (h *Handler) initCommands() {
 			Help:    "Returns documents matched by the query.",
 		},
 		"findAndModify": {
-			handler: h.msgFindAndModify,
-			Help:    "Updates or deletes, and returns a document matched by the query.",
+			handler:  h.msgFindAndModify,
+			mutating: true,
+			Help:     "Updates or deletes, and returns a document matched by the query.",
 		},
 		"findandmodify": { // old lowercase variant
-			handler: h.msgFindAndModify,
-			Help:    "", // hidden
+			handler:  h.msgFindAndModify,
+			mutating: true,
+			Help:     "", // hidden
 		},
 		"getCmdLineOpts": {
 			handler: h.msgGetCmdLineOpts,
@@ -208,8 +235,9 @@ func (h *Handler) initCommands() {
 			Help:    "Returns a summary of the system information.",
 		},
 		"insert": {
-			handler: h.msgInsert,
-			Help:    "Inserts documents into the database.",
+			handler:  h.msgInsert,
+			mutating: true,
+			Help:     "Inserts documents into the database.",
 		},
 		"isMaster": {
 			handler:   h.msgIsMaster,
@@ -233,6 +261,10 @@ func (h *Handler) initCommands() {
 			handler: h.msgKillCursors,
 			Help:    "Closes server cursors.",
 		},
+		"killOp": {
+			handler: h.msgKillOp,
+			Help:    "Terminates an operation as specified by the operation ID.",
+		},
 		"killSessions": {
 			handler: h.msgKillSessions,
 			Help:    "Kills sessions.",
@@ -263,17 +295,23 @@ func (h *Handler) initCommands() {
 			anonymous: true,
 			Help:      "Returns a pong response.",
 		},
+		"profile": {
+			handler: h.msgProfile,
+			Help:    "Sets the database profiler level and thresholds.",
+		},
 		"refreshSessions": {
 			handler: h.msgRefreshSessions,
 			Help:    "Updates the last used time of sessions.",
 		},
 		"reIndex": {
-			handler: h.msgReIndex,
-			Help:    "Drops and recreates all indexes except default _id index of a collection.",
+			handler:  h.msgReIndex,
+			mutating: true,
+			Help:     "Drops and recreates all indexes except default _id index of a collection.",
 		},
 		"renameCollection": {
-			handler: h.msgRenameCollection,
-			Help:    "Changes the name of an existing collection.",
+			handler:  h.msgRenameCollection,
+			mutating: true,
+			Help:     "Changes the name of an existing collection.",
 		},
 		"saslStart": {
 			handler:   h.msgSASLStart,
@@ -293,17 +331,27 @@ func (h *Handler) initCommands() {
 			handler: h.msgSetFreeMonitoring,
 			Help:    "Toggles free monitoring.",
 		},
+		"setParameter": {
+			handler: h.msgSetParameter,
+			Help:    "Sets the value of the parameter.",
+		},
 		"startSession": {
 			handler: h.msgStartSession,
 			Help:    "Returns a session.",
 		},
+		"top": {
+			handler: h.msgTop,
+			Help:    "Returns usage statistics for each collection.",
+		},
 		"update": {
-			handler: h.msgUpdate,
-			Help:    "Updates documents that are matched by the query.",
+			handler:  h.msgUpdate,
+			mutating: true,
+			Help:     "Updates documents that are matched by the query.",
 		},
 		"updateUser": {
-			handler: h.msgUpdateUser,
-			Help:    "Updates user.",
+			handler:  h.msgUpdateUser,
+			mutating: true,
+			Help:     "Updates user.",
 		},
 		"usersInfo": {
 			handler: h.msgUsersInfo,
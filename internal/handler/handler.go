@@ -17,19 +17,23 @@
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/AlekSi/lazyerrors"
 	"github.com/FerretDB/wire"
+	"github.com/FerretDB/wire/wirebson"
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
 	"github.com/FerretDB/FerretDB/v2/internal/documentdb"
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
 	"github.com/FerretDB/FerretDB/v2/internal/handler/session"
+	"github.com/FerretDB/FerretDB/v2/internal/handlers/proxy"
 	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
 	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
 	"github.com/FerretDB/FerretDB/v2/internal/util/state"
@@ -66,6 +70,18 @@ type Handler struct {
 	p        *documentdb.Pool
 	commands map[string]*command
 	s        *session.Registry
+	ttl      *ttlMonitor
+	ops      *operations
+	prof     *profiler
+	adm      *admission
+	params   map[string]serverParameter
+
+	// Parameters that could be changed at runtime with `setParameter` command.
+	readOnly          atomic.Bool  // see [NewOpts.ReadOnly]
+	slowOpThreshold   atomic.Int64 // time.Duration; see [NewOpts.SlowOpThreshold]
+	cursorTimeout     atomic.Int64 // time.Duration
+	quiet             atomic.Bool
+	authSchemaVersion atomic.Int32
 
 	runM   sync.Mutex
 	runCtx context.Context
@@ -81,11 +97,49 @@ type NewOpts struct {
 	TCPHost       string
 	ReplSetName   string
 
+	// ReadOnly rejects all mutating commands.
+	// It could be changed at runtime with `setParameter` command.
+	ReadOnly bool
+
 	L             *slog.Logger
 	Metrics       *middleware.Metrics
 	StateProvider *state.Provider
 
 	SessionCleanupInterval time.Duration
+
+	// TTLMonitorInterval is a duration between TTL monitor passes.
+	// Zero value means the default (1 minute), negative value disables the monitor.
+	TTLMonitorInterval time.Duration
+
+	// TTLMonitorBatchSize is a maximum number of documents deleted from a single collection at once.
+	// Zero value means the default (10000).
+	TTLMonitorBatchSize int
+
+	// DefaultMaxTime is a time limit for commands without `maxTimeMS` argument.
+	// Zero value means no limit.
+	DefaultMaxTime time.Duration
+
+	// SlowOpThreshold is a duration after which commands are written to the slow operation log
+	// (with info level, in MongoDB-like format if `mongo` log format is used).
+	// Zero value disables the slow operation log.
+	// It could be changed at runtime with `setParameter` command.
+	SlowOpThreshold time.Duration
+
+	// MaxRequests is a maximum number of concurrently handled requests.
+	// Zero value means the maximum size of PostgreSQL connection pool.
+	MaxRequests int
+
+	// MaxUserRequests is a maximum number of concurrently handled requests of a single authenticated user.
+	// Zero value means no limit.
+	MaxUserRequests int
+
+	// AdmissionMaxWait is a maximum time requests over limits wait to be handled.
+	// Zero value means no limit.
+	AdmissionMaxWait time.Duration
+
+	// ProxyStats returns the state of upstream connections of the proxy handler for `connPoolStats`.
+	// Nil if the proxy handler is not used.
+	ProxyStats func() *proxy.Stats
 }
 
 // New returns a new handler.
@@ -102,12 +156,29 @@ func New(opts *NewOpts) (*Handler, error) {
 		return nil, lazyerrors.Error(err)
 	}
 
+	maxRequests := opts.MaxRequests
+	if maxRequests <= 0 {
+		maxRequests = int(p.Stats().Max)
+	}
+
 	h := &Handler{
 		NewOpts: opts,
 		p:       p,
 		s:       session.NewRegistry(sessionTimeout, opts.L),
+		ops:     newOperations(),
+		prof:    newProfiler(),
+		adm:     newAdmission(maxRequests, opts.MaxUserRequests, opts.AdmissionMaxWait),
 	}
 
+	h.ttl = newTTLMonitor(p, logging.WithName(opts.L, "ttl"), opts.TTLMonitorInterval, opts.TTLMonitorBatchSize, h.readOnly.Load)
+
+	h.readOnly.Store(opts.ReadOnly)
+	h.slowOpThreshold.Store(int64(opts.SlowOpThreshold))
+	h.cursorTimeout.Store(int64(defaultCursorTimeout))
+	h.authSchemaVersion.Store(5)
+
+	h.initParameters()
+
 	h.initCommands()
 
 	return h, nil
@@ -119,8 +190,19 @@ func New(opts *NewOpts) (*Handler, error) {
 func (h *Handler) Run(ctx context.Context) {
 	h.runM.Lock()
 	h.runCtx = ctx
+	h.runWG.Add(2)
 	h.runM.Unlock()
 
+	go func() {
+		defer h.runWG.Done()
+		h.ttl.Run(ctx)
+	}()
+
+	go func() {
+		defer h.runWG.Done()
+		h.runProfiler(ctx)
+	}()
+
 	defer func() {
 		h.runWG.Wait()
 
@@ -150,6 +232,10 @@ func (h *Handler) Run(ctx context.Context) {
 			for _, cursorID := range cursorIDs {
 				_ = h.p.KillCursor(ctx, cursorID)
 			}
+
+			if timeout := time.Duration(h.cursorTimeout.Load()); timeout > 0 {
+				h.p.KillIdleCursors(ctx, timeout)
+			}
 		}
 	}
 }
@@ -213,12 +299,69 @@ func (h *Handler) Handle(ctx context.Context, req *middleware.Request) (*middlew
 			h.L.DebugContext(ctx, "Authentication passed", slog.String("username", username))
 		}
 
-		resp, err := cmd.handler(ctx, req)
+		if h.readOnly.Load() && (cmd.mutating || writesOutput(req.Document())) {
+			return middleware.ResponseErr(req, mongoerrors.New(
+				mongoerrors.ErrNotWritablePrimary,
+				fmt.Sprintf("Command %s is not allowed in read-only mode", msgCmd),
+			)), nil
+		}
+
+		ci := conninfo.Get(ctx)
+
+		var client, user string
+		if peer := ci.Peer; peer.IsValid() {
+			client = peer.String()
+		}
+
+		if conv := ci.Conv(); conv.Succeed() {
+			user = conv.Username()
+		}
+
+		ctx, op, finish := h.ops.start(ctx, req.Document(), client, user)
+		defer finish()
+
+		var stats *documentdb.QueryStats
+		if h.slowOpThreshold.Load() > 0 {
+			stats = new(documentdb.QueryStats)
+			ctx = documentdb.WithQueryStats(ctx, stats)
+		}
+
+		maxTime, err := h.maxTime(req.Document())
 		if err != nil {
+			return middleware.ResponseErr(req, mongoerrors.Make(ctx, err, "", h.L)), nil
+		}
+
+		if maxTime > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, maxTime)
+
+			defer cancel()
+		}
+
+		var resp *middleware.Response
+
+		release, err := h.admit(ctx, req.Document(), cmd)
+		if err == nil {
+			defer release()
+			resp, err = cmd.handler(ctx, req)
+		}
+
+		if err != nil {
+			switch {
+			case op.killed.Load():
+				err = mongoerrors.New(mongoerrors.ErrInterrupted, "operation was interrupted")
+			case errors.Is(ctx.Err(), context.DeadlineExceeded):
+				err = mongoerrors.New(mongoerrors.ErrMaxTimeMSExpired, "operation exceeded time limit")
+			}
+
 			// TODO https://github.com/FerretDB/FerretDB/issues/4965
 			resp = middleware.ResponseErr(req, mongoerrors.Make(ctx, err, "", h.L))
 		}
 
+		if stats != nil {
+			h.logSlowOp(ctx, op, resp, stats)
+		}
+
 		return resp, nil
 
 	case *wire.OpQuery:
@@ -235,19 +378,135 @@ func (h *Handler) Handle(ctx context.Context, req *middleware.Request) (*middlew
 	}
 }
 
+// privileged returns true if the client connection could run administrative commands
+// and access operations of other users.
+//
+// All clients are privileged if authentication is disabled.
+// Otherwise, only users with the `clusterAdmin` role are.
+func (h *Handler) privileged(ctx context.Context) (bool, error) {
+	if !h.Auth {
+		return true, nil
+	}
+
+	conv := conninfo.Get(ctx).Conv()
+	if !conv.Succeed() {
+		return false, nil
+	}
+
+	res, err := h.p.Superuser(ctx, conv.Username())
+	if err != nil {
+		return false, lazyerrors.Error(err)
+	}
+
+	return res, nil
+}
+
+// admit waits until the given command could be handled by [admission].
+// Commands that do not require authentication and a few diagnostic commands are admitted immediately.
+//
+// `getMore` commands of tailable cursors with awaitData are also admitted immediately:
+// they could wait for new documents for a long time without using PostgreSQL connections.
+func (h *Handler) admit(ctx context.Context, doc *wirebson.Document, cmd *command) (func(), error) {
+	msgCmd := doc.Command()
+
+	if _, ok := admissionExempt[msgCmd]; ok || cmd.anonymous {
+		return func() {}, nil
+	}
+
+	if id, ok := doc.Get(msgCmd).(int64); ok && msgCmd == "getMore" && h.p.AwaitData(id) {
+		return func() {}, nil
+	}
+
+	var user string
+	if h.Auth {
+		user = conninfo.Get(ctx).Conv().Username()
+	}
+
+	return h.adm.admit(ctx, user)
+}
+
+// maxTime returns the time limit for the given command.
+//
+// `maxTimeMS` of `getMore` command for tailable cursors with awaitData is a time to wait
+// for new documents, not a time limit, so no limit is set for such commands.
+// Other `getMore` commands use the server-wide default.
+func (h *Handler) maxTime(doc *wirebson.Document) (time.Duration, error) {
+	if doc.Command() == "getMore" {
+		id, _ := doc.Get("getMore").(int64)
+		if doc.Get("maxTimeMS") != nil && h.p.AwaitData(id) {
+			return 0, nil
+		}
+
+		return h.DefaultMaxTime, nil
+	}
+
+	res, err := getMaxTimeParam(doc)
+	if err != nil {
+		return 0, err
+	}
+
+	if res == 0 {
+		res = h.DefaultMaxTime
+	}
+
+	return res, nil
+}
+
+// writesOutput returns true if the given `aggregate` command writes its results
+// with `$out` or `$merge` stage.
+func writesOutput(doc *wirebson.Document) bool {
+	if doc.Command() != "aggregate" {
+		return false
+	}
+
+	pipeline, ok := doc.Get("pipeline").(wirebson.AnyArray)
+	if !ok {
+		return false
+	}
+
+	stages, err := pipeline.Decode()
+	if err != nil {
+		return false
+	}
+
+	for v := range stages.Values() {
+		stage, ok := v.(wirebson.AnyDocument)
+		if !ok {
+			continue
+		}
+
+		stageDoc, err := stage.Decode()
+		if err != nil {
+			continue
+		}
+
+		switch stageDoc.Command() {
+		case "$out", "$merge":
+			return true
+		}
+	}
+
+	return false
+}
+
 // Describe implements [prometheus.Collector].
 func (h *Handler) Describe(ch chan<- *prometheus.Desc) {
 	h.p.Describe(ch)
 	h.s.Describe(ch)
+	h.ttl.Describe(ch)
+	h.adm.Describe(ch)
 }
 
 // Collect implements [prometheus.Collector].
 func (h *Handler) Collect(ch chan<- prometheus.Metric) {
 	h.p.Collect(ch)
 	h.s.Collect(ch)
+	h.ttl.Collect(ch)
+	h.adm.Collect(ch)
 }
 
 // check interfaces
 var (
-	_ middleware.Handler = (*Handler)(nil)
+	_ middleware.Handler  = (*Handler)(nil)
+	_ middleware.Profiler = (*Handler)(nil)
 )
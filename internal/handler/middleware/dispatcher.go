@@ -119,9 +119,11 @@ func (d *dispatcher) Dispatch(ctx context.Context, req *Request) (resp *Response
 
 		var level slog.Level
 
+		// successful commands are logged only with debug level;
+		// slow ones are written to the handler's slow operation log
 		switch res {
 		case resultOK:
-			level = slog.LevelInfo
+			level = slog.LevelDebug
 		case resultError, resultPanic, resultUnknown:
 			level = slog.LevelError
 		default:
@@ -129,6 +131,15 @@ func (d *dispatcher) Dispatch(ctx context.Context, req *Request) (resp *Response
 		}
 
 		d.l.LogAttrs(ctx, level, "Command handled", attrs...)
+
+		if p, ok := d.h.(Profiler); ok {
+			p.Profile(ctx, &HandledOp{
+				Request:  req,
+				Response: resp,
+				Start:    start,
+				Duration: time.Since(start),
+			})
+		}
 	}()
 
 	resp, err = d.h.Handle(ctx, req)
@@ -16,6 +16,7 @@
 
 import (
 	"fmt"
+	"sync/atomic"
 
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
@@ -31,9 +32,35 @@
 )
 
 // Metrics represents middleware Metrics.
+//
+// It also tracks client connections and network traffic for `serverStatus` command.
 type Metrics struct {
 	requests  *prometheus.CounterVec
 	responses *prometheus.CounterVec
+	top       *Top
+
+	connsMax     atomic.Int64
+	connsCurrent atomic.Int64
+	connsTotal   atomic.Int64
+	active       atomic.Int64
+	bytesIn      atomic.Int64
+	bytesOut     atomic.Int64
+	numRequests  atomic.Int64
+}
+
+// ConnectionMetrics represents client connection metrics.
+type ConnectionMetrics struct {
+	Current      int64 // currently open connections
+	TotalCreated int64 // all connections created since start
+	Active       int64 // requests currently being handled
+	Max          int64 // maximum number of open connections, zero if unlimited
+}
+
+// NetworkMetrics represents wire protocol traffic metrics.
+type NetworkMetrics struct {
+	BytesIn     int64
+	BytesOut    int64
+	NumRequests int64
 }
 
 // CommandMetrics represents command results metrics.
@@ -70,6 +97,8 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"opcode", "command", "argument", "result"},
 		),
+
+		top: newTop(),
 	}
 
 	m.requests.With(prometheus.Labels{
@@ -90,12 +119,55 @@ func NewMetrics() *Metrics {
 func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
 	m.requests.Describe(ch)
 	m.responses.Describe(ch)
+	m.top.Describe(ch)
 }
 
 // Collect implements [prometheus.Collector].
 func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
 	m.requests.Collect(ch)
 	m.responses.Collect(ch)
+	m.top.Collect(ch)
+}
+
+// SetMaxConnections should be called by listeners to report the connections limit.
+// Zero value means no limit.
+func (m *Metrics) SetMaxConnections(n int64) {
+	m.connsMax.Store(n)
+}
+
+// ConnectionOpened should be called by listeners when a new client connection is accepted.
+func (m *Metrics) ConnectionOpened() {
+	m.connsCurrent.Add(1)
+	m.connsTotal.Add(1)
+}
+
+// ConnectionClosed should be called by listeners when a client connection is closed.
+func (m *Metrics) ConnectionClosed() {
+	m.connsCurrent.Add(-1)
+}
+
+// GetConnections returns client connection metrics.
+func (m *Metrics) GetConnections() ConnectionMetrics {
+	return ConnectionMetrics{
+		Current:      m.connsCurrent.Load(),
+		TotalCreated: m.connsTotal.Load(),
+		Active:       m.active.Load(),
+		Max:          m.connsMax.Load(),
+	}
+}
+
+// GetNetwork returns wire protocol traffic metrics.
+func (m *Metrics) GetNetwork() NetworkMetrics {
+	return NetworkMetrics{
+		BytesIn:     m.bytesIn.Load(),
+		BytesOut:    m.bytesOut.Load(),
+		NumRequests: m.numRequests.Load(),
+	}
+}
+
+// GetTop returns per-namespace operation counters for `top` command.
+func (m *Metrics) GetTop() map[TopNamespace]TopStats {
+	return m.top.Get()
 }
 
 // GetResponses returns a map with all response metrics:
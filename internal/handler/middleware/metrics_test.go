@@ -15,7 +15,9 @@
 package middleware
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -45,3 +47,51 @@ func TestGetResponses(t *testing.T) {
 	}
 	assert.Equal(t, expected, mm.GetResponses())
 }
+
+func TestGetConnections(t *testing.T) {
+	mm := NewMetrics()
+	mm.ConnectionOpened()
+	mm.ConnectionOpened()
+	mm.ConnectionClosed()
+
+	expected := ConnectionMetrics{
+		Current:      1,
+		TotalCreated: 2,
+	}
+	assert.Equal(t, expected, mm.GetConnections())
+}
+
+func TestTop(t *testing.T) {
+	mm := NewMetrics()
+	mm.top.record("find", "db", "coll", time.Second, true)
+	mm.top.record("getMore", "db", "coll", time.Second, true)
+	mm.top.record("insert", "db", "coll", 2*time.Second, true)
+	mm.top.record("ping", "admin", "", time.Second, true)
+	mm.top.record("drop", "db", "other", time.Second, true)
+
+	expected := map[TopNamespace]TopStats{
+		{DB: "db", Collection: "coll"}: {
+			Total:     TopCounter{Time: 4 * time.Second, Count: 3},
+			ReadLock:  TopCounter{Time: 2 * time.Second, Count: 2},
+			WriteLock: TopCounter{Time: 2 * time.Second, Count: 1},
+			Queries:   TopCounter{Time: time.Second, Count: 1},
+			GetMore:   TopCounter{Time: time.Second, Count: 1},
+			Insert:    TopCounter{Time: 2 * time.Second, Count: 1},
+		},
+	}
+	assert.Equal(t, expected, mm.GetTop())
+
+	for i := range maxTopNamespaces {
+		mm.top.record("count", "other", fmt.Sprint(i), time.Second, true)
+	}
+
+	top := mm.GetTop()
+	assert.Len(t, top, maxTopNamespaces+1)
+	assert.Equal(t, int64(1), top[TopNamespace{DB: TopOther, Collection: TopOther}].Commands.Count)
+
+	mm.top.record("drop", "db", "coll", time.Second, true)
+	mm.top.record("dropDatabase", "other", "", time.Second, true)
+	assert.Equal(t, map[TopNamespace]TopStats{
+		{DB: TopOther, Collection: TopOther}: top[TopNamespace{DB: TopOther, Collection: TopOther}],
+	}, mm.GetTop())
+}
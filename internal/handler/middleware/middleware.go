@@ -22,7 +22,9 @@
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/FerretDB/wire/wirebson"
 	"github.com/pmezard/go-difflib/difflib"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel"
@@ -55,6 +57,7 @@ type NewOpts struct {
 	Mode    Mode
 	DocDB   Handler
 	Proxy   Handler
+	Router  *Router // only for RoutingMode
 	Metrics *Metrics
 	L       *slog.Logger
 }
@@ -68,15 +71,23 @@ func New(opts *NewOpts) *Middleware {
 	case NormalMode:
 		must.NotBeZero(opts.DocDB)
 		must.BeZero(opts.Proxy)
+		must.BeZero(opts.Router)
 	case ProxyMode:
-		must.BeZero(opts.DocDB)
+		// DocDB is optional; if set, it handles authentication commands
 		must.NotBeZero(opts.Proxy)
+		must.BeZero(opts.Router)
 	case DiffNormalMode:
 		must.NotBeZero(opts.DocDB)
 		must.NotBeZero(opts.Proxy)
+		must.BeZero(opts.Router)
 	case DiffProxyMode:
 		must.NotBeZero(opts.DocDB)
 		must.NotBeZero(opts.Proxy)
+		must.BeZero(opts.Router)
+	case RoutingMode:
+		must.NotBeZero(opts.DocDB)
+		must.NotBeZero(opts.Proxy)
+		must.NotBeZero(opts.Router)
 	default:
 		panic("not reached")
 	}
@@ -92,6 +103,15 @@ func (m *Middleware) Run(ctx context.Context) {
 	m.runCtx = ctx
 	m.runM.Unlock()
 
+	if m.opts.Router != nil {
+		m.runWG.Add(1)
+
+		go func() {
+			defer m.runWG.Done()
+			m.opts.Router.Run(ctx)
+		}()
+	}
+
 	<-ctx.Done()
 	m.runWG.Wait()
 }
@@ -125,6 +145,23 @@ func (m *Middleware) Handle(ctx context.Context, req *Request) (resp *Response)
 	}
 	m.opts.Metrics.requests.With(labels).Inc()
 
+	m.opts.Metrics.numRequests.Add(1)
+	m.opts.Metrics.bytesIn.Add(int64(req.WireHeader().MessageLength))
+
+	start := time.Now()
+
+	m.opts.Metrics.active.Add(1)
+	defer func() {
+		m.opts.Metrics.active.Add(-1)
+
+		if resp != nil {
+			m.opts.Metrics.bytesOut.Add(int64(resp.WireHeader().MessageLength))
+		}
+
+		command, database, collection := requestNamespace(req)
+		m.opts.Metrics.top.record(command, database, collection, time.Since(start), resp != nil && resp.OK())
+	}()
+
 	ctx = m.startSpan(ctx, req)
 	defer func() {
 		m.endSpan(ctx, resp)
@@ -134,19 +171,43 @@ func (m *Middleware) Handle(ctx context.Context, req *Request) (resp *Response)
 		m.opts.L.DebugContext(ctx, fmt.Sprintf("<<< %s\n%s", req.WireHeader(), req.WireBody().StringIndent()))
 	}
 
-	docdb, proxy := m.dispatch(ctx, req)
-
 	switch m.opts.Mode {
 	case NormalMode:
-		resp = docdb
+		resp, _ = m.dispatch(ctx, req, true, false)
 	case ProxyMode:
-		resp = proxy
+		if m.opts.DocDB != nil && authCommand(req.Document()) {
+			resp, _ = m.dispatch(ctx, req, true, false)
+			break
+		}
+
+		_, resp = m.dispatch(ctx, req, false, true)
 	case DiffNormalMode:
+		docdb, proxy := m.dispatch(ctx, req, true, true)
 		m.logDiff(ctx, docdb, proxy)
 		resp = docdb
 	case DiffProxyMode:
+		docdb, proxy := m.dispatch(ctx, req, true, true)
 		m.logDiff(ctx, docdb, proxy)
 		resp = proxy
+	case RoutingMode:
+		if authCommand(req.Document()) {
+			resp, _ = m.dispatch(ctx, req, true, false)
+			break
+		}
+
+		target, rule := m.opts.Router.Route(req.Document())
+		oteltrace.SpanFromContext(ctx).SetAttributes(otelattribute.String("db.ferretdb.routing_rule", rule))
+
+		switch target {
+		case RoutingDocumentDB:
+			resp, _ = m.dispatch(ctx, req, true, false)
+		case RoutingProxy:
+			_, resp = m.dispatch(ctx, req, false, true)
+		default:
+			panic("not reached")
+		}
+
+		m.opts.Router.Track(req.Document(), resp, target)
 	default:
 		panic("not reached")
 	}
@@ -154,12 +215,24 @@ func (m *Middleware) Handle(ctx context.Context, req *Request) (resp *Response)
 	return
 }
 
-// dispatch sends the request to both handlers.
-// It returns nil for the given handler if it is not enabled, or if unrecoverable error occurs in it.
-func (m *Middleware) dispatch(ctx context.Context, req *Request) (docdb, proxy *Response) {
+// authCommand returns true if the given request authenticates the client connection.
+func authCommand(doc *wirebson.Document) bool {
+	switch doc.Command() {
+	case "saslStart", "saslContinue", "authenticate", "logout":
+		return true
+	case "hello", "isMaster", "ismaster":
+		return doc.Get("speculativeAuthenticate") != nil
+	default:
+		return false
+	}
+}
+
+// dispatch sends the request to selected handlers.
+// It returns nil for the given handler if it is not selected, or if unrecoverable error occurs in it.
+func (m *Middleware) dispatch(ctx context.Context, req *Request, useDocDB, useProxy bool) (docdb, proxy *Response) {
 	var wg sync.WaitGroup
 
-	if m.opts.DocDB != nil {
+	if useDocDB {
 		wg.Add(1)
 
 		go func() {
@@ -177,7 +250,7 @@ func (m *Middleware) dispatch(ctx context.Context, req *Request) (docdb, proxy *
 		}()
 	}
 
-	if m.opts.Proxy != nil {
+	if useProxy {
 		wg.Add(1)
 
 		go func() {
@@ -200,6 +273,25 @@ func (m *Middleware) dispatch(ctx context.Context, req *Request) (docdb, proxy *
 	return
 }
 
+// requestNamespace returns the command name, database, and collection of the request.
+// Database and collection are empty if they are not known.
+func requestNamespace(req *Request) (command, database, collection string) {
+	doc := req.Document()
+
+	command = doc.Command()
+	database, _ = doc.Get("$db").(string)
+
+	switch command {
+	case "":
+	case "getMore":
+		collection, _ = doc.Get("collection").(string)
+	default:
+		collection, _ = doc.Get(command).(string)
+	}
+
+	return
+}
+
 // startSpan starts a new OpenTelemetry span for the request and returns the derived context.
 func (m *Middleware) startSpan(ctx context.Context, req *Request) context.Context {
 	comment, _ := req.Document().Get("comment").(string)
@@ -213,13 +305,7 @@ func (m *Middleware) startSpan(ctx context.Context, req *Request) context.Contex
 		ctx = oteltrace.ContextWithSpanContext(ctx, spanCtx)
 	}
 
-	command := req.Document().Command()
-	database, _ := req.Document().Get("$db").(string)
-
-	var collection string
-	if command != "" {
-		collection, _ = req.Document().Get(command).(string)
-	}
+	command, database, collection := requestNamespace(req)
 
 	ctx, _ = otel.Tracer("").Start(
 		ctx,
@@ -317,11 +403,19 @@ func (m *Middleware) logDiff(ctx context.Context, docdb, proxy *Response) {
 // Describe implements [prometheus.Collector].
 func (m *Middleware) Describe(ch chan<- *prometheus.Desc) {
 	// m.opts.Metrics is not owned by the middleware; it exposes its own metrics.
+
+	if m.opts.Router != nil {
+		m.opts.Router.Describe(ch)
+	}
 }
 
 // Collect implements [prometheus.Collector].
 func (m *Middleware) Collect(ch chan<- prometheus.Metric) {
 	// m.opts.Metrics is not owned by the middleware; it exposes its own metrics.
+
+	if m.opts.Router != nil {
+		m.opts.Router.Collect(ch)
+	}
 }
 
 // check interfaces
@@ -22,6 +22,8 @@
 	NormalMode Mode = "normal"
 
 	// ProxyMode only proxies requests to another wire protocol compatible service.
+	// If DocumentDB handler is set, it handles authentication commands,
+	// so clients authenticate against FerretDB instead.
 	ProxyMode Mode = "proxy"
 
 	// DiffNormalMode both handles requests and proxies them, then logs the diff.
@@ -31,6 +33,10 @@
 	// DiffProxyMode both handles requests and proxies them, then logs the diff.
 	// Only the proxy response is sent to the client.
 	DiffProxyMode Mode = "diff-proxy"
+
+	// RoutingMode either handles or proxies each request depending on [Router] rules.
+	// Authentication commands are always handled.
+	RoutingMode Mode = "routing"
 )
 
 // AllModes includes all operation modes, with the first one being the default.
@@ -39,4 +45,5 @@
 	string(ProxyMode),
 	string(DiffNormalMode),
 	string(DiffProxyMode),
+	string(RoutingMode),
 }
@@ -0,0 +1,38 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"time"
+)
+
+// Profiler is an optional interface that could be implemented by [Handler]
+// to record information about handled commands, for example, for the database profiler.
+type Profiler interface {
+	// Profile is called by the dispatcher after the request is handled
+	// and before the response is sent to the client.
+	//
+	// It should not block; any expensive work should be done in the background.
+	Profile(ctx context.Context, op *HandledOp)
+}
+
+// HandledOp represents a handled request.
+type HandledOp struct {
+	Request  *Request
+	Response *Response // nil if unrecoverable error occurred
+	Start    time.Time
+	Duration time.Duration
+}
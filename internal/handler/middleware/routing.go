@@ -0,0 +1,506 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// RoutingTarget represents a handler that requests are routed to.
+type RoutingTarget string
+
+const (
+	// RoutingDocumentDB routes requests to the DocumentDB handler.
+	RoutingDocumentDB RoutingTarget = "documentdb"
+
+	// RoutingProxy routes requests to the proxy handler.
+	RoutingProxy RoutingTarget = "proxy"
+)
+
+// Rule names used in metrics for requests that were not routed by a configured rule.
+const (
+	routingRuleDefault = "default"
+	routingRuleCursor  = "cursor"
+)
+
+// routingCursorTimeout is the time after which unused cursors are forgotten.
+// It matches MongoDB's default cursorTimeoutMillis.
+const routingCursorTimeout = 10 * time.Minute
+
+// RoutingRule represents a single routing rule.
+//
+// Empty patterns match anything; non-empty patterns use [path.Match] syntax.
+// All non-empty patterns should match for the rule to be applied.
+//
+//nolint:vet // for readability
+type RoutingRule struct {
+	Name       string        `json:"name"`
+	DB         string        `json:"db"`
+	Collection string        `json:"collection"`
+	Command    string        `json:"command"`
+	Stages     []string      `json:"stages"` // if set, aggregation pipeline should contain any of those stages
+	Target     RoutingTarget `json:"target"`
+}
+
+// routingConfig represents the routing rules file.
+type routingConfig struct {
+	Default RoutingTarget `json:"default"` // defaults to DocumentDB
+	Rules   []RoutingRule `json:"rules"`
+}
+
+// routedCursor represents a cursor created by a routed request.
+type routedCursor struct {
+	lastUsed time.Time
+	target   RoutingTarget
+}
+
+// Router routes requests between DocumentDB and proxy handlers
+// using rules loaded from a file in [RoutingMode].
+//
+// The file is reloaded when it changes.
+// getMore and killCursors commands are routed to the handler that created the cursor.
+//
+//nolint:vet // for readability
+type Router struct {
+	opts *RouterOpts
+
+	config  atomic.Pointer[routingConfig]
+	modTime time.Time // used only by Run
+
+	cursorsM sync.Mutex
+	cursors  map[int64]*routedCursor
+
+	requests *prometheus.CounterVec
+	reloads  *prometheus.CounterVec
+	rules    prometheus.Gauge
+}
+
+// RouterOpts represents router configuration.
+type RouterOpts struct {
+	File           string
+	ReloadInterval time.Duration // zero value disables reloading
+	L              *slog.Logger
+}
+
+// NewRouter creates a new router and loads rules from the given file.
+func NewRouter(opts *RouterOpts) (*Router, error) {
+	must.NotBeZero(opts)
+
+	if opts.File == "" {
+		return nil, lazyerrors.New("routing rules file is not set")
+	}
+
+	r := &Router{
+		opts:    opts,
+		cursors: map[int64]*routedCursor{},
+		requests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: "routing",
+				Name:      "requests_total",
+				Help:      "Total number of routed requests by rule and target.",
+			},
+			[]string{"rule", "target"},
+		),
+		reloads: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: "routing",
+				Name:      "reloads_total",
+				Help:      "Total number of routing rules file reloads.",
+			},
+			[]string{"result"},
+		),
+		rules: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Subsystem: "routing",
+				Name:      "rules",
+				Help:      "The current number of routing rules.",
+			},
+		),
+	}
+
+	fi, err := os.Stat(opts.File)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if err = r.load(); err != nil {
+		return nil, err
+	}
+
+	r.modTime = fi.ModTime()
+
+	return r, nil
+}
+
+// load loads and validates rules from the file, replacing the current ones.
+func (r *Router) load() error {
+	b, err := os.ReadFile(r.opts.File)
+	if err != nil {
+		return lazyerrors.Errorf("Failed to read routing rules file: %w", err)
+	}
+
+	var config routingConfig
+	if err = json.Unmarshal(b, &config); err != nil {
+		return lazyerrors.Errorf("Failed to parse routing rules file: %w", err)
+	}
+
+	if err = config.validate(); err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	r.config.Store(&config)
+
+	r.rules.Set(float64(len(config.Rules)))
+
+	r.requests.With(prometheus.Labels{"rule": routingRuleDefault, "target": string(config.Default)})
+
+	for _, rule := range config.Rules {
+		r.requests.With(prometheus.Labels{"rule": rule.Name, "target": string(rule.Target)})
+	}
+
+	return nil
+}
+
+// validate checks the configuration and sets default values.
+func (config *routingConfig) validate() error {
+	switch config.Default {
+	case "":
+		config.Default = RoutingDocumentDB
+	case RoutingDocumentDB, RoutingProxy:
+	default:
+		return lazyerrors.Errorf("invalid default target %q", config.Default)
+	}
+
+	names := make(map[string]struct{}, len(config.Rules))
+
+	for i, rule := range config.Rules {
+		switch rule.Name {
+		case "":
+			return lazyerrors.Errorf("rule %d: name is not set", i)
+		case routingRuleDefault, routingRuleCursor:
+			return lazyerrors.Errorf("rule %d: name %q is reserved", i, rule.Name)
+		}
+
+		if _, ok := names[rule.Name]; ok {
+			return lazyerrors.Errorf("rule %d: duplicate name %q", i, rule.Name)
+		}
+
+		names[rule.Name] = struct{}{}
+
+		switch rule.Target {
+		case RoutingDocumentDB, RoutingProxy:
+		default:
+			return lazyerrors.Errorf("rule %q: invalid target %q", rule.Name, rule.Target)
+		}
+
+		for _, p := range append([]string{rule.DB, rule.Collection, rule.Command}, rule.Stages...) {
+			if _, err := path.Match(p, ""); err != nil {
+				return lazyerrors.Errorf("rule %q: invalid pattern %q: %w", rule.Name, p, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Run reloads rules when the file changes and forgets unused cursors until ctx is canceled.
+func (r *Router) Run(ctx context.Context) {
+	interval := r.opts.ReloadInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		if r.opts.ReloadInterval > 0 {
+			r.reload(ctx)
+		}
+
+		r.forgetCursors(time.Now().Add(-routingCursorTimeout))
+	}
+}
+
+// reload reloads rules if the file was modified.
+// On error, current rules are kept.
+func (r *Router) reload(ctx context.Context) {
+	fi, err := os.Stat(r.opts.File)
+	if err == nil {
+		if fi.ModTime().Equal(r.modTime) {
+			return
+		}
+
+		// do not retry the same invalid file
+		r.modTime = fi.ModTime()
+
+		err = r.load()
+	}
+
+	if err != nil {
+		r.reloads.WithLabelValues("error").Inc()
+		r.opts.L.WarnContext(ctx, "Failed to reload routing rules, keeping current ones", logging.Error(err))
+
+		return
+	}
+
+	r.reloads.WithLabelValues("ok").Inc()
+	r.opts.L.InfoContext(ctx, "Routing rules reloaded", slog.Int("rules", len(r.config.Load().Rules)))
+}
+
+// forgetCursors removes cursors that were not used since the given time.
+func (r *Router) forgetCursors(before time.Time) {
+	r.cursorsM.Lock()
+	defer r.cursorsM.Unlock()
+
+	for id, c := range r.cursors {
+		if c.lastUsed.Before(before) {
+			delete(r.cursors, id)
+		}
+	}
+}
+
+// Route returns the target for the given request document and the name of the matched rule.
+func (r *Router) Route(doc *wirebson.Document) (RoutingTarget, string) {
+	target, rule := r.route(doc)
+	r.requests.With(prometheus.Labels{"rule": rule, "target": string(target)}).Inc()
+
+	return target, rule
+}
+
+// route implements [Router.Route] without metrics.
+func (r *Router) route(doc *wirebson.Document) (RoutingTarget, string) {
+	config := r.config.Load()
+
+	command := doc.Command()
+
+	switch command {
+	case "getMore", "killCursors":
+		for _, id := range routingCursorIDs(doc) {
+			r.cursorsM.Lock()
+			c := r.cursors[id]
+
+			if c != nil {
+				c.lastUsed = time.Now()
+			}
+			r.cursorsM.Unlock()
+
+			if c != nil {
+				return c.target, routingRuleCursor
+			}
+		}
+	}
+
+	db, _ := doc.Get("$db").(string)
+
+	var collection string
+	switch command {
+	case "getMore":
+		collection, _ = doc.Get("collection").(string)
+	default:
+		collection, _ = doc.Get(command).(string)
+	}
+
+	for _, rule := range config.Rules {
+		if rule.matches(doc, db, collection, command) {
+			return rule.Target, rule.Name
+		}
+	}
+
+	return config.Default, routingRuleDefault
+}
+
+// matches returns true if the rule matches the given request.
+func (rule *RoutingRule) matches(doc *wirebson.Document, db, collection, command string) bool {
+	for _, p := range [][2]string{{rule.DB, db}, {rule.Collection, collection}, {rule.Command, command}} {
+		if p[0] == "" {
+			continue
+		}
+
+		// patterns are validated on load
+		if ok, _ := path.Match(p[0], p[1]); !ok {
+			return false
+		}
+	}
+
+	if len(rule.Stages) == 0 {
+		return true
+	}
+
+	for _, stage := range routingStages(doc) {
+		for _, p := range rule.Stages {
+			if ok, _ := path.Match(p, stage); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// routingStages returns names of aggregation pipeline stages of the given request.
+func routingStages(doc *wirebson.Document) []string {
+	v, _ := doc.Get("pipeline").(wirebson.AnyArray)
+	if v == nil {
+		return nil
+	}
+
+	pipeline, err := v.Decode()
+	if err != nil {
+		return nil
+	}
+
+	var res []string
+
+	for s := range pipeline.Values() {
+		d, _ := s.(wirebson.AnyDocument)
+		if d == nil {
+			continue
+		}
+
+		var stage *wirebson.Document
+		if stage, err = d.Decode(); err != nil {
+			continue
+		}
+
+		res = append(res, stage.Command())
+	}
+
+	return res
+}
+
+// routingCursorIDs returns cursor IDs used by getMore and killCursors requests.
+func routingCursorIDs(doc *wirebson.Document) []int64 {
+	switch doc.Command() {
+	case "getMore":
+		if id, ok := doc.Get("getMore").(int64); ok {
+			return []int64{id}
+		}
+
+	case "killCursors":
+		v, _ := doc.Get("cursors").(wirebson.AnyArray)
+		if v == nil {
+			return nil
+		}
+
+		arr, err := v.Decode()
+		if err != nil {
+			return nil
+		}
+
+		var res []int64
+
+		for id := range arr.Values() {
+			if id, ok := id.(int64); ok {
+				res = append(res, id)
+			}
+		}
+
+		return res
+	}
+
+	return nil
+}
+
+// Track records cursors created and closed by the routed request,
+// so getMore and killCursors commands are routed to the same target.
+func (r *Router) Track(doc *wirebson.Document, resp *Response, target RoutingTarget) {
+	if resp == nil || !resp.OK() {
+		return
+	}
+
+	r.cursorsM.Lock()
+	defer r.cursorsM.Unlock()
+
+	if doc.Command() == "killCursors" {
+		for _, id := range routingCursorIDs(doc) {
+			delete(r.cursors, id)
+		}
+
+		return
+	}
+
+	v, _ := resp.Document().Get("cursor").(wirebson.AnyDocument)
+	if v == nil {
+		return
+	}
+
+	cursor, err := v.Decode()
+	if err != nil {
+		return
+	}
+
+	id, _ := cursor.Get("id").(int64)
+
+	switch {
+	case doc.Command() == "getMore" && id == 0:
+		for _, id := range routingCursorIDs(doc) {
+			delete(r.cursors, id)
+		}
+
+	case id != 0:
+		if c := r.cursors[id]; c != nil {
+			c.lastUsed = time.Now()
+			return
+		}
+
+		r.cursors[id] = &routedCursor{
+			lastUsed: time.Now(),
+			target:   target,
+		}
+	}
+}
+
+// Describe implements [prometheus.Collector].
+func (r *Router) Describe(ch chan<- *prometheus.Desc) {
+	r.requests.Describe(ch)
+	r.reloads.Describe(ch)
+	r.rules.Describe(ch)
+}
+
+// Collect implements [prometheus.Collector].
+func (r *Router) Collect(ch chan<- prometheus.Metric) {
+	r.requests.Collect(ch)
+	r.reloads.Collect(ch)
+	r.rules.Collect(ch)
+}
+
+// check interfaces
+var (
+	_ prometheus.Collector = (*Router)(nil)
+)
@@ -0,0 +1,231 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/FerretDB/wire/wirebson"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/testutil"
+)
+
+// testRoutingRules is a routing rules file used by tests.
+const testRoutingRules = `{
+	"rules": [
+		{"name": "analytics", "db": "analytics", "target": "proxy"},
+		{"name": "lookup", "command": "aggregate", "stages": ["$lookup"], "target": "proxy"},
+		{"name": "logs", "db": "app", "collection": "logs_*", "target": "proxy"}
+	]
+}`
+
+// newTestRouter creates a router with the given rules file content.
+func newTestRouter(t *testing.T, rules string) (*Router, string) {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "routing.json")
+	require.NoError(t, os.WriteFile(file, []byte(rules), 0o666))
+
+	r, err := NewRouter(&RouterOpts{
+		File:           file,
+		ReloadInterval: 10 * time.Millisecond,
+		L:              testutil.Logger(t),
+	})
+	require.NoError(t, err)
+
+	return r, file
+}
+
+func TestRouterRoute(t *testing.T) {
+	t.Parallel()
+
+	r, _ := newTestRouter(t, testRoutingRules)
+
+	for name, tc := range map[string]struct {
+		doc    *wirebson.Document
+		target RoutingTarget
+		rule   string
+	}{
+		"DB": {
+			doc:    wirebson.MustDocument("find", "events", "$db", "analytics"),
+			target: RoutingProxy,
+			rule:   "analytics",
+		},
+		"Default": {
+			doc:    wirebson.MustDocument("find", "events", "$db", "app"),
+			target: RoutingDocumentDB,
+			rule:   "default",
+		},
+		"Stage": {
+			doc: wirebson.MustDocument(
+				"aggregate", "users",
+				"pipeline", wirebson.MustArray(
+					wirebson.MustDocument("$match", wirebson.MustDocument()),
+					wirebson.MustDocument("$lookup", wirebson.MustDocument()),
+				),
+				"$db", "app",
+			),
+			target: RoutingProxy,
+			rule:   "lookup",
+		},
+		"NoStage": {
+			doc: wirebson.MustDocument(
+				"aggregate", "users",
+				"pipeline", wirebson.MustArray(wirebson.MustDocument("$match", wirebson.MustDocument())),
+				"$db", "app",
+			),
+			target: RoutingDocumentDB,
+			rule:   "default",
+		},
+		"CollectionPattern": {
+			doc:    wirebson.MustDocument("insert", "logs_2025", "$db", "app"),
+			target: RoutingProxy,
+			rule:   "logs",
+		},
+		"GetMoreCollection": {
+			doc:    wirebson.MustDocument("getMore", int64(1), "collection", "logs_2025", "$db", "app"),
+			target: RoutingProxy,
+			rule:   "logs",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			target, rule := r.Route(tc.doc)
+			assert.Equal(t, tc.target, target)
+			assert.Equal(t, tc.rule, rule)
+		})
+	}
+}
+
+func TestRouterCursors(t *testing.T) {
+	t.Parallel()
+
+	r, _ := newTestRouter(t, testRoutingRules)
+
+	doc := wirebson.MustDocument(
+		"aggregate", "users",
+		"pipeline", wirebson.MustArray(wirebson.MustDocument("$lookup", wirebson.MustDocument())),
+		"$db", "app",
+	)
+
+	req, err := RequestDoc(doc)
+	require.NoError(t, err)
+
+	resp, err := ResponseDoc(req, wirebson.MustDocument(
+		"cursor", wirebson.MustDocument("firstBatch", wirebson.MustArray(), "id", int64(42), "ns", "app.users"),
+		"ok", float64(1),
+	))
+	require.NoError(t, err)
+
+	target, _ := r.Route(doc)
+	require.Equal(t, RoutingProxy, target)
+	r.Track(doc, resp, target)
+
+	getMore := wirebson.MustDocument("getMore", int64(42), "collection", "users", "$db", "app")
+
+	target, rule := r.Route(getMore)
+	assert.Equal(t, RoutingProxy, target)
+	assert.Equal(t, "cursor", rule)
+
+	killCursors := wirebson.MustDocument("killCursors", "users", "cursors", wirebson.MustArray(int64(42)), "$db", "app")
+
+	req, err = RequestDoc(killCursors)
+	require.NoError(t, err)
+
+	resp, err = ResponseDoc(req, wirebson.MustDocument("ok", float64(1)))
+	require.NoError(t, err)
+
+	target, _ = r.Route(killCursors)
+	assert.Equal(t, RoutingProxy, target)
+	r.Track(killCursors, resp, target)
+
+	target, rule = r.Route(getMore)
+	assert.Equal(t, RoutingDocumentDB, target)
+	assert.Equal(t, "default", rule)
+}
+
+func TestRouterReload(t *testing.T) {
+	t.Parallel()
+
+	r, file := newTestRouter(t, testRoutingRules)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	go r.Run(ctx)
+
+	doc := wirebson.MustDocument("find", "events", "$db", "analytics")
+
+	target, _ := r.Route(doc)
+	require.Equal(t, RoutingProxy, target)
+
+	// invalid file keeps current rules
+	require.NoError(t, os.WriteFile(file, []byte(`{"default": "invalid"}`), 0o666))
+	require.NoError(t, os.Chtimes(file, time.Now(), time.Now().Add(time.Second)))
+
+	require.Eventually(t, func() bool {
+		return promtestutil.ToFloat64(r.reloads.WithLabelValues("error")) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	target, _ = r.Route(doc)
+	assert.Equal(t, RoutingProxy, target)
+
+	require.NoError(t, os.WriteFile(file, []byte(`{"default": "proxy"}`), 0o666))
+	require.NoError(t, os.Chtimes(file, time.Now(), time.Now().Add(2*time.Second)))
+
+	require.Eventually(t, func() bool {
+		return promtestutil.ToFloat64(r.reloads.WithLabelValues("ok")) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	target, rule := r.Route(doc)
+	assert.Equal(t, RoutingProxy, target)
+	assert.Equal(t, "default", rule)
+
+	assert.Equal(t, float64(0), promtestutil.ToFloat64(r.rules))
+	assert.Equal(t, float64(2), promtestutil.ToFloat64(r.requests.WithLabelValues("analytics", "proxy")))
+}
+
+func TestRoutingConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	for name, rules := range map[string]string{
+		"NoName":        `{"rules": [{"db": "a", "target": "proxy"}]}`,
+		"ReservedName":  `{"rules": [{"name": "default", "target": "proxy"}]}`,
+		"DuplicateName": `{"rules": [{"name": "a", "target": "proxy"}, {"name": "a", "target": "proxy"}]}`,
+		"Target":        `{"rules": [{"name": "a", "target": "mongodb"}]}`,
+		"Pattern":       `{"rules": [{"name": "a", "db": "[", "target": "proxy"}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			file := filepath.Join(t.TempDir(), "routing.json")
+			require.NoError(t, os.WriteFile(file, []byte(rules), 0o666))
+
+			_, err := NewRouter(&RouterOpts{
+				File: file,
+				L:    testutil.Logger(t),
+			})
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// maxTopNamespaces is the maximum number of namespaces tracked by [Top].
+// It limits both memory usage and Prometheus metrics cardinality.
+// Operations on other namespaces are counted together as [TopOther].
+const maxTopNamespaces = 1000
+
+// TopOther is a name used for database and collection labels
+// of operations on namespaces that exceed the limit.
+const TopOther = "_other"
+
+// topWriteCommands contains commands that are counted as taking the write lock.
+var topWriteCommands = map[string]struct{}{
+	"collMod":          {},
+	"compact":          {},
+	"create":           {},
+	"createIndexes":    {},
+	"delete":           {},
+	"drop":             {},
+	"dropIndexes":      {},
+	"findAndModify":    {},
+	"insert":           {},
+	"reIndex":          {},
+	"renameCollection": {},
+	"update":           {},
+}
+
+// TopNamespace represents a namespace tracked by [Top].
+type TopNamespace struct {
+	DB         string
+	Collection string
+}
+
+// TopCounter represents the total time and count of operations of a single type.
+type TopCounter struct {
+	Time  time.Duration
+	Count int64
+}
+
+// add adds a single operation with the given duration.
+func (c *TopCounter) add(d time.Duration) {
+	c.Time += d
+	c.Count++
+}
+
+// TopStats represents per-namespace operation counters for `top` command.
+type TopStats struct {
+	Total     TopCounter
+	ReadLock  TopCounter
+	WriteLock TopCounter
+	Queries   TopCounter
+	GetMore   TopCounter
+	Insert    TopCounter
+	Update    TopCounter
+	Remove    TopCounter
+	Commands  TopCounter
+}
+
+// counters returns counters by their types, as used in Prometheus labels.
+func (s *TopStats) counters() map[string]*TopCounter {
+	return map[string]*TopCounter{
+		"total":     &s.Total,
+		"readLock":  &s.ReadLock,
+		"writeLock": &s.WriteLock,
+		"queries":   &s.Queries,
+		"getmore":   &s.GetMore,
+		"insert":    &s.Insert,
+		"update":    &s.Update,
+		"remove":    &s.Remove,
+		"commands":  &s.Commands,
+	}
+}
+
+// Top tracks per-namespace operation counters.
+type Top struct {
+	m  sync.Mutex
+	ns map[TopNamespace]*TopStats
+
+	count *prometheus.Desc
+	time  *prometheus.Desc
+}
+
+// newTop creates a new Top.
+func newTop() *Top {
+	return &Top{
+		ns: map[TopNamespace]*TopStats{},
+		count: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "top_operations_total"),
+			"Total number of operations by namespace and type.",
+			[]string{"db", "collection", "type"}, nil,
+		),
+		time: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "top_operations_seconds_total"),
+			"Total time of operations by namespace and type in seconds.",
+			[]string{"db", "collection", "type"}, nil,
+		),
+	}
+}
+
+// record records a single operation of the given command on the given namespace.
+//
+// Successful `drop` and `dropDatabase` commands remove counters of dropped namespaces.
+func (t *Top) record(command, db, collection string, d time.Duration, ok bool) {
+	if db == "" {
+		return
+	}
+
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	if ok {
+		switch command {
+		case "drop":
+			delete(t.ns, TopNamespace{DB: db, Collection: collection})
+			return
+
+		case "dropDatabase":
+			maps.DeleteFunc(t.ns, func(ns TopNamespace, _ *TopStats) bool {
+				return ns.DB == db
+			})
+
+			return
+		}
+	}
+
+	if collection == "" {
+		return
+	}
+
+	key := TopNamespace{DB: db, Collection: collection}
+
+	s := t.ns[key]
+	if s == nil {
+		if len(t.ns) >= maxTopNamespaces {
+			key = TopNamespace{DB: TopOther, Collection: TopOther}
+			s = t.ns[key]
+		}
+
+		if s == nil {
+			s = new(TopStats)
+			t.ns[key] = s
+		}
+	}
+
+	s.Total.add(d)
+
+	if _, ok := topWriteCommands[command]; ok {
+		s.WriteLock.add(d)
+	} else {
+		s.ReadLock.add(d)
+	}
+
+	switch command {
+	case "find":
+		s.Queries.add(d)
+	case "getMore":
+		s.GetMore.add(d)
+	case "insert":
+		s.Insert.add(d)
+	case "update":
+		s.Update.add(d)
+	case "delete":
+		s.Remove.add(d)
+	default:
+		s.Commands.add(d)
+	}
+}
+
+// Get returns a copy of counters for all tracked namespaces.
+func (t *Top) Get() map[TopNamespace]TopStats {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	res := make(map[TopNamespace]TopStats, len(t.ns))
+	for ns, s := range t.ns {
+		res[ns] = *s
+	}
+
+	return res
+}
+
+// Describe implements [prometheus.Collector].
+func (t *Top) Describe(ch chan<- *prometheus.Desc) {
+	ch <- t.count
+	ch <- t.time
+}
+
+// Collect implements [prometheus.Collector].
+func (t *Top) Collect(ch chan<- prometheus.Metric) {
+	for ns, s := range t.Get() {
+		for typ, c := range s.counters() {
+			ch <- prometheus.MustNewConstMetric(
+				t.count, prometheus.CounterValue, float64(c.Count), ns.DB, ns.Collection, typ,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				t.time, prometheus.CounterValue, c.Time.Seconds(), ns.DB, ns.Collection, typ,
+			)
+		}
+	}
+}
+
+// check interfaces
+var (
+	_ prometheus.Collector = (*Top)(nil)
+)
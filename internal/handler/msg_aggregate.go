@@ -18,6 +18,7 @@
 	"context"
 
 	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
 
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
 )
@@ -38,7 +39,49 @@ func (h *Handler) msgAggregate(connCtx context.Context, req *middleware.Request)
 		return nil, err
 	}
 
-	page, cursorID, err := h.p.Aggregate(connCtx, dbName, req.DocumentRaw())
+	if err = checkCollation(doc); err != nil {
+		return nil, err
+	}
+
+	spec := req.DocumentRaw()
+
+	// search stages must be the first stages of collection pipelines
+	if collectionName, ok := doc.Get("aggregate").(string); ok {
+		pipeline, stageName, stage, err := firstStage(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		switch stageName {
+		case "$listSearchIndexes":
+			var res *wirebson.Document
+
+			if res, err = h.listSearchIndexes(connCtx, dbName, collectionName, pipeline, stage); err != nil {
+				return nil, err
+			}
+
+			return middleware.ResponseDoc(req, res)
+
+		case "$vectorSearch":
+			if spec, err = h.vectorSearchSpec(connCtx, dbName, collectionName, doc, pipeline, stage); err != nil {
+				return nil, err
+			}
+
+			// Atlas returns no documents for search indexes that do not exist
+			if spec == nil {
+				return middleware.ResponseDoc(req, wirebson.MustDocument(
+					"cursor", wirebson.MustDocument(
+						"id", int64(0),
+						"ns", dbName+"."+collectionName,
+						"firstBatch", wirebson.MakeArray(0),
+					),
+					"ok", float64(1),
+				))
+			}
+		}
+	}
+
+	page, cursorID, err := h.p.Aggregate(connCtx, dbName, spec)
 	if err != nil {
 		return nil, lazyerrors.Error(err)
 	}
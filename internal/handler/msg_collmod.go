@@ -47,7 +47,7 @@ func (h *Handler) msgCollMod(connCtx context.Context, req *middleware.Request) (
 
 	var res wirebson.RawDocument
 
-	err = h.p.WithConn(func(conn *pgx.Conn) error {
+	err = h.p.WithConn(connCtx, func(conn *pgx.Conn) error {
 		res, err = documentdb_api.CollMod(connCtx, conn, h.L, dbName, collName, req.DocumentRaw())
 		return err
 	})
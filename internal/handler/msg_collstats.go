@@ -73,7 +73,7 @@ func (h *Handler) msgCollStats(connCtx context.Context, req *middleware.Request)
 
 	var res wirebson.RawDocument
 
-	err = h.p.WithConn(func(conn *pgx.Conn) error {
+	err = h.p.WithConn(connCtx, func(conn *pgx.Conn) error {
 		res, err = documentdb_api.CollStats(connCtx, conn, h.L, dbName, collection, scale)
 		return err
 	})
@@ -0,0 +1,111 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// connPoolName is the name of PostgreSQL connection pool in `connPoolStats` response.
+const connPoolName = "DocumentDB"
+
+// msgConnPoolStats implements `connPoolStats` command.
+//
+// PostgreSQL connection pool is reported as a single MongoDB connection pool with a single host.
+// Connections hijacked by persisted cursors and proxy upstream connections are reported
+// in FerretDB-specific section.
+//
+// The passed context is canceled when the client connection is closed.
+func (h *Handler) msgConnPoolStats(connCtx context.Context, req *middleware.Request) (*middleware.Response, error) {
+	doc := req.Document()
+
+	if _, _, err := h.s.CreateOrUpdateByLSID(connCtx, doc); err != nil {
+		return nil, err
+	}
+
+	stats := h.p.Stats()
+
+	host := wirebson.MustDocument(
+		"inUse", stats.Acquired,
+		"available", stats.Idle,
+		"leased", int32(0),
+		"created", stats.Created,
+		"refreshing", stats.Constructing,
+		"refreshed", int64(0),
+	)
+
+	pool := wirebson.MustDocument(
+		"poolInUse", stats.Acquired,
+		"poolAvailable", stats.Idle,
+		"poolLeased", int32(0),
+		"poolCreated", stats.Created,
+		"poolRefreshing", stats.Constructing,
+		"poolRefreshed", int64(0),
+		stats.Addr, host,
+	)
+
+	ferretdb := wirebson.MustDocument(
+		"pool", wirebson.MustDocument(
+			"total", stats.Total,
+			"max", stats.Max,
+			"acquires", stats.Acquires,
+			"emptyAcquires", stats.EmptyAcquires,
+			"canceledAcquires", stats.CanceledAcquires,
+			"acquireDurationMillis", stats.AcquireDuration.Milliseconds(),
+		),
+		"cursors", wirebson.MustDocument(
+			"total", stats.Cursors,
+			"hijackedConnections", stats.CursorConns,
+		),
+	)
+
+	if h.ProxyStats != nil {
+		ps := h.ProxyStats()
+
+		must.NoError(ferretdb.Add("proxy", wirebson.MustDocument(
+			"addr", ps.Addr,
+			"dedicated", ps.Dedicated,
+			"pooled", ps.Pooled,
+			"idle", ps.Idle,
+			"max", ps.Max,
+			"cursors", ps.Cursors,
+		)))
+	}
+
+	return middleware.ResponseDoc(req, wirebson.MustDocument(
+		"numClientConnections", int32(0),
+		"numAScopedConnections", int32(0),
+		"totalInUse", stats.Acquired,
+		"totalAvailable", stats.Idle,
+		"totalLeased", int32(0),
+		"totalCreated", stats.Created,
+		"totalRefreshing", stats.Constructing,
+		"totalRefreshed", int64(0),
+		"pools", wirebson.MustDocument(
+			connPoolName, pool,
+		),
+		"hosts", wirebson.MustDocument(
+			stats.Addr, host,
+		),
+		"replicaSets", wirebson.MustDocument(),
+		"ferretdb", ferretdb,
+		"ok", float64(1),
+	))
+}
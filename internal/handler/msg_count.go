@@ -40,9 +40,13 @@ func (h *Handler) msgCount(connCtx context.Context, req *middleware.Request) (*m
 		return nil, err
 	}
 
+	if err = checkCollation(doc); err != nil {
+		return nil, err
+	}
+
 	var res wirebson.RawDocument
 
-	err = h.p.WithConn(func(conn *pgx.Conn) error {
+	err = h.p.WithConn(connCtx, func(conn *pgx.Conn) error {
 		res, err = documentdb_api.CountQuery(connCtx, conn, h.L, dbName, req.DocumentRaw())
 		return err
 	})
@@ -18,12 +18,14 @@
 	"context"
 	"fmt"
 	"regexp"
+	"strings"
 	"unicode/utf8"
 
 	"github.com/AlekSi/lazyerrors"
 	"github.com/FerretDB/wire/wirebson"
 	"github.com/jackc/pgx/v5"
 
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb"
 	"github.com/FerretDB/FerretDB/v2/internal/documentdb/documentdb_api"
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
 	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
@@ -33,6 +35,55 @@
 // TODO https://github.com/FerretDB/FerretDB/issues/4879
 var collectionNameRe = regexp.MustCompile("^[^\\.$\x00][^$\x00]{0,234}$")
 
+// createOptions contains `create` options that are passed to DocumentDB as is.
+var createOptions = map[string]struct{}{
+	"viewOn":           {},
+	"pipeline":         {},
+	"validator":        {},
+	"validationLevel":  {},
+	"validationAction": {},
+	"collation":        {},
+}
+
+// createUnsupportedOptions contains known `create` options that are not supported yet.
+var createUnsupportedOptions = map[string]struct{}{
+	"autoIndexId":                  {},
+	"changeStreamPreAndPostImages": {},
+	"encryptedFields":              {},
+	"flags":                        {},
+	"idIndex":                      {},
+	"indexOptionDefaults":          {},
+	"storageEngine":                {},
+	"temp":                         {},
+}
+
+// cappedOptions contains `create` options for capped collections handled by FerretDB itself.
+var cappedOptions = map[string]struct{}{
+	"capped": {},
+	"size":   {},
+	"max":    {},
+}
+
+// clusteredOptions contains `create` options for clustered collections handled by FerretDB itself.
+// `expireAfterSeconds` is also used, see [timeseriesOptions].
+var clusteredOptions = map[string]struct{}{
+	"clusteredIndex": {},
+}
+
+// timeseriesOptions contains `create` options for time series collections handled by FerretDB itself.
+var timeseriesOptions = map[string]struct{}{
+	"timeseries":         {},
+	"expireAfterSeconds": {},
+}
+
+// timeseriesBucketMaxSpanSeconds contains valid values of `timeseries.granularity` option
+// and corresponding `bucketMaxSpanSeconds` values reported by MongoDB.
+var timeseriesBucketMaxSpanSeconds = map[string]int32{
+	"seconds": 3600,
+	"minutes": 86400,
+	"hours":   2592000,
+}
+
 // msgCreate implements `create` command.
 //
 // The passed context is canceled when the client connection is closed.
@@ -61,7 +112,133 @@ func (h *Handler) msgCreate(connCtx context.Context, req *middleware.Request) (*
 		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrInvalidNamespace, msg, command)
 	}
 
-	err = h.p.WithConn(func(conn *pgx.Conn) error {
+	if err = checkCollation(doc); err != nil {
+		return nil, err
+	}
+
+	var withOptions, withCappedOptions, withTimeSeriesOptions, withClusteredOptions bool
+
+	for _, k := range doc.FieldNames() {
+		if _, ok := createOptions[k]; ok {
+			withOptions = true
+			continue
+		}
+
+		if _, ok := cappedOptions[k]; ok {
+			withCappedOptions = true
+			continue
+		}
+
+		if _, ok := timeseriesOptions[k]; ok {
+			withTimeSeriesOptions = true
+			continue
+		}
+
+		if _, ok := clusteredOptions[k]; ok {
+			withClusteredOptions = true
+			continue
+		}
+
+		if _, ok := createUnsupportedOptions[k]; ok {
+			msg := fmt.Sprintf("Option '%s' is not supported yet", k)
+			return nil, mongoerrors.NewWithArgument(mongoerrors.ErrNotImplemented, msg, command)
+		}
+
+		if _, ok := genericArguments[k]; ok || k == command || strings.HasPrefix(k, "$") {
+			continue
+		}
+
+		msg := fmt.Sprintf("BSON field '%s.%s' is an unknown field.", command, k)
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrUnknownBsonField, msg, command)
+	}
+
+	if withClusteredOptions {
+		var clustered *documentdb.ClusteredCollection
+
+		if clustered, err = getClusteredOptions(doc); err != nil {
+			return nil, err
+		}
+
+		if withOptions {
+			msg := "Clustered collections with other options are not supported yet"
+			return nil, mongoerrors.NewWithArgument(mongoerrors.ErrNotImplemented, msg, command)
+		}
+
+		if err = h.p.CreateClustered(connCtx, dbName, collectionName, clustered); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		return middleware.ResponseDoc(req, wirebson.MustDocument(
+			"ok", float64(1),
+		))
+	}
+
+	if withTimeSeriesOptions {
+		var ts *documentdb.TimeSeries
+
+		if ts, err = getTimeSeriesOptions(doc); err != nil {
+			return nil, err
+		}
+
+		if withOptions || withCappedOptions {
+			msg := "Time series collections with other options are not supported yet"
+			return nil, mongoerrors.NewWithArgument(mongoerrors.ErrNotImplemented, msg, command)
+		}
+
+		if err = h.p.CreateTimeSeries(connCtx, dbName, collectionName, ts); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		if ts.MetaField != "" {
+			if err = h.createTimeSeriesIndex(connCtx, dbName, collectionName, ts); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+		}
+
+		return middleware.ResponseDoc(req, wirebson.MustDocument(
+			"ok", float64(1),
+		))
+	}
+
+	if withCappedOptions {
+		var limits *documentdb.CappedCollection
+
+		if limits, err = getCappedOptions(doc); err != nil {
+			return nil, err
+		}
+
+		if limits != nil {
+			if withOptions {
+				msg := "Capped collections with other options are not supported yet"
+				return nil, mongoerrors.NewWithArgument(mongoerrors.ErrNotImplemented, msg, command)
+			}
+
+			if err = h.p.CreateCapped(connCtx, dbName, collectionName, limits); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+
+			return middleware.ResponseDoc(req, wirebson.MustDocument(
+				"ok", float64(1),
+			))
+		}
+	}
+
+	// views and collections with options are created (and options are validated) by DocumentDB
+	if withOptions {
+		var res wirebson.RawDocument
+
+		err = h.p.WithConn(connCtx, func(conn *pgx.Conn) error {
+			res, err = documentdb_api.CreateCollectionView(connCtx, conn, h.L, dbName, req.DocumentRaw())
+			return err
+		})
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		return middleware.ResponseDoc(req, res)
+	}
+
+	err = h.p.WithConn(connCtx, func(conn *pgx.Conn) error {
 		_, err = documentdb_api.CreateCollection(connCtx, conn, h.L, dbName, collectionName)
 		return err
 	})
@@ -73,3 +250,303 @@ func (h *Handler) msgCreate(connCtx context.Context, req *middleware.Request) (*
 		"ok", float64(1),
 	))
 }
+
+// getCappedOptions returns limits of the capped collection from `create` command,
+// or nil if the collection is not capped.
+func getCappedOptions(doc *wirebson.Document) (*documentdb.CappedCollection, error) {
+	capped, err := getBoolParam("capped", doc.Get("capped"))
+	if err != nil {
+		return nil, err
+	}
+
+	if !capped {
+		return nil, nil
+	}
+
+	if doc.Get("size") == nil {
+		msg := "the 'size' field is required when 'capped' is true"
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrInvalidOptions, msg, "create")
+	}
+
+	size, err := getCreateInt64(doc, "size")
+	if err != nil {
+		return nil, err
+	}
+
+	maxDocs, err := getCreateInt64(doc, "max")
+	if err != nil {
+		return nil, err
+	}
+
+	if size > cappedMaxSize {
+		msg := "Capped collection size must be less than 1PB"
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrBadValue, msg, "create")
+	}
+
+	// the same rounding as in MongoDB
+	if size < cappedMinSize {
+		size = cappedMinSize
+	}
+
+	size = (size + 0xff) &^ 0xff
+
+	return &documentdb.CappedCollection{Size: size, Max: maxDocs}, nil
+}
+
+// Capped collection size limits.
+const (
+	cappedMinSize = 4096
+	cappedMaxSize = 1 << 50
+)
+
+// getCreateInt64 returns a non-negative integer option of `create` command with the given name, or 0 if it is not set.
+func getCreateInt64(doc *wirebson.Document, key string) (int64, error) {
+	var res int64
+
+	switch v := doc.Get(key).(type) {
+	case nil:
+		return 0, nil
+	case int32:
+		res = int64(v)
+	case int64:
+		res = v
+	case float64:
+		res = int64(v)
+	default:
+		msg := fmt.Sprintf(
+			"BSON field 'create.%s' is the wrong type '%s', expected types '[long, int, decimal, double]'",
+			key, aliasFromType(v),
+		)
+
+		return 0, mongoerrors.NewWithArgument(mongoerrors.ErrTypeMismatch, msg, "create")
+	}
+
+	if res < 0 {
+		msg := fmt.Sprintf("BSON field '%s' value must be >= 0, actual value '%d'", key, res)
+		return 0, mongoerrors.NewWithArgument(mongoerrors.ErrBadValue, msg, "create")
+	}
+
+	return res, nil
+}
+
+// getClusteredOptions returns options of the clustered collection from `create` command.
+// Options that conflict with clustering by _id are rejected.
+func getClusteredOptions(doc *wirebson.Document) (*documentdb.ClusteredCollection, error) {
+	if _, ok := doc.Get("viewOn").(string); ok {
+		msg := "The 'clusteredIndex' option is not supported for views"
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrInvalidOptions, msg, "create")
+	}
+
+	if doc.Get("timeseries") != nil {
+		msg := "The 'clusteredIndex' option is not supported for time-series collections"
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrInvalidOptions, msg, "create")
+	}
+
+	capped, err := getBoolParam("capped", doc.Get("capped"))
+	if err != nil {
+		return nil, err
+	}
+
+	if capped {
+		msg := "The 'clusteredIndex' option is not supported for capped collections"
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrInvalidOptions, msg, "create")
+	}
+
+	expireAfterSeconds, err := getCreateInt64(doc, "expireAfterSeconds")
+	if err != nil {
+		return nil, err
+	}
+
+	if doc.Get("expireAfterSeconds") != nil && expireAfterSeconds == 0 {
+		msg := "'expireAfterSeconds' must be a positive number"
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrInvalidOptions, msg, "create")
+	}
+
+	v := doc.Get("clusteredIndex")
+
+	opts, ok := v.(wirebson.AnyDocument)
+	if !ok {
+		msg := fmt.Sprintf("BSON field 'create.clusteredIndex' is the wrong type '%s', expected type 'object'", aliasFromType(v))
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrTypeMismatch, msg, "create")
+	}
+
+	optsDoc, err := opts.Decode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	res := &documentdb.ClusteredCollection{
+		Name:               "_id_",
+		ExpireAfterSeconds: expireAfterSeconds,
+	}
+
+	for k, v := range optsDoc.All() {
+		switch k {
+		case "key", "unique":
+			// checked below
+		case "name":
+			name, ok := v.(string)
+			if !ok {
+				msg := fmt.Sprintf(
+					"BSON field 'create.clusteredIndex.name' is the wrong type '%s', expected type 'string'",
+					aliasFromType(v),
+				)
+
+				return nil, mongoerrors.NewWithArgument(mongoerrors.ErrTypeMismatch, msg, "create")
+			}
+
+			res.Name = name
+		case "v":
+			if version, _ := getCreateInt64(optsDoc, "v"); version != 2 {
+				msg := fmt.Sprintf("Invalid clustered index version: %v", v)
+				return nil, mongoerrors.NewWithArgument(mongoerrors.ErrInvalidIndexSpecificationOption, msg, "create")
+			}
+		default:
+			msg := fmt.Sprintf("BSON field 'create.clusteredIndex.%s' is an unknown field.", k)
+			return nil, mongoerrors.NewWithArgument(mongoerrors.ErrUnknownBsonField, msg, "create")
+		}
+	}
+
+	for _, k := range []string{"key", "unique"} {
+		if optsDoc.Get(k) == nil {
+			msg := fmt.Sprintf("BSON field 'create.clusteredIndex.%s' is missing but a required field", k)
+			return nil, mongoerrors.NewWithArgument(mongoerrors.ErrLocation40414, msg, "create")
+		}
+	}
+
+	key, _ := optsDoc.Get("key").(wirebson.AnyDocument)
+	if key == nil {
+		msg := "The clusteredIndex option is only supported for key: {_id: 1}"
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrInvalidIndexSpecificationOption, msg, "create")
+	}
+
+	keyDoc, err := key.Decode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if order, _ := getCreateInt64(keyDoc, "_id"); keyDoc.Len() != 1 || order != 1 {
+		msg := "The clusteredIndex option is only supported for key: {_id: 1}"
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrInvalidIndexSpecificationOption, msg, "create")
+	}
+
+	unique, err := getBoolParam("clusteredIndex.unique", optsDoc.Get("unique"))
+	if err != nil {
+		return nil, err
+	}
+
+	if !unique {
+		msg := "The clusteredIndex option requires unique: true"
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrInvalidIndexSpecificationOption, msg, "create")
+	}
+
+	return res, nil
+}
+
+// getTimeSeriesOptions returns options of the time series collection from `create` command.
+func getTimeSeriesOptions(doc *wirebson.Document) (*documentdb.TimeSeries, error) {
+	expireAfterSeconds, err := getCreateInt64(doc, "expireAfterSeconds")
+	if err != nil {
+		return nil, err
+	}
+
+	v := doc.Get("timeseries")
+	if v == nil {
+		msg := "'expireAfterSeconds' is only supported on time-series collections"
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrInvalidOptions, msg, "create")
+	}
+
+	if doc.Get("expireAfterSeconds") != nil && expireAfterSeconds == 0 {
+		msg := "'expireAfterSeconds' must be a positive number"
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrInvalidOptions, msg, "create")
+	}
+
+	opts, ok := v.(wirebson.AnyDocument)
+	if !ok {
+		msg := fmt.Sprintf("BSON field 'create.timeseries' is the wrong type '%s', expected type 'object'", aliasFromType(v))
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrTypeMismatch, msg, "create")
+	}
+
+	optsDoc, err := opts.Decode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	res := &documentdb.TimeSeries{
+		Granularity:        "seconds",
+		ExpireAfterSeconds: expireAfterSeconds,
+	}
+
+	for k, v := range optsDoc.All() {
+		var field *string
+
+		switch k {
+		case "timeField":
+			field = &res.TimeField
+		case "metaField":
+			field = &res.MetaField
+		case "granularity":
+			field = &res.Granularity
+		case "bucketMaxSpanSeconds", "bucketRoundingSeconds":
+			msg := fmt.Sprintf("Option 'timeseries.%s' is not supported yet", k)
+			return nil, mongoerrors.NewWithArgument(mongoerrors.ErrNotImplemented, msg, "create")
+		default:
+			msg := fmt.Sprintf("BSON field 'create.timeseries.%s' is an unknown field.", k)
+			return nil, mongoerrors.NewWithArgument(mongoerrors.ErrUnknownBsonField, msg, "create")
+		}
+
+		s, ok := v.(string)
+		if !ok {
+			msg := fmt.Sprintf(
+				"BSON field 'create.timeseries.%s' is the wrong type '%s', expected type 'string'",
+				k, aliasFromType(v),
+			)
+
+			return nil, mongoerrors.NewWithArgument(mongoerrors.ErrTypeMismatch, msg, "create")
+		}
+
+		*field = s
+	}
+
+	if res.TimeField == "" {
+		msg := "BSON field 'create.timeseries.timeField' is missing but a required field"
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrLocation40414, msg, "create")
+	}
+
+	if _, ok := timeseriesBucketMaxSpanSeconds[res.Granularity]; !ok {
+		msg := fmt.Sprintf("Enumeration value '%s' for field 'granularity' is not a valid value.", res.Granularity)
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrBadValue, msg, "create")
+	}
+
+	if res.MetaField == res.TimeField {
+		msg := "The 'metaField' cannot be the same as the 'timeField'"
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrInvalidOptions, msg, "create")
+	}
+
+	return res, nil
+}
+
+// createTimeSeriesIndex creates an index on meta and time fields of the time series collection,
+// the same as MongoDB does.
+func (h *Handler) createTimeSeriesIndex(ctx context.Context, dbName, collectionName string, ts *documentdb.TimeSeries) error {
+	spec, err := wirebson.MustDocument(
+		"createIndexes", collectionName,
+		"indexes", wirebson.MustArray(wirebson.MustDocument(
+			"key", wirebson.MustDocument(ts.MetaField, int32(1), ts.TimeField, int32(1)),
+			"name", ts.MetaField+"_1_"+ts.TimeField+"_1",
+		)),
+	).Encode()
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	err = h.p.WithConn(ctx, func(conn *pgx.Conn) error {
+		_, err = h.createIndexes(ctx, conn, "create", dbName, spec)
+		return err
+	})
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	return nil
+}
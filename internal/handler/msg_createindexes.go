@@ -53,9 +53,15 @@ func (h *Handler) msgCreateIndexes(connCtx context.Context, req *middleware.Requ
 		)
 	}
 
+	if indexes, ok := v.(wirebson.AnyArray); ok {
+		if err = checkIndexesCollation(indexes); err != nil {
+			return nil, err
+		}
+	}
+
 	var res wirebson.AnyDocument
 
-	err = h.p.WithConn(func(conn *pgx.Conn) error {
+	err = h.p.WithConn(connCtx, func(conn *pgx.Conn) error {
 		res, err = h.createIndexes(connCtx, conn, doc.Command(), dbName, req.DocumentRaw())
 		return err
 	})
@@ -0,0 +1,362 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/jackc/pgx/v5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+)
+
+// vectorSimilarities maps Atlas vector similarity functions to DocumentDB ones.
+var vectorSimilarities = map[string]string{
+	"cosine":     "COS",
+	"euclidean":  "L2",
+	"dotProduct": "IP",
+}
+
+// Limits of vector dimensions, the same as in Atlas.
+const (
+	vectorMinDimensions = 1
+	vectorMaxDimensions = 8192
+)
+
+// msgCreateSearchIndexes implements `createSearchIndexes` command.
+//
+// Only `vectorSearch` indexes are supported.
+// They are created as DocumentDB HNSW vector indexes.
+//
+// The passed context is canceled when the client connection is closed.
+func (h *Handler) msgCreateSearchIndexes(connCtx context.Context, req *middleware.Request) (*middleware.Response, error) {
+	doc := req.Document()
+
+	if _, _, err := h.s.CreateOrUpdateByLSID(connCtx, doc); err != nil {
+		return nil, err
+	}
+
+	command := doc.Command()
+
+	dbName, err := getRequiredParam[string](doc, "$db")
+	if err != nil {
+		return nil, err
+	}
+
+	collectionName, err := getRequiredParam[string](doc, command)
+	if err != nil {
+		return nil, err
+	}
+
+	v := doc.Get("indexes")
+	if v == nil {
+		msg := "BSON field 'createSearchIndexes.indexes' is missing but a required field"
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrLocation40414, msg, command)
+	}
+
+	arr, ok := v.(wirebson.AnyArray)
+	if !ok {
+		msg := fmt.Sprintf(
+			"BSON field 'createSearchIndexes.indexes' is the wrong type '%s', expected type 'array'",
+			aliasFromType(v),
+		)
+
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrTypeMismatch, msg, command)
+	}
+
+	indexes, err := arr.Decode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	created := wirebson.MakeArray(indexes.Len())
+
+	for i, v := range indexes.All() {
+		var index *documentdb.SearchIndex
+		var spec *wirebson.Document
+
+		if index, spec, err = getSearchIndex(i, v); err != nil {
+			return nil, err
+		}
+
+		spec, err = wirebson.NewDocument(
+			"createIndexes", collectionName,
+			"indexes", wirebson.MustArray(spec),
+		)
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		var raw wirebson.RawDocument
+
+		if raw, err = spec.Encode(); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		err = h.p.WithConn(connCtx, func(conn *pgx.Conn) error {
+			_, err = h.createIndexes(connCtx, conn, command, dbName, raw)
+			return err
+		})
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		if err = h.p.CreateSearchIndex(connCtx, dbName, collectionName, index); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		if err = created.Add(wirebson.MustDocument("id", index.ID, "name", index.Name)); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	return middleware.ResponseDoc(req, wirebson.MustDocument(
+		"indexesCreated", created,
+		"ok", float64(1),
+	))
+}
+
+// getSearchIndex returns the search index with the given position in the `indexes` field,
+// and the specification of the corresponding DocumentDB index.
+func getSearchIndex(i int, v any) (*documentdb.SearchIndex, *wirebson.Document, error) {
+	path := fmt.Sprintf("createSearchIndexes.indexes.%d", i)
+
+	d, ok := v.(wirebson.AnyDocument)
+	if !ok {
+		msg := fmt.Sprintf("BSON field '%s' is the wrong type '%s', expected type 'object'", path, aliasFromType(v))
+		return nil, nil, mongoerrors.NewWithArgument(mongoerrors.ErrTypeMismatch, msg, path)
+	}
+
+	indexDoc, err := d.Decode()
+	if err != nil {
+		return nil, nil, lazyerrors.Error(err)
+	}
+
+	name, err := getOptionalParam(indexDoc, "name", "default")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	typ, err := getOptionalParam(indexDoc, "type", "search")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if typ != "vectorSearch" {
+		msg := fmt.Sprintf("Search index type '%s' is not supported yet, only 'vectorSearch' is supported", typ)
+		return nil, nil, mongoerrors.NewWithArgument(mongoerrors.ErrNotImplemented, msg, path)
+	}
+
+	definition, ok := indexDoc.Get("definition").(wirebson.AnyDocument)
+	if !ok {
+		msg := fmt.Sprintf("BSON field '%s.definition' is missing but a required field", path)
+		return nil, nil, mongoerrors.NewWithArgument(mongoerrors.ErrLocation40414, msg, path)
+	}
+
+	definitionDoc, err := definition.Decode()
+	if err != nil {
+		return nil, nil, lazyerrors.Error(err)
+	}
+
+	fields, ok := definitionDoc.Get("fields").(wirebson.AnyArray)
+	if !ok {
+		msg := fmt.Sprintf("BSON field '%s.definition.fields' is missing but a required field", path)
+		return nil, nil, mongoerrors.NewWithArgument(mongoerrors.ErrLocation40414, msg, path)
+	}
+
+	fieldsArr, err := fields.Decode()
+	if err != nil {
+		return nil, nil, lazyerrors.Error(err)
+	}
+
+	var spec *wirebson.Document
+
+	for j, f := range fieldsArr.All() {
+		fieldPath := fmt.Sprintf("%s.definition.fields.%d", path, j)
+
+		field, ok := f.(wirebson.AnyDocument)
+		if !ok {
+			msg := fmt.Sprintf("BSON field '%s' is the wrong type '%s', expected type 'object'", fieldPath, aliasFromType(f))
+			return nil, nil, mongoerrors.NewWithArgument(mongoerrors.ErrTypeMismatch, msg, path)
+		}
+
+		fieldDoc, err := field.Decode()
+		if err != nil {
+			return nil, nil, lazyerrors.Error(err)
+		}
+
+		fieldType, err := getRequiredParam[string](fieldDoc, "type")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if _, err = getRequiredParam[string](fieldDoc, "path"); err != nil {
+			return nil, nil, err
+		}
+
+		switch fieldType {
+		case "filter":
+			// DocumentDB applies filters of `$vectorSearch` to any field
+			continue
+
+		case "vector":
+			if spec != nil {
+				msg := "Search indexes with multiple vector fields are not supported yet"
+				return nil, nil, mongoerrors.NewWithArgument(mongoerrors.ErrNotImplemented, msg, path)
+			}
+
+			if spec, err = vectorIndexSpec(name, fieldPath, fieldDoc); err != nil {
+				return nil, nil, err
+			}
+
+		default:
+			msg := fmt.Sprintf("Invalid type '%s' for field '%s', expected 'vector' or 'filter'", fieldType, fieldPath)
+			return nil, nil, mongoerrors.NewWithArgument(mongoerrors.ErrBadValue, msg, path)
+		}
+	}
+
+	if spec == nil {
+		msg := fmt.Sprintf("Search index '%s' must have a field with 'vector' type", name)
+		return nil, nil, mongoerrors.NewWithArgument(mongoerrors.ErrBadValue, msg, path)
+	}
+
+	raw, err := definitionDoc.Encode()
+	if err != nil {
+		return nil, nil, lazyerrors.Error(err)
+	}
+
+	index := &documentdb.SearchIndex{
+		ID:         bson.NewObjectID().Hex(),
+		Name:       name,
+		Type:       typ,
+		Definition: raw,
+	}
+
+	return index, spec, nil
+}
+
+// vectorIndexSpec returns the specification of DocumentDB vector index for the given `vector` field definition.
+func vectorIndexSpec(name, fieldPath string, field *wirebson.Document) (*wirebson.Document, error) {
+	path, err := getRequiredParam[string](field, "path")
+	if err != nil {
+		return nil, err
+	}
+
+	dimensions, err := getSearchInt(field, fieldPath, "numDimensions")
+	if err != nil {
+		return nil, err
+	}
+
+	if dimensions < vectorMinDimensions || dimensions > vectorMaxDimensions {
+		msg := fmt.Sprintf(
+			"'%s.numDimensions' must be between %d and %d, got %d",
+			fieldPath, vectorMinDimensions, vectorMaxDimensions, dimensions,
+		)
+
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrBadValue, msg, fieldPath)
+	}
+
+	similarity, err := getRequiredParam[string](field, "similarity")
+	if err != nil {
+		return nil, err
+	}
+
+	kind, ok := vectorSimilarities[similarity]
+	if !ok {
+		msg := fmt.Sprintf(
+			"'%s.similarity' must be one of 'euclidean', 'cosine', 'dotProduct', got '%s'",
+			fieldPath, similarity,
+		)
+
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrBadValue, msg, fieldPath)
+	}
+
+	if q, _ := field.Get("quantization").(string); q != "" && q != "none" {
+		msg := fmt.Sprintf("Vector quantization '%s' is not supported yet", q)
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrNotImplemented, msg, fieldPath)
+	}
+
+	opts := wirebson.MustDocument(
+		"kind", "vector-hnsw",
+		"similarity", kind,
+		"dimensions", int32(dimensions),
+	)
+
+	if hnsw, ok := field.Get("hnswOptions").(wirebson.AnyDocument); ok {
+		hnswDoc, err := hnsw.Decode()
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		for _, o := range [][2]string{{"maxEdges", "m"}, {"numEdgeCandidates", "efConstruction"}} {
+			if hnswDoc.Get(o[0]) == nil {
+				continue
+			}
+
+			v, err := getSearchInt(hnswDoc, fieldPath+".hnswOptions", o[0])
+			if err != nil {
+				return nil, err
+			}
+
+			if err = opts.Add(o[1], int32(v)); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+		}
+	}
+
+	return wirebson.MustDocument(
+		"name", name,
+		"key", wirebson.MustDocument(path, "cosmosSearch"),
+		"cosmosSearchOptions", opts,
+	), nil
+}
+
+// getSearchInt returns a required positive integer field of search index definitions and `$vectorSearch` stages.
+// The path is used in error messages.
+func getSearchInt(doc *wirebson.Document, path, key string) (int64, error) {
+	var res int64
+
+	switch v := doc.Get(key).(type) {
+	case nil:
+		msg := fmt.Sprintf("BSON field '%s.%s' is missing but a required field", path, key)
+		return 0, mongoerrors.NewWithArgument(mongoerrors.ErrLocation40414, msg, path)
+	case int32:
+		res = int64(v)
+	case int64:
+		res = v
+	case float64:
+		res = int64(v)
+	default:
+		msg := fmt.Sprintf(
+			"BSON field '%s.%s' is the wrong type '%s', expected types '[long, int, decimal, double]'",
+			path, key, aliasFromType(v),
+		)
+
+		return 0, mongoerrors.NewWithArgument(mongoerrors.ErrTypeMismatch, msg, path)
+	}
+
+	if res <= 0 {
+		msg := fmt.Sprintf("BSON field '%s.%s' value must be > 0, actual value '%d'", path, key, res)
+		return 0, mongoerrors.NewWithArgument(mongoerrors.ErrBadValue, msg, path)
+	}
+
+	return res, nil
+}
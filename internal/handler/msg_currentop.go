@@ -16,37 +16,210 @@
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/AlekSi/lazyerrors"
 	"github.com/FerretDB/wire/wirebson"
-	"github.com/jackc/pgx/v5"
 
-	"github.com/FerretDB/FerretDB/v2/internal/documentdb/documentdb_api"
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
 )
 
 // msgCurrentOp implements `currentOp` command.
 //
-// The passed context is canceled when the client connection is closed.
+// Only operations of this FerretDB instance are returned.
+// Filter supports equality conditions on top-level fields only.
+// Operations of other users are returned only to privileged users (see [Handler.privileged]),
+// and only if `$ownOps` is not set.
+// Commands that could contain credentials are redacted.
+// PostgreSQL backend PID of the running query is returned in `ferretdb.backendPid` field.
 //
-// TODO https://github.com/FerretDB/FerretDB/issues/3974
+// The passed context is canceled when the client connection is closed.
 func (h *Handler) msgCurrentOp(connCtx context.Context, req *middleware.Request) (*middleware.Response, error) {
 	doc := req.Document()
 
-	if _, _, err := h.s.CreateOrUpdateByLSID(connCtx, doc); err != nil {
+	_, _, err := h.s.CreateOrUpdateByLSID(connCtx, doc)
+	if err != nil {
 		return nil, err
 	}
 
-	var res wirebson.RawDocument
+	var ownOps bool
 
-	var err error
-	err = h.p.WithConn(func(conn *pgx.Conn) error {
-		res, err = documentdb_api.CurrentOpCommand(connCtx, conn, h.L, req.DocumentRaw())
-		return err
-	})
+	if v := doc.Get("$ownOps"); v != nil {
+		if ownOps, err = getBoolParam("$ownOps", v); err != nil {
+			return nil, err
+		}
+	}
+
+	if !ownOps {
+		var privileged bool
+		if privileged, err = h.privileged(connCtx); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		if !privileged {
+			return nil, mongoerrors.NewWithArgument(
+				mongoerrors.ErrUnauthorized,
+				"not authorized on admin to execute command currentOp without $ownOps",
+				doc.Command(),
+			)
+		}
+	}
+
+	user := conninfo.Get(connCtx).Conv().Username()
+
+	filter := wirebson.MustDocument()
+
+	for k, v := range doc.All() {
+		switch {
+		case k == doc.Command(), strings.HasPrefix(k, "$"):
+			continue
+		case k == "lsid", k == "comment":
+			continue
+		}
+
+		switch v.(type) {
+		case wirebson.AnyDocument, wirebson.AnyArray:
+			return nil, mongoerrors.NewWithArgument(
+				mongoerrors.ErrNotImplemented,
+				fmt.Sprintf("currentOp filter for field %q is not implemented", k),
+				doc.Command(),
+			)
+		}
+
+		must.NoError(filter.Add(k, v))
+	}
+
+	now := time.Now()
+
+	inprog := wirebson.MakeArray(0)
+
+	for _, op := range h.ops.all() {
+		if ownOps && op.user != user {
+			continue
+		}
+
+		var opDoc *wirebson.Document
+		if opDoc, err = op.currentOpDocument(now); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		if !matchesCurrentOpFilter(opDoc, filter) {
+			continue
+		}
+
+		must.NoError(inprog.Add(opDoc))
+	}
+
+	return middleware.ResponseDoc(req, wirebson.MustDocument(
+		"inprog", inprog,
+		"ok", float64(1),
+	))
+}
+
+// currentOpDocument returns `currentOp` command's representation of the operation.
+func (op *operation) currentOpDocument(now time.Time) (*wirebson.Document, error) {
+	running := now.Sub(op.start)
+
+	command, err := redactSensitive(op.command)
 	if err != nil {
 		return nil, lazyerrors.Error(err)
 	}
 
-	return middleware.ResponseDoc(req, res)
+	res := wirebson.MustDocument(
+		"type", "op",
+		"active", true,
+		"currentOpTime", now.Format("2006-01-02T15:04:05.000-07:00"),
+		"opid", op.opID,
+		"secs_running", int64(running.Seconds()),
+		"microsecs_running", running.Microseconds(),
+		"op", currentOpType(op.command.Command()),
+		"ns", currentOpNamespace(op.command),
+		"command", command,
+	)
+
+	if op.client != "" {
+		must.NoError(res.Add("client", op.client))
+	}
+
+	if pid := op.query.PID(); pid != 0 {
+		must.NoError(res.Add("ferretdb", wirebson.MustDocument(
+			"backendPid", int64(pid),
+		)))
+	}
+
+	return res, nil
+}
+
+// currentOpType returns the type of operation for `currentOp` command, as in MongoDB.
+func currentOpType(command string) string {
+	switch command {
+	case "insert", "update":
+		return command
+	case "delete":
+		return "remove"
+	case "find":
+		return "query"
+	case "getMore":
+		return "getmore"
+	default:
+		return "command"
+	}
+}
+
+// currentOpNamespace returns the namespace of the given command.
+func currentOpNamespace(command *wirebson.Document) string {
+	dbName, _ := command.Get("$db").(string)
+
+	if command.Command() == "getMore" {
+		if cName, ok := command.Get("collection").(string); ok {
+			return dbName + "." + cName
+		}
+	}
+
+	if cName, ok := command.Get(command.Command()).(string); ok {
+		return dbName + "." + cName
+	}
+
+	return dbName + ".$cmd"
+}
+
+// matchesCurrentOpFilter returns true if the operation document matches all equality conditions.
+func matchesCurrentOpFilter(opDoc, filter *wirebson.Document) bool {
+	for k, v := range filter.All() {
+		actual := opDoc.Get(k)
+
+		if actual == v {
+			continue
+		}
+
+		// compare numbers of different types, such as opid
+		if a, ok := currentOpNumber(actual); ok {
+			if b, ok := currentOpNumber(v); ok && a == b {
+				continue
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// currentOpNumber converts the given value to float64 if it is a number.
+func currentOpNumber(v any) (float64, bool) {
+	switch v := v.(type) {
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
 }
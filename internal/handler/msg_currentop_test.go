@@ -0,0 +1,52 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/testutil"
+)
+
+func TestCurrentOpDocumentRedacted(t *testing.T) {
+	t.Parallel()
+
+	op := &operation{
+		start: time.Now(),
+		command: wirebson.MustDocument(
+			"updateUser", "alice",
+			"pwd", "secret",
+			"$db", "admin",
+		),
+		opID: 1,
+	}
+
+	doc, err := op.currentOpDocument(time.Now())
+	require.NoError(t, err)
+
+	actual, ok := doc.Get("command").(*wirebson.Document)
+	require.True(t, ok, "%T", doc.Get("command"))
+
+	expected := wirebson.MustDocument(
+		"updateUser", "alice",
+		"pwd", "###",
+		"$db", "admin",
+	)
+	testutil.AssertEqual(t, expected, actual)
+}
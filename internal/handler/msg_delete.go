@@ -45,11 +45,24 @@ func (h *Handler) msgDelete(connCtx context.Context, req *middleware.Request) (*
 		return nil, err
 	}
 
+	collectionName, _ := doc.Get(doc.Command()).(string)
+
+	statements, err := writeStatements(doc, "deletes", seq)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if err = checkStatementsCollation(doc.Command(), "deletes", statements); err != nil {
+		return nil, err
+	}
+
 	var res wirebson.RawDocument
 
-	err = h.p.WithConn(func(conn *pgx.Conn) error {
+	filters := writeFilters(doc, statements)
+
+	err = h.p.WithWriteConn(connCtx, dbName, collectionName, filters, func(conn *pgx.Conn) ([]any, error) {
 		res, _, err = documentdb_api.Delete(connCtx, conn, h.L, dbName, spec, seq)
-		return err
+		return nil, err
 	})
 	if err != nil {
 		return nil, lazyerrors.Error(err)
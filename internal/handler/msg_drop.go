@@ -62,7 +62,7 @@ func (h *Handler) msgDrop(connCtx context.Context, req *middleware.Request) (*mi
 
 	var dropped bool
 
-	err = h.p.WithConn(func(conn *pgx.Conn) error {
+	err = h.p.WithConn(connCtx, func(conn *pgx.Conn) error {
 		dropped, err = documentdb_api.DropCollection(connCtx, conn, h.L, dbName, collectionName, nil, nil, false)
 		return err
 	})
@@ -70,6 +70,10 @@ func (h *Handler) msgDrop(connCtx context.Context, req *middleware.Request) (*mi
 		return nil, lazyerrors.Error(err)
 	}
 
+	if err = h.p.DropMetadata(connCtx, dbName, collectionName); err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
 	res := wirebson.MakeDocument(3)
 	if dropped {
 		must.NoError(res.Add("nIndexesWas", int32(1))) // TODO https://github.com/FerretDB/FerretDB/issues/2337
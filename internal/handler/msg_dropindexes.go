@@ -16,6 +16,7 @@
 
 import (
 	"context"
+	"slices"
 
 	"github.com/AlekSi/lazyerrors"
 	"github.com/FerretDB/wire/wirebson"
@@ -41,7 +42,8 @@ func (h *Handler) msgDropIndexes(connCtx context.Context, req *middleware.Reques
 		return nil, err
 	}
 
-	if index := doc.Get("index"); index == nil {
+	index := doc.Get("index")
+	if index == nil {
 		return nil, mongoerrors.NewWithArgument(
 			mongoerrors.ErrLocation40414,
 			"BSON field 'dropIndexes.index' is missing but a required field",
@@ -51,7 +53,7 @@ func (h *Handler) msgDropIndexes(connCtx context.Context, req *middleware.Reques
 
 	var res wirebson.RawDocument
 
-	err = h.p.WithConn(func(conn *pgx.Conn) error {
+	err = h.p.WithConn(connCtx, func(conn *pgx.Conn) error {
 		res, err = documentdb_api.DropIndexes(connCtx, conn, h.L, dbName, req.DocumentRaw(), nil)
 		return err
 	})
@@ -59,5 +61,55 @@ func (h *Handler) msgDropIndexes(connCtx context.Context, req *middleware.Reques
 		return nil, lazyerrors.Error(err)
 	}
 
+	// vector indexes could be dropped as regular indexes
+	if err = h.dropSearchIndexes(connCtx, dbName, doc, index); err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
 	return middleware.ResponseDoc(req, res)
 }
+
+// dropSearchIndexes removes definitions of search indexes dropped by `dropIndexes` command.
+func (h *Handler) dropSearchIndexes(ctx context.Context, dbName string, doc *wirebson.Document, index any) error {
+	collectionName, ok := doc.Get(doc.Command()).(string)
+	if !ok {
+		return nil
+	}
+
+	var names []string
+
+	switch index := index.(type) {
+	case string:
+		names = []string{index}
+	case wirebson.AnyArray:
+		arr, err := index.Decode()
+		if err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		for v := range arr.Values() {
+			if name, ok := v.(string); ok {
+				names = append(names, name)
+			}
+		}
+	default:
+		return nil
+	}
+
+	indexes, err := h.p.ListSearchIndexes(ctx, dbName, collectionName)
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	for _, si := range indexes {
+		if !slices.Contains(names, "*") && !slices.Contains(names, si.Name) {
+			continue
+		}
+
+		if err = h.p.DropSearchIndex(ctx, dbName, collectionName, si.Name); err != nil {
+			return lazyerrors.Error(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb/documentdb_api"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// msgDropSearchIndex implements `dropSearchIndex` command.
+//
+// The passed context is canceled when the client connection is closed.
+func (h *Handler) msgDropSearchIndex(connCtx context.Context, req *middleware.Request) (*middleware.Response, error) {
+	doc := req.Document()
+
+	if _, _, err := h.s.CreateOrUpdateByLSID(connCtx, doc); err != nil {
+		return nil, err
+	}
+
+	command := doc.Command()
+
+	dbName, err := getRequiredParam[string](doc, "$db")
+	if err != nil {
+		return nil, err
+	}
+
+	collectionName, err := getRequiredParam[string](doc, command)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := getOptionalParam(doc, "name", "")
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := getOptionalParam(doc, "id", "")
+	if err != nil {
+		return nil, err
+	}
+
+	if (name == "") == (id == "") {
+		msg := "Either 'name' or 'id' must be specified, but not both"
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrInvalidOptions, msg, command)
+	}
+
+	indexes, err := h.p.ListSearchIndexes(connCtx, dbName, collectionName)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	var found bool
+
+	for _, index := range indexes {
+		if index.Name == name || index.ID == id {
+			name = index.Name
+			found = true
+
+			break
+		}
+	}
+
+	if !found {
+		msg := fmt.Sprintf("Search index '%s%s' not found", name, id)
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrIndexNotFound, msg, command)
+	}
+
+	spec := must.NotFail(wirebson.MustDocument(
+		"dropIndexes", collectionName,
+		"index", name,
+	).Encode())
+
+	err = h.p.WithConn(connCtx, func(conn *pgx.Conn) error {
+		_, err = documentdb_api.DropIndexes(connCtx, conn, h.L, dbName, spec, nil)
+		return err
+	})
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if err = h.p.DropSearchIndex(connCtx, dbName, collectionName, name); err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return middleware.ResponseDoc(req, wirebson.MustDocument(
+		"ok", float64(1),
+	))
+}
@@ -53,7 +53,7 @@ func (h *Handler) msgDropUser(connCtx context.Context, req *middleware.Request)
 
 	var res wirebson.RawDocument
 
-	err = h.p.WithConn(func(conn *pgx.Conn) error {
+	err = h.p.WithConn(connCtx, func(conn *pgx.Conn) error {
 		// TODO https://github.com/FerretDB/FerretDB-DocumentDB/issues/859
 		res, err = documentdb_api.DropUser(connCtx, conn, h.L, dropUserSpec)
 		return err
@@ -81,38 +81,9 @@ func (h *Handler) msgExplain(connCtx context.Context, req *middleware.Request) (
 		)
 	}
 
-	var f string
-	switch cmd {
-	case "aggregate":
-		f = "documentdb_api_catalog.bson_aggregation_pipeline"
-	case "count":
-		f = "documentdb_api_catalog.bson_aggregation_count"
-	case "find":
-		f = "documentdb_api_catalog.bson_aggregation_find"
-	default:
-		return nil, mongoerrors.NewWithArgument(
-			mongoerrors.ErrNotImplemented,
-			fmt.Sprintf("explain for %s command is not supported", cmd),
-			command,
-		)
-	}
-
-	q := fmt.Sprintf(`
-		EXPLAIN (FORMAT JSON)
-			SELECT document
-		FROM %s($1, $2::bytea)`,
-		f,
-	)
-
-	conn, err := h.p.Acquire()
+	dest, err := h.explainQuery(connCtx, dbName, cmd, explainSpec)
 	if err != nil {
-		return nil, lazyerrors.Error(err)
-	}
-	defer conn.Release()
-
-	var dest []byte
-	if err = conn.Conn().QueryRow(connCtx, q, dbName, explainSpec).Scan(&dest); err != nil {
-		return nil, lazyerrors.Error(mongoerrors.Make(connCtx, err, "", h.L))
+		return nil, err
 	}
 
 	queryPlan, err := unmarshalExplain(dest)
@@ -162,6 +133,46 @@ func (h *Handler) msgExplain(connCtx context.Context, req *middleware.Request) (
 	return middleware.ResponseDoc(req, res)
 }
 
+// explainQuery returns PostgreSQL query plan in JSON format for the given `find`, `count`, or `aggregate` command.
+// The query is not executed.
+func (h *Handler) explainQuery(ctx context.Context, dbName, cmd string, spec wirebson.RawDocument) ([]byte, error) {
+	var f string
+	switch cmd {
+	case "aggregate":
+		f = "documentdb_api_catalog.bson_aggregation_pipeline"
+	case "count":
+		f = "documentdb_api_catalog.bson_aggregation_count"
+	case "find":
+		f = "documentdb_api_catalog.bson_aggregation_find"
+	default:
+		return nil, mongoerrors.NewWithArgument(
+			mongoerrors.ErrNotImplemented,
+			fmt.Sprintf("explain for %s command is not supported", cmd),
+			"explain",
+		)
+	}
+
+	q := fmt.Sprintf(`
+		EXPLAIN (FORMAT JSON)
+			SELECT document
+		FROM %s($1, $2::bytea)`,
+		f,
+	)
+
+	conn, err := h.p.Acquire(ctx)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+	defer conn.Release()
+
+	var dest []byte
+	if err = conn.Conn().QueryRow(ctx, q, dbName, spec).Scan(&dest); err != nil {
+		return nil, lazyerrors.Error(mongoerrors.Make(ctx, err, "", h.L))
+	}
+
+	return dest, nil
+}
+
 // unmarshalExplain unmarshalls the plan from EXPLAIN postgreSQL command.
 func unmarshalExplain(b []byte) (*wirebson.Document, error) {
 	var plans []map[string]any
@@ -16,10 +16,13 @@
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
 
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
 )
 
 // msgFind implements `find` command.
@@ -38,7 +41,21 @@ func (h *Handler) msgFind(connCtx context.Context, req *middleware.Request) (*mi
 		return nil, err
 	}
 
-	page, cursorID, err := h.p.Find(connCtx, dbName, req.DocumentRaw())
+	if err = checkCollation(doc); err != nil {
+		return nil, err
+	}
+
+	tailable, err := getFindTailable(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	find := h.p.Find
+	if tailable {
+		find = h.p.FindTailable
+	}
+
+	page, cursorID, err := find(connCtx, dbName, req.DocumentRaw())
 	if err != nil {
 		return nil, lazyerrors.Error(err)
 	}
@@ -47,3 +64,50 @@ func (h *Handler) msgFind(connCtx context.Context, req *middleware.Request) (*mi
 
 	return middleware.ResponseDoc(req, page)
 }
+
+// getFindTailable returns true if the `find` command requests a tailable cursor,
+// and validates related parameters.
+func getFindTailable(doc *wirebson.Document) (bool, error) {
+	tailable, err := getOptionalParam(doc, "tailable", false)
+	if err != nil {
+		return false, err
+	}
+
+	awaitData, err := getOptionalParam(doc, "awaitData", false)
+	if err != nil {
+		return false, err
+	}
+
+	if awaitData && !tailable {
+		msg := "Cannot set 'awaitData' without also setting 'tailable'"
+		return false, mongoerrors.NewWithArgument(mongoerrors.ErrFailedToParse, msg, "find")
+	}
+
+	if !tailable {
+		return false, nil
+	}
+
+	if sort, _ := doc.Get("sort").(wirebson.AnyDocument); sort != nil {
+		sortDoc, err := sort.Decode()
+		if err != nil {
+			return false, lazyerrors.Error(err)
+		}
+
+		for k := range sortDoc.Fields() {
+			if k != "$natural" {
+				msg := "error processing query: tailable cursor requested on non capped collection " +
+					"or with a sort other than $natural"
+				return false, mongoerrors.NewWithArgument(mongoerrors.ErrBadValue, msg, "find")
+			}
+		}
+	}
+
+	for _, k := range []string{"skip", "limit", "singleBatch"} {
+		if doc.Get(k) != nil {
+			msg := fmt.Sprintf("Option '%s' is not supported for tailable cursors yet", k)
+			return false, mongoerrors.NewWithArgument(mongoerrors.ErrNotImplemented, msg, "find")
+		}
+	}
+
+	return true, nil
+}
@@ -21,6 +21,7 @@
 	"github.com/FerretDB/wire/wirebson"
 	"github.com/jackc/pgx/v5"
 
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb"
 	"github.com/FerretDB/FerretDB/v2/internal/documentdb/documentdb_api"
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
 )
@@ -40,11 +41,29 @@ func (h *Handler) msgFindAndModify(connCtx context.Context, req *middleware.Requ
 		return nil, err
 	}
 
+	collectionName, _ := doc.Get(doc.Command()).(string)
+
+	if err = checkCollation(doc); err != nil {
+		return nil, err
+	}
+
+	var filters []documentdb.WriteFilter
+
+	if filter, ok := doc.Get("query").(wirebson.AnyDocument); ok {
+		collation, _ := doc.Get("collation").(wirebson.AnyDocument)
+		let, _ := doc.Get("let").(wirebson.AnyDocument)
+
+		filters = append(filters, documentdb.WriteFilter{Filter: filter, Collation: collation, Let: let})
+	}
+
 	var res wirebson.RawDocument
 
-	err = h.p.WithConn(func(conn *pgx.Conn) error {
-		res, _, err = documentdb_api.FindAndModify(connCtx, conn, h.L, dbName, req.DocumentRaw())
-		return err
+	err = h.p.WithWriteConn(connCtx, dbName, collectionName, filters, func(conn *pgx.Conn) ([]any, error) {
+		if res, _, err = documentdb_api.FindAndModify(connCtx, conn, h.L, dbName, req.DocumentRaw()); err != nil {
+			return nil, err
+		}
+
+		return upsertedIDs(res)
 	})
 	if err != nil {
 		return nil, lazyerrors.Error(err)
@@ -16,14 +16,24 @@
 
 import (
 	"context"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/FerretDB/wire/wirebson"
 
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
 )
 
 // msgGetCmdLineOpts implements `getCmdLineOpts` command.
 //
+// Values of server parameters that could be set with flags are reported as they were at startup,
+// even if they were changed at runtime with `setParameter`.
+// Passwords in command-line arguments are redacted (see [redactArgs]).
+//
 // The passed context is canceled when the client connection is closed.
 func (h *Handler) msgGetCmdLineOpts(connCtx context.Context, req *middleware.Request) (*middleware.Response, error) {
 	doc := req.Document()
@@ -32,9 +42,99 @@ func (h *Handler) msgGetCmdLineOpts(connCtx context.Context, req *middleware.Req
 		return nil, err
 	}
 
+	args := redactArgs(os.Args)
+
+	argv := wirebson.MakeArray(len(args))
+	for _, arg := range args {
+		must.NoError(argv.Add(arg))
+	}
+
+	setParameter := wirebson.MakeDocument(len(h.params))
+
+	for _, name := range h.parameterNames() {
+		if p := h.params[name]; p.settableAtStartup() {
+			must.NoError(setParameter.Add(name, p.startupValue()))
+		}
+	}
+
 	return middleware.ResponseDoc(req, wirebson.MustDocument(
-		"argv", wirebson.MustArray("ferretdb"),
-		"parsed", wirebson.MustDocument(),
+		"argv", argv,
+		"parsed", wirebson.MustDocument(
+			"setParameter", setParameter,
+		),
 		"ok", float64(1),
 	))
 }
+
+// redactedPassword replaces secret values in command-line arguments, like MongoDB does.
+const redactedPassword = "<password>"
+
+// secretFlags contains flags with values that are never returned by `getCmdLineOpts`.
+var secretFlags = []string{
+	"--proxy-password",
+}
+
+// dsnPasswordRe matches password in PostgreSQL keyword/value connection strings.
+var dsnPasswordRe = regexp.MustCompile(`(password\s*=\s*)('[^']*'|\S+)`)
+
+// redactArgs returns a copy of command-line arguments with values of secret flags
+// and passwords in connection strings (like `--postgresql-url`) redacted.
+func redactArgs(args []string) []string {
+	res := make([]string, len(args))
+
+	var secretNext bool
+
+	for i, arg := range args {
+		if secretNext {
+			res[i] = redactedPassword
+			secretNext = false
+
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(arg, "=")
+
+		switch {
+		case slices.Contains(secretFlags, name) && hasValue:
+			res[i] = name + "=" + redactedPassword
+		case slices.Contains(secretFlags, name):
+			res[i] = arg
+			secretNext = true
+		case strings.HasPrefix(name, "--") && hasValue:
+			res[i] = name + "=" + redactConnString(value)
+		default:
+			res[i] = redactConnString(arg)
+		}
+	}
+
+	return res
+}
+
+// redactConnString returns the given value with a password redacted
+// if it is a URL or PostgreSQL keyword/value connection string.
+// Other values are returned as is.
+func redactConnString(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" {
+		return dsnPasswordRe.ReplaceAllString(s, "${1}"+redactedPassword)
+	}
+
+	var found bool
+
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redactedPassword)
+		found = true
+	}
+
+	if q := u.Query(); q.Has("password") {
+		q.Set("password", redactedPassword)
+		u.RawQuery = q.Encode()
+		found = true
+	}
+
+	if !found {
+		return s
+	}
+
+	return u.String()
+}
@@ -59,5 +59,14 @@ func (h *Handler) msgGetMore(connCtx context.Context, req *middleware.Request) (
 		return nil, lazyerrors.Error(err)
 	}
 
+	if doc.Get("collection") == listCollectionsCursorCollection {
+		res, err := h.addCollectionOptions(connCtx, dbName, page)
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		return middleware.ResponseDoc(req, res)
+	}
+
 	return middleware.ResponseDoc(req, page)
 }
@@ -42,30 +42,17 @@ func (h *Handler) msgGetParameter(connCtx context.Context, req *middleware.Reque
 		return nil, lazyerrors.Error(err)
 	}
 
-	parameters := wirebson.MustDocument(
-		"authenticationMechanisms", wirebson.MustDocument(
-			"value", wirebson.MustArray("SCRAM-SHA-1", "SCRAM-SHA-256"),
-			"settableAtRuntime", false,
-			"settableAtStartup", true,
-		),
-		"authSchemaVersion", wirebson.MustDocument(
-			"value", int32(5),
-			"settableAtRuntime", true,
-			"settableAtStartup", true,
-		),
-		"featureCompatibilityVersion", wirebson.MustDocument(
-			// TODO https://github.com/FerretDB/FerretDB/issues/5073
-			"value", wirebson.MustDocument("version", "7.0"),
-			"settableAtRuntime", false,
-			"settableAtStartup", false,
-		),
-		"quiet", wirebson.MustDocument(
-			"value", false,
-			"settableAtRuntime", true,
-			"settableAtStartup", true,
-		),
-		// parameters are alphabetically ordered
-	)
+	parameters := wirebson.MakeDocument(len(h.params))
+
+	for _, name := range h.parameterNames() {
+		p := h.params[name]
+
+		must.NoError(parameters.Add(name, wirebson.MustDocument(
+			"value", p.value(),
+			"settableAtRuntime", p.settableAtRuntime(),
+			"settableAtStartup", p.settableAtStartup(),
+		)))
+	}
 
 	res, err := selectParameters(doc, parameters, showDetails, allParameters)
 	if err != nil {
@@ -93,7 +93,7 @@ func (h *Handler) hello(ctx context.Context, doc *wirebson.Document, tcpHost, na
 	must.NoError(res.Add("connectionId", connectionID))
 	must.NoError(res.Add("minWireVersion", minWireVersion))
 	must.NoError(res.Add("maxWireVersion", maxWireVersion))
-	must.NoError(res.Add("readOnly", false))
+	must.NoError(res.Add("readOnly", h.readOnly.Load()))
 	must.NoError(res.Add("saslSupportedMechs", wirebson.MustArray("SCRAM-SHA-256")))
 
 	authV := doc.Get("speculativeAuthenticate")
@@ -15,16 +15,23 @@
 package handler
 
 import (
+	"cmp"
 	"context"
+	"fmt"
+	"slices"
+	"time"
 
 	"github.com/AlekSi/lazyerrors"
 	"github.com/FerretDB/wire"
 	"github.com/FerretDB/wire/wirebson"
 	"github.com/jackc/pgx/v5"
+	"go.mongodb.org/mongo-driver/v2/bson"
 
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb"
 	"github.com/FerretDB/FerretDB/v2/internal/documentdb/documentdb_api"
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
 	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
 )
 
 // msgInsert implements `insert` command.
@@ -45,15 +52,369 @@ func (h *Handler) msgInsert(connCtx context.Context, req *middleware.Request) (*
 		return nil, err
 	}
 
-	var res wirebson.RawDocument
+	collectionName, _ := doc.Get(doc.Command()).(string)
 
-	err = h.p.WithConn(func(conn *pgx.Conn) error {
-		res, _, err = documentdb_api.Insert(connCtx, conn, h.L, dbName, spec, seq)
-		return err
-	})
+	capped, err := h.p.Capped(connCtx, dbName, collectionName)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	ts, err := h.p.TimeSeries(connCtx, dbName, collectionName)
 	if err != nil {
 		return nil, lazyerrors.Error(err)
 	}
 
-	return middleware.ResponseDoc(req, mongoerrors.MapWriteErrors(connCtx, res))
+	// capped collections need _id values of inserted documents to track insertion order,
+	// and documents of time series collections are validated before insertion
+	var docs []wirebson.RawDocument
+
+	// original indexes of documents passed to DocumentDB and write errors for other documents,
+	// only for time series collections
+	var valid []int
+	var invalid []*wirebson.Document
+
+	// for other collections, it is validated by DocumentDB
+	ordered := true
+
+	if capped != nil || ts != nil {
+		if v := doc.Get("ordered"); v != nil {
+			if ordered, err = getBoolParam("ordered", v); err != nil {
+				return nil, err
+			}
+		}
+
+		var specDoc *wirebson.Document
+
+		if specDoc, docs, err = getInsertDocuments(spec, seq); err != nil {
+			return nil, err
+		}
+
+		toInsert := docs
+
+		if ts != nil {
+			toInsert, valid, invalid = validateTimeSeries(ts, docs, ordered)
+		}
+
+		if spec, err = insertSpec(specDoc, toInsert); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		seq = nil
+
+		// DocumentDB does not accept empty batches
+		if len(toInsert) == 0 {
+			spec = nil
+		}
+	}
+
+	res := must.NotFail(wirebson.MustDocument("n", int32(0), "ok", float64(1)).Encode())
+
+	if spec != nil {
+		insert := func(conn *pgx.Conn) error {
+			res, _, err = documentdb_api.Insert(connCtx, conn, h.L, dbName, spec, seq)
+			return err
+		}
+
+		if capped == nil {
+			err = h.p.WithConn(connCtx, insert)
+		} else {
+			err = h.p.CappedInsert(connCtx, dbName, collectionName, capped, func(conn *pgx.Conn) ([]wirebson.RawDocument, error) {
+				if err := insert(conn); err != nil {
+					return nil, err
+				}
+
+				return insertedDocuments(res, docs, ordered)
+			})
+		}
+
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	mapped := mongoerrors.MapWriteErrors(connCtx, res)
+
+	if ts != nil {
+		if mapped, err = mergeWriteErrors(mapped, valid, invalid, ordered); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	return middleware.ResponseDoc(req, mapped)
+}
+
+// getInsertDocuments returns `insert` command spec without `documents` field,
+// and all documents from that field and the document sequence.
+// Documents without _id field get a new ObjectID.
+func getInsertDocuments(spec wirebson.RawDocument, seq []byte) (*wirebson.Document, []wirebson.RawDocument, error) {
+	specDoc, err := spec.Decode()
+	if err != nil {
+		return nil, nil, lazyerrors.Error(err)
+	}
+
+	var docs []wirebson.RawDocument
+
+	switch v := specDoc.Get("documents").(type) {
+	case nil:
+	case wirebson.AnyArray:
+		arr, err := v.Decode()
+		if err != nil {
+			return nil, nil, lazyerrors.Error(err)
+		}
+
+		for v := range arr.Values() {
+			d, ok := v.(wirebson.AnyDocument)
+			if !ok {
+				return nil, nil, mongoerrors.NewWithArgument(
+					mongoerrors.ErrTypeMismatch,
+					fmt.Sprintf("BSON field 'insert.documents.0' is the wrong type '%s', expected type 'object'", aliasFromType(v)),
+					"documents",
+				)
+			}
+
+			raw, err := d.Encode()
+			if err != nil {
+				return nil, nil, lazyerrors.Error(err)
+			}
+
+			docs = append(docs, raw)
+		}
+	default:
+		msg := fmt.Sprintf("BSON field 'insert.documents' is the wrong type '%s', expected type 'array'", aliasFromType(v))
+		return nil, nil, mongoerrors.NewWithArgument(mongoerrors.ErrTypeMismatch, msg, "documents")
+	}
+
+	seqDocs, err := splitDocumentSequence(seq)
+	if err != nil {
+		return nil, nil, lazyerrors.Error(err)
+	}
+
+	docs = append(docs, seqDocs...)
+
+	for i, d := range docs {
+		withID, err := ensureID(d)
+		if err != nil {
+			return nil, nil, lazyerrors.Error(err)
+		}
+
+		if docs[i], err = withID.Encode(); err != nil {
+			return nil, nil, lazyerrors.Error(err)
+		}
+	}
+
+	specDoc.Remove("documents")
+
+	return specDoc, docs, nil
+}
+
+// insertSpec returns `insert` command spec with the given documents.
+func insertSpec(specDoc *wirebson.Document, docs []wirebson.RawDocument) (wirebson.RawDocument, error) {
+	arr := wirebson.MakeArray(len(docs))
+
+	for _, d := range docs {
+		if err := arr.Add(d); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	res := wirebson.MakeDocument(specDoc.Len() + 1)
+
+	for k, v := range specDoc.All() {
+		if err := res.Add(k, v); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	if err := res.Add("documents", arr); err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return res.Encode()
+}
+
+// ensureID returns a document with _id field.
+// If the given document does not have it, a new ObjectID is added.
+func ensureID(doc wirebson.RawDocument) (*wirebson.Document, error) {
+	decoded, err := doc.Decode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if decoded.Get("_id") != nil {
+		return decoded, nil
+	}
+
+	id, err := wirebson.FromDriver(bson.NewObjectID())
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	res := wirebson.MakeDocument(decoded.Len() + 1)
+	if err = res.Add("_id", id); err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	for k, v := range decoded.All() {
+		if err = res.Add(k, v); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	return res, nil
+}
+
+// insertedDocuments returns documents that were inserted successfully, using `insert` command's result.
+// For ordered inserts, documents after the first failed one are not inserted.
+func insertedDocuments(res wirebson.RawDocument, docs []wirebson.RawDocument, ordered bool) ([]wirebson.RawDocument, error) {
+	resDoc, err := res.Decode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	failed := map[int]struct{}{}
+	firstFailed := len(docs)
+
+	if v, ok := resDoc.Get("writeErrors").(wirebson.AnyArray); ok {
+		writeErrors, err := v.Decode()
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		for v := range writeErrors.Values() {
+			we, ok := v.(wirebson.AnyDocument)
+			if !ok {
+				continue
+			}
+
+			weDoc, err := we.Decode()
+			if err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+
+			i, ok := weDoc.Get("index").(int32)
+			if !ok {
+				continue
+			}
+
+			failed[int(i)] = struct{}{}
+			firstFailed = min(firstFailed, int(i))
+		}
+	}
+
+	var inserted []wirebson.RawDocument
+
+	for i, d := range docs {
+		if _, ok := failed[i]; ok {
+			continue
+		}
+
+		if ordered && i > firstFailed {
+			break
+		}
+
+		inserted = append(inserted, d)
+	}
+
+	return inserted, nil
+}
+
+// validateTimeSeries returns documents of the time series collection that could be inserted,
+// their indexes, and write errors for other documents.
+// For ordered inserts, documents after the first invalid one are skipped without errors.
+func validateTimeSeries(ts *documentdb.TimeSeries, docs []wirebson.RawDocument, ordered bool) ([]wirebson.RawDocument, []int, []*wirebson.Document) { //nolint:lll // for readability
+	var toInsert []wirebson.RawDocument
+	var valid []int
+	var invalid []*wirebson.Document
+
+	for i, d := range docs {
+		decoded, err := d.Decode()
+		if err == nil {
+			if _, ok := decoded.Get(ts.TimeField).(time.Time); ok {
+				toInsert = append(toInsert, d)
+				valid = append(valid, i)
+
+				continue
+			}
+		}
+
+		invalid = append(invalid, wirebson.MustDocument(
+			"index", int32(i),
+			"code", int32(mongoerrors.ErrBadValue),
+			"errmsg", fmt.Sprintf("'%s' must be present and contain a valid BSON UTC datetime value", ts.TimeField),
+		))
+
+		if ordered {
+			break
+		}
+	}
+
+	return toInsert, valid, invalid
+}
+
+// mergeWriteErrors returns `insert` command's result with indexes of DocumentDB's write errors
+// mapped to original documents' indexes, and with additional write errors.
+func mergeWriteErrors(res wirebson.AnyDocument, valid []int, invalid []*wirebson.Document, ordered bool) (wirebson.AnyDocument, error) {
+	resDoc, err := res.Decode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	var writeErrors []*wirebson.Document
+
+	if v, ok := resDoc.Get("writeErrors").(wirebson.AnyArray); ok {
+		arr, err := v.Decode()
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		for v := range arr.Values() {
+			we, ok := v.(wirebson.AnyDocument)
+			if !ok {
+				return nil, lazyerrors.Errorf("unexpected write error %T", v)
+			}
+
+			weDoc, err := we.Decode()
+			if err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+
+			if i, ok := weDoc.Get("index").(int32); ok && int(i) < len(valid) {
+				must.NoError(weDoc.Replace("index", int32(valid[i])))
+			}
+
+			writeErrors = append(writeErrors, weDoc)
+		}
+	}
+
+	// ordered insert stops at the first error reported by DocumentDB, before any invalid document
+	if !ordered || len(writeErrors) == 0 {
+		writeErrors = append(writeErrors, invalid...)
+	}
+
+	if len(writeErrors) == 0 {
+		return res, nil
+	}
+
+	slices.SortFunc(writeErrors, func(a, b *wirebson.Document) int {
+		return cmp.Compare(a.Get("index").(int32), b.Get("index").(int32))
+	})
+
+	arr := wirebson.MakeArray(len(writeErrors))
+	for _, we := range writeErrors {
+		must.NoError(arr.Add(we))
+	}
+
+	resDoc.Remove("writeErrors")
+
+	// keep `ok` last
+	ok := resDoc.Get("ok")
+	resDoc.Remove("ok")
+
+	must.NoError(resDoc.Add("writeErrors", arr))
+
+	if ok != nil {
+		must.NoError(resDoc.Add("ok", ok))
+	}
+
+	return resDoc, nil
 }
@@ -0,0 +1,115 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+)
+
+// msgKillOp implements `killOp` command.
+//
+// Operations of other users could be killed only by privileged users (see [Handler.privileged]).
+//
+// The passed context is canceled when the client connection is closed.
+func (h *Handler) msgKillOp(connCtx context.Context, req *middleware.Request) (*middleware.Response, error) {
+	doc := req.Document()
+
+	if _, _, err := h.s.CreateOrUpdateByLSID(connCtx, doc); err != nil {
+		return nil, err
+	}
+
+	dbName, err := getRequiredParam[string](doc, "$db")
+	if err != nil {
+		return nil, err
+	}
+
+	if dbName != "admin" {
+		return nil, mongoerrors.NewWithArgument(
+			mongoerrors.ErrUnauthorized,
+			doc.Command()+" may only be run against the admin database.",
+			doc.Command(),
+		)
+	}
+
+	var opID int32
+
+	switch v := doc.Get("op").(type) {
+	case nil:
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrBadValue, `Did not provide "op" field`, "op")
+	case int32:
+		opID = v
+	case int64:
+		if v < math.MinInt32 || v > math.MaxInt32 {
+			return nil, mongoerrors.NewWithArgument(mongoerrors.ErrBadValue, fmt.Sprintf("invalid op: %d", v), "op")
+		}
+
+		opID = int32(v)
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt32 || v > math.MaxInt32 {
+			return nil, mongoerrors.NewWithArgument(mongoerrors.ErrBadValue, fmt.Sprintf("invalid op: %v", v), "op")
+		}
+
+		opID = int32(v)
+	default:
+		msg := fmt.Sprintf(
+			"BSON field 'killOp.op' is the wrong type '%s', expected types '[long, int, decimal, double]'",
+			aliasFromType(v),
+		)
+
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrTypeMismatch, msg, "op")
+	}
+
+	if op := h.ops.get(opID); op != nil {
+		if user := conninfo.Get(connCtx).Conv().Username(); op.user != user {
+			var privileged bool
+			if privileged, err = h.privileged(connCtx); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+
+			if !privileged {
+				return nil, mongoerrors.NewWithArgument(
+					mongoerrors.ErrUnauthorized,
+					"not authorized on admin to kill operations of other users",
+					doc.Command(),
+				)
+			}
+		}
+
+		op.kill()
+
+		h.L.InfoContext(connCtx, "Killing operation", slog.Int("opid", int(opID)))
+
+		// context cancellation interrupts the query too, but only if it is still waiting for the result
+		if err = op.query.Cancel(connCtx); err != nil {
+			h.L.WarnContext(connCtx, "Failed to cancel PostgreSQL query", logging.Error(err))
+		}
+	}
+
+	return middleware.ResponseDoc(req, wirebson.MustDocument(
+		"info", "attempting to kill op",
+		"ok", float64(1),
+	))
+}
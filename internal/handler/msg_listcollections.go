@@ -18,8 +18,10 @@
 	"context"
 
 	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
 
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
 )
 
 // msgListCollections implements `listCollections` command.
@@ -45,5 +47,120 @@ func (h *Handler) msgListCollections(connCtx context.Context, req *middleware.Re
 
 	h.s.AddCursor(connCtx, userID, sessionID, cursorID)
 
-	return middleware.ResponseDoc(req, page)
+	res, err := h.addCollectionOptions(connCtx, dbName, page)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return middleware.ResponseDoc(req, res)
+}
+
+// listCollectionsCursorCollection is the value of `collection` field of `getMore` command
+// for `listCollections` cursors.
+const listCollectionsCursorCollection = "$cmd.listCollections"
+
+// addCollectionOptions adds capped, time series, and clustered collection options
+// to the page (first or subsequent) of `listCollections` cursor,
+// as DocumentDB does not know about them.
+func (h *Handler) addCollectionOptions(ctx context.Context, dbName string, page wirebson.RawDocument) (wirebson.AnyDocument, error) {
+	pageDoc, err := page.DecodeDeep()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	c, _ := pageDoc.Get("cursor").(*wirebson.Document)
+	if c == nil {
+		return page, nil
+	}
+
+	batch, _ := c.Get("firstBatch").(*wirebson.Array)
+	if batch == nil {
+		batch, _ = c.Get("nextBatch").(*wirebson.Array)
+	}
+
+	if batch == nil {
+		return page, nil
+	}
+
+	var modified bool
+
+	for v := range batch.Values() {
+		collection, ok := v.(*wirebson.Document)
+		if !ok {
+			continue
+		}
+
+		name, _ := collection.Get("name").(string)
+
+		limits, err := h.p.Capped(ctx, dbName, name)
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		ts, err := h.p.TimeSeries(ctx, dbName, name)
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		clustered, err := h.p.Clustered(ctx, dbName, name)
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		if limits == nil && ts == nil && clustered == nil {
+			continue
+		}
+
+		opts, _ := collection.Get("options").(*wirebson.Document)
+		if opts == nil {
+			opts = wirebson.MakeDocument(3)
+			must.NoError(collection.Add("options", opts))
+		}
+
+		if limits != nil {
+			must.NoError(opts.Add("capped", true))
+			must.NoError(opts.Add("size", limits.Size))
+
+			if limits.Max > 0 {
+				must.NoError(opts.Add("max", limits.Max))
+			}
+		}
+
+		if ts != nil {
+			must.NoError(collection.Replace("type", "timeseries"))
+
+			tsOpts := wirebson.MustDocument("timeField", ts.TimeField)
+			if ts.MetaField != "" {
+				must.NoError(tsOpts.Add("metaField", ts.MetaField))
+			}
+
+			must.NoError(tsOpts.Add("granularity", ts.Granularity))
+			must.NoError(tsOpts.Add("bucketMaxSpanSeconds", timeseriesBucketMaxSpanSeconds[ts.Granularity]))
+
+			must.NoError(opts.Add("timeseries", tsOpts))
+
+			if ts.ExpireAfterSeconds > 0 {
+				must.NoError(opts.Add("expireAfterSeconds", ts.ExpireAfterSeconds))
+			}
+		}
+
+		if clustered != nil {
+			// like MongoDB, do not return a separate _id index for clustered collections
+			collection.Remove("idIndex")
+
+			must.NoError(opts.Add("clusteredIndex", clusteredIndexSpec(clustered, false)))
+
+			if clustered.ExpireAfterSeconds > 0 {
+				must.NoError(opts.Add("expireAfterSeconds", clustered.ExpireAfterSeconds))
+			}
+		}
+
+		modified = true
+	}
+
+	if !modified {
+		return page, nil
+	}
+
+	return pageDoc, nil
 }
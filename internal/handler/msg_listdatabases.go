@@ -38,7 +38,7 @@ func (h *Handler) msgListDatabases(connCtx context.Context, req *middleware.Requ
 	var res wirebson.RawDocument
 
 	var err error
-	err = h.p.WithConn(func(conn *pgx.Conn) error {
+	err = h.p.WithConn(connCtx, func(conn *pgx.Conn) error {
 		// TODO https://github.com/FerretDB/FerretDB/issues/4862
 		// TODO https://github.com/documentdb/documentdb/issues/121
 		res, err = documentdb_api.ListDatabases(connCtx, conn, h.L, req.DocumentRaw())
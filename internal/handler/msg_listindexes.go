@@ -18,8 +18,11 @@
 	"context"
 
 	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
 
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb"
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
 )
 
 // msgListIndexes implements `listIndexes` command.
@@ -45,5 +48,73 @@ func (h *Handler) msgListIndexes(connCtx context.Context, req *middleware.Reques
 
 	h.s.AddCursor(connCtx, userID, sessionID, cursorID)
 
-	return middleware.ResponseDoc(req, page)
+	collectionName, _ := doc.Get(doc.Command()).(string)
+
+	clustered, err := h.p.Clustered(connCtx, dbName, collectionName)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if clustered == nil {
+		return middleware.ResponseDoc(req, page)
+	}
+
+	res, err := replaceIDIndex(page, clustered)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return middleware.ResponseDoc(req, res)
+}
+
+// replaceIDIndex replaces _id index in the first page of `listIndexes` cursor
+// with the clustered index, as DocumentDB does not know about it.
+//
+// The _id index is always the first one, so subsequent pages are not modified.
+func replaceIDIndex(page wirebson.RawDocument, clustered *documentdb.ClusteredCollection) (wirebson.AnyDocument, error) {
+	pageDoc, err := page.DecodeDeep()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	c, _ := pageDoc.Get("cursor").(*wirebson.Document)
+	if c == nil {
+		return page, nil
+	}
+
+	firstBatch, _ := c.Get("firstBatch").(*wirebson.Array)
+	if firstBatch == nil {
+		return page, nil
+	}
+
+	for i, v := range firstBatch.All() {
+		index, ok := v.(*wirebson.Document)
+		if !ok {
+			continue
+		}
+
+		if name, _ := index.Get("name").(string); name == "_id_" {
+			must.NoError(firstBatch.Replace(i, clusteredIndexSpec(clustered, true)))
+			break
+		}
+	}
+
+	return pageDoc, nil
+}
+
+// clusteredIndexSpec returns a specification of the clustered index
+// for `listIndexes` (with `clustered` field) or `listCollections` (without it).
+func clusteredIndexSpec(clustered *documentdb.ClusteredCollection, forListIndexes bool) *wirebson.Document {
+	res := wirebson.MustDocument(
+		"v", int32(2),
+		"key", wirebson.MustDocument("_id", int32(1)),
+		"name", clustered.Name,
+		"unique", true,
+	)
+
+	if forListIndexes {
+		must.NoError(res.Add("clustered", true))
+	}
+
+	return res
 }
@@ -0,0 +1,143 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+)
+
+// msgProfile implements `profile` command.
+//
+// The passed context is canceled when the client connection is closed.
+func (h *Handler) msgProfile(connCtx context.Context, req *middleware.Request) (*middleware.Response, error) {
+	doc := req.Document()
+
+	if _, _, err := h.s.CreateOrUpdateByLSID(connCtx, doc); err != nil {
+		return nil, err
+	}
+
+	dbName, err := getRequiredParam[string](doc, "$db")
+	if err != nil {
+		return nil, err
+	}
+
+	command := doc.Command()
+
+	level, err := getProfileNumber(doc, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if level != math.Trunc(level) || level < -1 || level > 2 {
+		return nil, mongoerrors.NewWithArgument(
+			mongoerrors.ErrBadValue,
+			fmt.Sprintf("Invalid profiling level: %v", level),
+			command,
+		)
+	}
+
+	if doc.Get("filter") != nil {
+		return nil, mongoerrors.NewWithArgument(
+			mongoerrors.ErrNotImplemented,
+			"profile filter is not supported",
+			"filter",
+		)
+	}
+
+	var slowMS, sampleRate *float64
+
+	if doc.Get("slowms") != nil {
+		var v float64
+		if v, err = getProfileNumber(doc, "slowms"); err != nil {
+			return nil, err
+		}
+
+		v = math.Trunc(max(min(v, math.MaxInt32), math.MinInt32))
+		slowMS = &v
+	}
+
+	if doc.Get("sampleRate") != nil {
+		var v float64
+		if v, err = getProfileNumber(doc, "sampleRate"); err != nil {
+			return nil, err
+		}
+
+		if v < 0 || v > 1 {
+			return nil, mongoerrors.NewWithArgument(
+				mongoerrors.ErrBadValue,
+				"'sampleRate' must be between 0.0 and 1.0 inclusive",
+				"sampleRate",
+			)
+		}
+
+		sampleRate = &v
+	}
+
+	was := h.prof.level(dbName)
+	wasSlowMS := h.prof.slowMS.Load()
+	wasSampleRate := math.Float64frombits(h.prof.sampleRate.Load())
+
+	if level >= 0 {
+		h.prof.setLevel(dbName, int32(level))
+	}
+
+	if slowMS != nil {
+		h.prof.slowMS.Store(int32(*slowMS))
+	}
+
+	if sampleRate != nil {
+		h.prof.sampleRate.Store(math.Float64bits(*sampleRate))
+	}
+
+	if level >= 0 || slowMS != nil || sampleRate != nil {
+		h.L.InfoContext(
+			connCtx, "Profiler settings changed",
+			slog.String("db", dbName), slog.Int("level", int(h.prof.level(dbName))),
+			slog.Int("slowms", int(h.prof.slowMS.Load())),
+			slog.Float64("sampleRate", math.Float64frombits(h.prof.sampleRate.Load())),
+		)
+	}
+
+	return middleware.ResponseDoc(req, wirebson.MustDocument(
+		"was", was,
+		"slowms", wasSlowMS,
+		"sampleRate", wasSampleRate,
+		"ok", float64(1),
+	))
+}
+
+// getProfileNumber returns the numeric value of the given `profile` command's field.
+func getProfileNumber(doc *wirebson.Document, key string) (float64, error) {
+	v := doc.Get(key)
+
+	if n, ok := currentOpNumber(v); ok {
+		return n, nil
+	}
+
+	msg := fmt.Sprintf(
+		"BSON field 'profile.%s' is the wrong type '%s', expected types '[long, int, decimal, double]'",
+		key, aliasFromType(v),
+	)
+
+	return 0, mongoerrors.NewWithArgument(mongoerrors.ErrTypeMismatch, msg, key)
+}
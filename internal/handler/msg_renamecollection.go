@@ -124,7 +124,7 @@ func (h *Handler) msgRenameCollection(connCtx context.Context, req *middleware.R
 		)
 	}
 
-	conn, err := h.p.Acquire()
+	conn, err := h.p.Acquire(connCtx)
 	if err != nil {
 		return nil, lazyerrors.Error(err)
 	}
@@ -136,6 +136,12 @@ func (h *Handler) msgRenameCollection(connCtx context.Context, req *middleware.R
 		return nil, lazyerrors.Error(err)
 	}
 
+	conn.Release()
+
+	if err = h.p.RenameMetadata(connCtx, oldDBName, oldCName, newCName); err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
 	return middleware.ResponseDoc(req, wirebson.MustDocument(
 		"ok", float64(1),
 	))
@@ -19,7 +19,10 @@
 	"maps"
 	"os"
 	"path/filepath"
+	"runtime"
 	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/AlekSi/lazyerrors"
@@ -52,6 +55,11 @@ func (h *Handler) msgServerStatus(connCtx context.Context, req *middleware.Reque
 
 	metricsDoc := wirebson.MustDocument()
 
+	// counters of operations by their types, as in MongoDB
+	opcounters := map[string]int64{}
+
+	var userAsserts int64
+
 	metrics := h.Metrics.GetResponses()
 	for _, commands := range metrics {
 		for command, arguments := range commands {
@@ -66,6 +74,9 @@ func (h *Handler) msgServerStatus(connCtx context.Context, req *middleware.Reque
 
 			d := wirebson.MustDocument("total", int64(total), "failed", int64(failed))
 			must.NoError(metricsDoc.Add(command, d))
+
+			opcounters[opcounterType(command)] += int64(total)
+			userAsserts += int64(failed)
 		}
 	}
 
@@ -76,9 +87,43 @@ func (h *Handler) msgServerStatus(connCtx context.Context, req *middleware.Reque
 		must.NoError(buildEnvironment.Add(k, info.BuildEnvironment[k]))
 	}
 
+	catalog, err := h.p.CatalogStats(connCtx)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	timeseries, err := h.p.CountTimeSeries(connCtx)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	clustered, err := h.p.CountClustered(connCtx)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
 	state := h.StateProvider.Get()
 	uptime := time.Since(state.Start)
 
+	conns := h.Metrics.GetConnections()
+	network := h.Metrics.GetNetwork()
+	pool := h.p.Stats()
+
+	connections := wirebson.MustDocument(
+		"current", int32(conns.Current),
+	)
+
+	// MongoDB always reports available connections, but there is no implicit limit in FerretDB
+	if conns.Max > 0 {
+		must.NoError(connections.Add("available", int32(max(conns.Max-conns.Current, 0))))
+	}
+
+	must.NoError(connections.Add("totalCreated", int32(conns.TotalCreated)))
+	must.NoError(connections.Add("active", int32(conns.Active)))
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
 	res := wirebson.MustDocument(
 		"host", host,
 		"version", info.MongoDBVersion,
@@ -91,16 +136,68 @@ func (h *Handler) msgServerStatus(connCtx context.Context, req *middleware.Reque
 		"freeMonitoring", wirebson.MustDocument(
 			"state", state.TelemetryString(),
 		),
+		"asserts", wirebson.MustDocument(
+			"regular", int32(0),
+			"warning", int32(0),
+			"msg", int32(0),
+			"user", int32(userAsserts),
+			"tripwire", int32(0),
+			"rollovers", int32(0),
+		),
+		"connections", connections,
+		"globalLock", wirebson.MustDocument(
+			"totalTime", uptime.Microseconds(),
+			"currentQueue", wirebson.MustDocument(
+				"total", int32(0),
+				"readers", int32(0),
+				"writers", int32(0),
+			),
+			"activeClients", wirebson.MustDocument(
+				"total", int32(conns.Active),
+				"readers", int32(0),
+				"writers", int32(0),
+			),
+		),
+		"mem", wirebson.MustDocument(
+			"bits", int32(strconv.IntSize),
+
+			// estimated by Go runtime, in MiB
+			"resident", int32((mem.Sys-mem.HeapReleased)>>20),
+			"virtual", int32(mem.Sys>>20),
+			"supported", true,
+		),
+		"network", wirebson.MustDocument(
+			"bytesIn", network.BytesIn,
+			"bytesOut", network.BytesOut,
+			"physicalBytesIn", network.BytesIn,
+			"physicalBytesOut", network.BytesOut,
+			"numSlowDNSOperations", int64(0),
+			"numSlowSSLOperations", int64(0),
+			"numRequests", network.NumRequests,
+		),
+		"opcounters", wirebson.MustDocument(
+			"insert", opcounters["insert"],
+			"query", opcounters["query"],
+			"update", opcounters["update"],
+			"delete", opcounters["delete"],
+			"getmore", opcounters["getmore"],
+			"command", opcounters["command"],
+		),
 		"metrics", wirebson.MustDocument(
 			"commands", metricsDoc,
+			"ttl", wirebson.MustDocument(
+				"deletedDocuments", h.ttl.deletedDocuments.Load(),
+				"passes", h.ttl.passes.Load(),
+				"subPasses", h.ttl.subPasses.Load(),
+			),
 		),
 		"catalogStats", wirebson.MustDocument(
-			"collections", int32(0),
-			"clustered", int32(0),
-			"timeseries", int32(0),
-			"views", int32(0),
-			"internalCollections", int32(0),
-			"internalViews", int32(0),
+			"collections", catalog.Collections,
+			"clustered", int32(clustered),
+			"timeseries", int32(timeseries),
+			"views", catalog.Views,
+			"internalCollections", catalog.InternalCollections,
+			"internalViews", catalog.InternalViews,
 		),
 
 		// our extensions for easier bug reporting
@@ -112,10 +209,64 @@ func (h *Handler) msgServerStatus(connCtx context.Context, req *middleware.Reque
 			"package", info.Package,
 			"postgresql", state.PostgreSQLVersion,
 			"documentdb", state.DocumentDBVersion,
+			"pool", wirebson.MustDocument(
+				"total", pool.Total,
+				"acquired", pool.Acquired,
+				"idle", pool.Idle,
+				"constructing", pool.Constructing,
+				"max", pool.Max,
+				"acquires", pool.Acquires,
+				"emptyAcquires", pool.EmptyAcquires,
+				"canceledAcquires", pool.CanceledAcquires,
+				"acquireDurationMillis", pool.AcquireDuration.Milliseconds(),
+			),
 		),
 
 		"ok", float64(1),
 	)
 
+	// sections could be excluded like in MongoDB
+	for k, v := range doc.All() {
+		if k == doc.Command() || strings.HasPrefix(k, "$") {
+			continue
+		}
+
+		if !isFalsy(v) {
+			continue
+		}
+
+		res.Remove(k)
+	}
+
 	return middleware.ResponseDoc(req, res)
 }
+
+// opcounterType returns the type of operation for `opcounters` section of `serverStatus`.
+func opcounterType(command string) string {
+	switch command {
+	case "insert", "update", "delete":
+		return command
+	case "find":
+		return "query"
+	case "getMore":
+		return "getmore"
+	default:
+		return "command"
+	}
+}
+
+// isFalsy returns true if the given value is false or zero number.
+func isFalsy(v any) bool {
+	switch v := v.(type) {
+	case bool:
+		return !v
+	case int32:
+		return v == 0
+	case int64:
+		return v == 0
+	case float64:
+		return v == 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// msgSetParameter implements `setParameter` command.
+//
+// Only privileged users can run it.
+// All values are validated before any of them is applied.
+// All changes are logged with the user and client that made them.
+//
+// The passed context is canceled when the client connection is closed.
+func (h *Handler) msgSetParameter(connCtx context.Context, req *middleware.Request) (*middleware.Response, error) {
+	doc := req.Document()
+
+	if _, _, err := h.s.CreateOrUpdateByLSID(connCtx, doc); err != nil {
+		return nil, err
+	}
+
+	dbName, err := getRequiredParam[string](doc, "$db")
+	if err != nil {
+		return nil, err
+	}
+
+	if dbName != "admin" {
+		return nil, mongoerrors.NewWithArgument(
+			mongoerrors.ErrUnauthorized,
+			doc.Command()+" may only be run against the admin database.",
+			doc.Command(),
+		)
+	}
+
+	privileged, err := h.privileged(connCtx)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if !privileged {
+		return nil, mongoerrors.NewWithArgument(
+			mongoerrors.ErrUnauthorized,
+			"not authorized on admin to execute command "+doc.Command(),
+			doc.Command(),
+		)
+	}
+
+	type change struct {
+		name  string
+		apply func() any
+	}
+
+	var changes []change
+
+	for k, v := range doc.All() {
+		switch {
+		case k == doc.Command(), k == "lsid", k == "comment", strings.HasPrefix(k, "$"):
+			continue
+		}
+
+		p := h.params[k]
+		if p == nil {
+			return nil, mongoerrors.NewWithArgument(
+				mongoerrors.ErrInvalidOptions,
+				fmt.Sprintf("attempted to set unrecognized parameter [%s], use help:true to see options ", k),
+				doc.Command(),
+			)
+		}
+
+		// validate all values before applying any of them
+		apply, err := p.prepare(k, v)
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, change{name: k, apply: apply})
+	}
+
+	if len(changes) < 1 {
+		return nil, mongoerrors.NewWithArgument(
+			mongoerrors.ErrInvalidOptions,
+			"no option found to set, use help:true to see options ",
+			doc.Command(),
+		)
+	}
+
+	var client string
+	if peer := conninfo.Get(connCtx).Peer; peer.IsValid() {
+		client = peer.String()
+	}
+
+	user := conninfo.Get(connCtx).Conv().Username()
+
+	res := wirebson.MustDocument()
+
+	for i, c := range changes {
+		was := c.apply()
+
+		h.L.InfoContext(
+			connCtx, "Parameter changed",
+			slog.String("parameter", c.name), slog.Any("was", was), slog.Any("value", h.params[c.name].value()),
+			slog.String("user", user), slog.String("client", client),
+		)
+
+		// MongoDB returns the previous value of the first parameter only
+		if i == 0 {
+			must.NoError(res.Add("was", was))
+		}
+	}
+
+	must.NoError(res.Add("ok", float64(1)))
+
+	return middleware.ResponseDoc(req, res)
+}
@@ -0,0 +1,91 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// msgTop implements `top` command.
+//
+// Counters are collected by the middleware for all handled requests, including proxied ones.
+//
+// The passed context is canceled when the client connection is closed.
+func (h *Handler) msgTop(connCtx context.Context, req *middleware.Request) (*middleware.Response, error) {
+	doc := req.Document()
+
+	if _, _, err := h.s.CreateOrUpdateByLSID(connCtx, doc); err != nil {
+		return nil, err
+	}
+
+	dbName, err := getRequiredParam[string](doc, "$db")
+	if err != nil {
+		return nil, err
+	}
+
+	if dbName != "admin" {
+		return nil, mongoerrors.NewWithArgument(
+			mongoerrors.ErrUnauthorized,
+			doc.Command()+" may only be run against the admin database.",
+			doc.Command(),
+		)
+	}
+
+	top := h.Metrics.GetTop()
+
+	namespaces := slices.SortedFunc(maps.Keys(top), func(a, b middleware.TopNamespace) int {
+		return cmp.Or(cmp.Compare(a.DB, b.DB), cmp.Compare(a.Collection, b.Collection))
+	})
+
+	totals := wirebson.MustDocument("note", "all times in microseconds")
+
+	for _, ns := range namespaces {
+		s := top[ns]
+
+		must.NoError(totals.Add(ns.DB+"."+ns.Collection, wirebson.MustDocument(
+			"total", topCounter(s.Total),
+			"readLock", topCounter(s.ReadLock),
+			"writeLock", topCounter(s.WriteLock),
+			"queries", topCounter(s.Queries),
+			"getmore", topCounter(s.GetMore),
+			"insert", topCounter(s.Insert),
+			"update", topCounter(s.Update),
+			"remove", topCounter(s.Remove),
+			"commands", topCounter(s.Commands),
+		)))
+	}
+
+	return middleware.ResponseDoc(req, wirebson.MustDocument(
+		"totals", totals,
+		"ok", float64(1),
+	))
+}
+
+// topCounter returns a `top` command document for the given counter.
+func topCounter(c middleware.TopCounter) *wirebson.Document {
+	return wirebson.MustDocument(
+		"time", c.Time.Microseconds(),
+		"count", c.Count,
+	)
+}
@@ -45,11 +45,27 @@ func (h *Handler) msgUpdate(connCtx context.Context, req *middleware.Request) (*
 		return nil, err
 	}
 
+	collectionName, _ := doc.Get(doc.Command()).(string)
+
+	statements, err := writeStatements(doc, "updates", seq)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if err = checkStatementsCollation(doc.Command(), "updates", statements); err != nil {
+		return nil, err
+	}
+
 	var res wirebson.RawDocument
 
-	err = h.p.WithConn(func(conn *pgx.Conn) error {
-		res, _, err = documentdb_api.Update(connCtx, conn, h.L, dbName, spec, seq)
-		return err
+	filters := writeFilters(doc, statements)
+
+	err = h.p.WithWriteConn(connCtx, dbName, collectionName, filters, func(conn *pgx.Conn) ([]any, error) {
+		if res, _, err = documentdb_api.Update(connCtx, conn, h.L, dbName, spec, seq); err != nil {
+			return nil, err
+		}
+
+		return upsertedIDs(res)
 	})
 	if err != nil {
 		return nil, lazyerrors.Error(err)
@@ -38,7 +38,7 @@ func (h *Handler) msgUsersInfo(connCtx context.Context, req *middleware.Request)
 	var res wirebson.RawDocument
 
 	var err error
-	err = h.p.WithConn(func(conn *pgx.Conn) error {
+	err = h.p.WithConn(connCtx, func(conn *pgx.Conn) error {
 		res, err = documentdb_api.UsersInfo(connCtx, conn, h.L, req.DocumentRaw())
 		return err
 	})
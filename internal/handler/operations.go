@@ -0,0 +1,123 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb"
+)
+
+// operation represents an in-progress client operation.
+type operation struct {
+	start   time.Time
+	cancel  context.CancelFunc
+	command *wirebson.Document
+	client  string // empty for Unix domain sockets
+	user    string // empty if authentication is disabled or not done
+	opID    int32
+
+	// killed is set by `killOp` command.
+	killed atomic.Bool
+
+	// query is the currently running PostgreSQL query.
+	query documentdb.RunningQuery
+}
+
+// operations tracks in-progress client operations for `currentOp` and `killOp` commands.
+type operations struct {
+	rw     sync.RWMutex
+	ops    map[int32]*operation
+	lastID int32
+}
+
+// newOperations creates a new operations registry.
+func newOperations() *operations {
+	return &operations{
+		ops: map[int32]*operation{},
+	}
+}
+
+// start registers a new operation for the given command.
+//
+// It returns a derived context that is canceled by [operation.kill],
+// and a function that should be called when the operation is finished.
+func (o *operations) start(ctx context.Context, command *wirebson.Document, client, user string) (context.Context, *operation, func()) { //nolint:lll // for readability
+	ctx, cancel := context.WithCancel(ctx)
+
+	op := &operation{
+		start:   time.Now(),
+		cancel:  cancel,
+		command: command,
+		client:  client,
+		user:    user,
+	}
+
+	ctx = documentdb.WithRunningQuery(ctx, &op.query)
+
+	o.rw.Lock()
+
+	o.lastID++
+	if o.lastID <= 0 {
+		o.lastID = 1
+	}
+
+	op.opID = o.lastID
+	o.ops[op.opID] = op
+
+	o.rw.Unlock()
+
+	finish := func() {
+		o.rw.Lock()
+		delete(o.ops, op.opID)
+		o.rw.Unlock()
+
+		cancel()
+	}
+
+	return ctx, op, finish
+}
+
+// get returns the operation with the given ID, or nil if it does not exist.
+func (o *operations) get(opID int32) *operation {
+	o.rw.RLock()
+	defer o.rw.RUnlock()
+
+	return o.ops[opID]
+}
+
+// kill marks the operation as killed and cancels its context.
+func (op *operation) kill() {
+	op.killed.Store(true)
+	op.cancel()
+}
+
+// all returns all in-progress operations sorted by ID.
+func (o *operations) all() []*operation {
+	o.rw.RLock()
+	defer o.rw.RUnlock()
+
+	return slices.SortedFunc(maps.Values(o.ops), func(a, b *operation) int {
+		return cmp.Compare(a.opID, b.opID)
+	})
+}
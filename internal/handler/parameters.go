@@ -0,0 +1,369 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"cmp"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/handler/session"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// defaultCursorTimeout is the default value of `cursorTimeoutMillis` parameter, the same as in MongoDB.
+const defaultCursorTimeout = 10 * time.Minute
+
+// serverParameter is a server parameter available via `getParameter`, `setParameter`, and `getCmdLineOpts` commands.
+type serverParameter interface {
+	// value returns the current value.
+	value() any
+
+	// startupValue returns the value at startup.
+	startupValue() any
+
+	// settableAtRuntime returns true if the parameter could be changed with `setParameter`.
+	settableAtRuntime() bool
+
+	// settableAtStartup returns true if the parameter could be set with a flag or PostgreSQL URL.
+	settableAtStartup() bool
+
+	// prepare validates the new value of the parameter with the given name.
+	// It returns a function that applies it and returns the previous value.
+	prepare(name string, v any) (func() any, error)
+}
+
+// parameter is a [serverParameter] with a value of type T.
+// T should be a BSON type.
+type parameter[T any] struct {
+	get func() T
+
+	// set and parse are nil for parameters that are not settable at runtime
+	set   func(T)
+	parse func(name string, v any) (T, error)
+
+	startup   T
+	atStartup bool
+}
+
+// newParameter creates a parameter that is not settable at runtime.
+func newParameter[T any](get func() T, atStartup bool) *parameter[T] {
+	return &parameter[T]{
+		get:       get,
+		startup:   get(),
+		atStartup: atStartup,
+	}
+}
+
+// newSettableParameter creates a parameter that is settable at runtime.
+func newSettableParameter[T any](get func() T, set func(T), parse func(string, any) (T, error), atStartup bool) *parameter[T] {
+	return &parameter[T]{
+		get:       get,
+		set:       set,
+		parse:     parse,
+		startup:   get(),
+		atStartup: atStartup,
+	}
+}
+
+// value implements [serverParameter].
+func (p *parameter[T]) value() any {
+	return p.get()
+}
+
+// startupValue implements [serverParameter].
+func (p *parameter[T]) startupValue() any {
+	return p.startup
+}
+
+// settableAtRuntime implements [serverParameter].
+func (p *parameter[T]) settableAtRuntime() bool {
+	return p.set != nil
+}
+
+// settableAtStartup implements [serverParameter].
+func (p *parameter[T]) settableAtStartup() bool {
+	return p.atStartup
+}
+
+// prepare implements [serverParameter].
+func (p *parameter[T]) prepare(name string, v any) (func() any, error) {
+	if p.set == nil {
+		return nil, mongoerrors.NewWithArgument(
+			mongoerrors.ErrBadValue,
+			fmt.Sprintf("not allowed to change [%s] at runtime", name),
+			name,
+		)
+	}
+
+	val, err := p.parse(name, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() any {
+		was := p.get()
+		p.set(val)
+
+		return was
+	}, nil
+}
+
+// parseNumberParameter returns a whole number value for a parameter with the given name
+// that should be in the given range.
+func parseNumberParameter(name string, v any, minValue, maxValue int64) (int64, error) {
+	n, ok := currentOpNumber(v)
+	if !ok || n != float64(int64(n)) {
+		return 0, mongoerrors.NewWithArgument(
+			mongoerrors.ErrBadValue,
+			fmt.Sprintf("Invalid value for parameter %s: %v is not a whole number", name, v),
+			name,
+		)
+	}
+
+	if res := int64(n); res >= minValue && res <= maxValue {
+		return res, nil
+	}
+
+	return 0, mongoerrors.NewWithArgument(
+		mongoerrors.ErrBadValue,
+		fmt.Sprintf("Invalid value for parameter %s: %v is not in range [%d, %d]", name, v, minValue, maxValue),
+		name,
+	)
+}
+
+// parseMillisParameter returns a non-negative duration value in milliseconds.
+func parseMillisParameter(name string, v any) (time.Duration, error) {
+	ms, err := parseNumberParameter(name, v, 0, int64(time.Duration(1<<63-1)/time.Millisecond))
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// logVerbosity converts slog level to MongoDB-like verbosity level:
+// 0 for INFO, 1 for DEBUG, negative values for WARN and ERROR.
+func logVerbosity(l slog.Level) int32 {
+	return int32(slog.LevelInfo-l) / 4
+}
+
+// logLevel converts MongoDB-like verbosity level to slog level; see [logVerbosity].
+func logLevel(v int32) slog.Level {
+	return slog.LevelInfo - slog.Level(v*4)
+}
+
+// getLogComponentVerbosity returns the value of `logComponentVerbosity` parameter:
+// the global verbosity level and verbosity levels of all logging components.
+// Components that use the global level have verbosity -1, like in MongoDB.
+func getLogComponentVerbosity(lh *logging.Handler) *wirebson.Document {
+	levels := lh.ComponentLevels()
+
+	res := wirebson.MustDocument("verbosity", logVerbosity(lh.Level()))
+
+	for _, c := range logging.Components() {
+		v := int32(-1)
+		if l, ok := levels[c]; ok {
+			v = logVerbosity(l)
+		}
+
+		must.NoError(res.Add(c, wirebson.MustDocument("verbosity", v)))
+	}
+
+	return res
+}
+
+// parseLogComponentVerbosity validates the new value of `logComponentVerbosity` parameter.
+// It returns a document with only given verbosity levels.
+func parseLogComponentVerbosity(name string, v any) (*wirebson.Document, error) {
+	ad, ok := v.(wirebson.AnyDocument)
+	if !ok {
+		return nil, mongoerrors.NewWithArgument(
+			mongoerrors.ErrBadValue,
+			fmt.Sprintf("Invalid value for parameter %s: %v is not a document", name, v),
+			name,
+		)
+	}
+
+	doc, err := ad.Decode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	res := wirebson.MakeDocument(doc.Len())
+
+	for c, cv := range doc.All() {
+		if c == "verbosity" {
+			n, err := parseNumberParameter(name+".verbosity", cv, -2, 5)
+			if err != nil {
+				return nil, err
+			}
+
+			must.NoError(res.Add(c, int32(n)))
+
+			continue
+		}
+
+		if !slices.Contains(logging.Components(), c) {
+			return nil, mongoerrors.NewWithArgument(
+				mongoerrors.ErrBadValue,
+				fmt.Sprintf("Invalid component name %s.%s", name, c),
+				name,
+			)
+		}
+
+		var cd *wirebson.Document
+
+		if cad, ok := cv.(wirebson.AnyDocument); ok {
+			if cd, err = cad.Decode(); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+		}
+
+		if cd == nil || cd.Len() != 1 || cd.Get("verbosity") == nil {
+			return nil, mongoerrors.NewWithArgument(
+				mongoerrors.ErrBadValue,
+				fmt.Sprintf("Invalid value for parameter %s.%s: expected {verbosity: <number>}", name, c),
+				name,
+			)
+		}
+
+		// -1 means that the component uses the global level
+		n, err := parseNumberParameter(name+"."+c+".verbosity", cd.Get("verbosity"), -1, 5)
+		if err != nil {
+			return nil, err
+		}
+
+		must.NoError(res.Add(c, wirebson.MustDocument("verbosity", int32(n))))
+	}
+
+	return res, nil
+}
+
+// setLogComponentVerbosity applies the value returned by [parseLogComponentVerbosity].
+func setLogComponentVerbosity(lh *logging.Handler, doc *wirebson.Document) {
+	for c, cv := range doc.All() {
+		if c == "verbosity" {
+			lh.SetLevel(logLevel(cv.(int32)))
+			continue
+		}
+
+		var l slog.Leveler
+		if v := cv.(*wirebson.Document).Get("verbosity").(int32); v >= 0 {
+			l = logLevel(v)
+		}
+
+		must.NoError(lh.SetComponentLevel(c, l))
+	}
+}
+
+// initParameters initializes server parameters.
+func (h *Handler) initParameters() {
+	lh := h.L.Handler().(*logging.Handler)
+	pool := h.p.Stats()
+
+	h.params = map[string]serverParameter{
+		"admissionMaxRequests": newParameter(func() int32 {
+			return int32(cap(h.adm.global))
+		}, true),
+		"admissionMaxUserRequests": newParameter(func() int32 {
+			return int32(h.adm.perUser)
+		}, true),
+		"admissionMaxWaitMillis": newSettableParameter(
+			func() int64 { return h.adm.getMaxWait().Milliseconds() },
+			func(v int64) { h.adm.setMaxWait(time.Duration(v) * time.Millisecond) },
+			func(name string, v any) (int64, error) {
+				d, err := parseMillisParameter(name, v)
+				return d.Milliseconds(), err
+			},
+			true,
+		),
+		"authenticationMechanisms": newParameter(func() *wirebson.Array {
+			return wirebson.MustArray("SCRAM-SHA-1", "SCRAM-SHA-256")
+		}, true),
+		// stored for compatibility only
+		"authSchemaVersion": newSettableParameter(
+			h.authSchemaVersion.Load,
+			h.authSchemaVersion.Store,
+			func(name string, v any) (int32, error) {
+				n, err := parseNumberParameter(name, v, 1, 5)
+				return int32(n), err
+			},
+			true,
+		),
+		"cursorTimeoutMillis": newSettableParameter(
+			func() int64 { return time.Duration(h.cursorTimeout.Load()).Milliseconds() },
+			func(v int64) { h.cursorTimeout.Store(int64(time.Duration(v) * time.Millisecond)) },
+			func(name string, v any) (int64, error) {
+				d, err := parseMillisParameter(name, v)
+				return d.Milliseconds(), err
+			},
+			false,
+		),
+		"featureCompatibilityVersion": newParameter(func() *wirebson.Document {
+			// TODO https://github.com/FerretDB/FerretDB/issues/5073
+			return wirebson.MustDocument("version", "7.0")
+		}, false),
+		"localLogicalSessionTimeoutMinutes": newParameter(func() int32 {
+			return session.LogicalSessionTimeoutMinutes
+		}, false),
+		"logComponentVerbosity": newSettableParameter(
+			func() *wirebson.Document { return getLogComponentVerbosity(lh) },
+			func(v *wirebson.Document) { setLogComponentVerbosity(lh, v) },
+			parseLogComponentVerbosity,
+			false,
+		),
+		"logLevel": newSettableParameter(
+			func() int32 { return logVerbosity(lh.Level()) },
+			func(v int32) { lh.SetLevel(logLevel(v)) },
+			func(name string, v any) (int32, error) {
+				n, err := parseNumberParameter(name, v, -2, 5)
+				return int32(n), err
+			},
+			true,
+		),
+		"postgreSQLPoolMaxConns": newParameter(func() int32 {
+			return pool.Max
+		}, true),
+		// stored for compatibility only
+		"quiet":    newSettableParameter(h.quiet.Load, h.quiet.Store, getBoolParam, true),
+		"readOnly": newSettableParameter(h.readOnly.Load, h.readOnly.Store, getBoolParam, true),
+		"slowOpThresholdMs": newSettableParameter(
+			func() int64 { return time.Duration(h.slowOpThreshold.Load()).Milliseconds() },
+			func(v int64) { h.slowOpThreshold.Store(int64(time.Duration(v) * time.Millisecond)) },
+			func(name string, v any) (int64, error) {
+				d, err := parseMillisParameter(name, v)
+				return d.Milliseconds(), err
+			},
+			true,
+		),
+	}
+}
+
+// parameterNames returns names of all server parameters in case-insensitive alphabetical order.
+func (h *Handler) parameterNames() []string {
+	return slices.SortedFunc(maps.Keys(h.params), func(a, b string) int {
+		return cmp.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+}
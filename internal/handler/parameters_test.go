@@ -0,0 +1,132 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+)
+
+func TestLogVerbosity(t *testing.T) {
+	t.Parallel()
+
+	for l, v := range map[slog.Level]int32{
+		slog.LevelDebug: 1,
+		slog.LevelInfo:  0,
+		slog.LevelWarn:  -1,
+		slog.LevelError: -2,
+	} {
+		assert.Equal(t, v, logVerbosity(l), "%s", l)
+		assert.Equal(t, l, logLevel(v), "%d", v)
+	}
+}
+
+func TestParameter(t *testing.T) {
+	t.Parallel()
+
+	var v atomic.Int64
+	v.Store(42)
+
+	p := newSettableParameter(v.Load, v.Store, func(name string, v any) (int64, error) {
+		return parseNumberParameter(name, v, 0, 100)
+	}, false)
+
+	assert.True(t, p.settableAtRuntime())
+	assert.False(t, p.settableAtStartup())
+
+	_, err := p.prepare("p", int32(101))
+	var mErr *mongoerrors.Error
+	require.ErrorAs(t, err, &mErr)
+	assert.Equal(t, int32(mongoerrors.ErrBadValue), mErr.Code)
+
+	_, err = p.prepare("p", 1.5)
+	require.ErrorAs(t, err, &mErr)
+	assert.Equal(t, int32(mongoerrors.ErrBadValue), mErr.Code)
+
+	apply, err := p.prepare("p", float64(7))
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), p.value(), "value should not change before apply")
+
+	assert.Equal(t, int64(42), apply())
+	assert.Equal(t, int64(7), p.value())
+	assert.Equal(t, int64(42), p.startupValue())
+
+	ro := newParameter(v.Load, true)
+	assert.False(t, ro.settableAtRuntime())
+
+	_, err = ro.prepare("ro", int64(1))
+	require.ErrorAs(t, err, &mErr)
+	assert.Equal(t, "not allowed to change [ro] at runtime", mErr.Message)
+}
+
+func TestLogComponentVerbosity(t *testing.T) {
+	t.Parallel()
+
+	lh := logging.NewHandler(io.Discard, &logging.NewHandlerOpts{
+		Base:  "console",
+		Level: slog.LevelInfo,
+	})
+
+	p := newSettableParameter(
+		func() *wirebson.Document { return getLogComponentVerbosity(lh) },
+		func(v *wirebson.Document) { setLogComponentVerbosity(lh, v) },
+		parseLogComponentVerbosity,
+		false,
+	)
+
+	for name, v := range map[string]any{
+		"NotDocument":      int32(1),
+		"UnknownComponent": wirebson.MustDocument("unknown", wirebson.MustDocument("verbosity", int32(1))),
+		"NotVerbosity":     wirebson.MustDocument("pgx", int32(1)),
+		"OutOfRange":       wirebson.MustDocument("pgx", wirebson.MustDocument("verbosity", int32(-2))),
+		"GlobalOutOfRange": wirebson.MustDocument("verbosity", int32(6)),
+	} {
+		_, err := p.prepare("logComponentVerbosity", v)
+		var mErr *mongoerrors.Error
+		require.ErrorAs(t, err, &mErr, name)
+		assert.Equal(t, int32(mongoerrors.ErrBadValue), mErr.Code, name)
+	}
+
+	apply, err := p.prepare("logComponentVerbosity", wirebson.MustDocument(
+		"verbosity", int32(-1),
+		"pgx", wirebson.MustDocument("verbosity", float64(2)),
+	))
+	require.NoError(t, err)
+
+	was := apply().(*wirebson.Document)
+	assert.Equal(t, int32(0), was.Get("verbosity"))
+	assert.Equal(t, wirebson.MustDocument("verbosity", int32(-1)), was.Get("pgx"))
+
+	assert.Equal(t, slog.LevelWarn, lh.Level())
+	assert.Equal(t, map[string]slog.Level{"pgx": slog.LevelDebug - 4}, lh.ComponentLevels())
+
+	apply, err = p.prepare("logComponentVerbosity", wirebson.MustDocument(
+		"pgx", wirebson.MustDocument("verbosity", int32(-1)),
+	))
+	require.NoError(t, err)
+	apply()
+
+	assert.Equal(t, slog.LevelWarn, lh.Level())
+	assert.Empty(t, lh.ComponentLevels())
+}
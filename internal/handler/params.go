@@ -15,7 +15,10 @@
 package handler
 
 import (
+	"encoding/binary"
 	"fmt"
+	"math"
+	"time"
 
 	"github.com/AlekSi/lazyerrors"
 	"github.com/FerretDB/wire/wirebson"
@@ -25,6 +28,19 @@
 	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
 )
 
+// genericArguments contains command arguments that are accepted by all commands.
+var genericArguments = map[string]struct{}{
+	"apiDeprecationErrors": {},
+	"apiStrict":            {},
+	"apiVersion":           {},
+	"comment":              {},
+	"lsid":                 {},
+	"maxTimeMS":            {},
+	"readConcern":          {},
+	"txnNumber":            {},
+	"writeConcern":         {},
+}
+
 // getRequiredParamAny returns doc's first value for the given key
 // or protocol error for missing key.
 func getRequiredParamAny(doc wirebson.AnyDocument, key string) (any, error) {
@@ -124,6 +140,48 @@ func getBoolParam(key string, v any) (bool, error) {
 	}
 }
 
+// getMaxTimeParam returns the duration of `maxTimeMS` command argument.
+// Missing and zero values return zero duration that means no limit.
+// Other values return a protocol error, as in MongoDB.
+func getMaxTimeParam(doc *wirebson.Document) (time.Duration, error) {
+	v := doc.Get("maxTimeMS")
+
+	var ms int64
+
+	switch v := v.(type) {
+	case nil:
+		return 0, nil
+	case int32:
+		ms = int64(v)
+	case int64:
+		ms = v
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			ms = 0
+		case v >= math.MaxInt64:
+			ms = math.MaxInt64
+		case v <= math.MinInt64:
+			ms = math.MinInt64
+		default:
+			ms = int64(v)
+		}
+	default:
+		return 0, mongoerrors.NewWithArgument(mongoerrors.ErrBadValue, "maxTimeMS must be a number", "maxTimeMS")
+	}
+
+	if ms < 0 || ms > math.MaxInt32 {
+		msg := fmt.Sprintf("%d value for maxTimeMS is out of range [0, %d]", ms, math.MaxInt32)
+		return 0, mongoerrors.NewWithArgument(mongoerrors.ErrBadValue, msg, "maxTimeMS")
+	}
+
+	if f, ok := v.(float64); ok && f != math.Floor(f) {
+		return 0, mongoerrors.NewWithArgument(mongoerrors.ErrBadValue, "maxTimeMS has non-integral value", "maxTimeMS")
+	}
+
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
 // getSessionIDsParam returns session UUIDs from the document.
 // The document has the format `{<key>: [{id: <uuid>}, ...]}` and
 // a protocol error is returned for invalid format or value.
@@ -269,3 +327,24 @@ func getSessionUsersParam(v any, command, field string) ([]session.UserID, error
 
 	return userIDs, nil
 }
+
+// splitDocumentSequence returns documents from the OP_MSG document sequence section payload.
+func splitDocumentSequence(seq []byte) ([]wirebson.RawDocument, error) {
+	var res []wirebson.RawDocument
+
+	for len(seq) > 0 {
+		if len(seq) < 4 {
+			return nil, lazyerrors.Errorf("invalid document sequence length %d", len(seq))
+		}
+
+		l := int(binary.LittleEndian.Uint32(seq))
+		if l < 5 || l > len(seq) {
+			return nil, lazyerrors.Errorf("invalid document length %d", l)
+		}
+
+		res = append(res, wirebson.RawDocument(seq[:l]))
+		seq = seq[l:]
+	}
+
+	return res, nil
+}
@@ -0,0 +1,396 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire"
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+const (
+	// Default threshold for slow operations, the same as MongoDB's.
+	defaultProfileSlowMS = 100
+
+	// Maximum number of profiled operations waiting to be written.
+	// Operations that do not fit are dropped.
+	profileQueueSize = 1000
+
+	// Time limit for writing a single profiler entry.
+	profileWriteTimeout = 5 * time.Second
+)
+
+// profiler stores database profiler settings and operations waiting to be written.
+//
+// Profiling levels are set per database; slowms and sampleRate are global, like in MongoDB.
+type profiler struct {
+	rw     sync.RWMutex
+	levels map[string]int32 // database name -> profiling level
+
+	slowMS     atomic.Int32
+	sampleRate atomic.Uint64 // bits of float64
+
+	queue chan *profiledOp
+}
+
+// profiledOp represents a handled operation that should be recorded by the database profiler.
+type profiledOp struct {
+	*middleware.HandledOp
+	client string
+	user   string
+}
+
+// newProfiler creates a new profiler with default settings.
+func newProfiler() *profiler {
+	p := &profiler{
+		levels: map[string]int32{},
+		queue:  make(chan *profiledOp, profileQueueSize),
+	}
+
+	p.slowMS.Store(defaultProfileSlowMS)
+	p.sampleRate.Store(math.Float64bits(1))
+
+	return p
+}
+
+// level returns the profiling level of the given database.
+func (p *profiler) level(dbName string) int32 {
+	p.rw.RLock()
+	defer p.rw.RUnlock()
+
+	return p.levels[dbName]
+}
+
+// setLevel sets the profiling level of the given database and returns the previous one.
+func (p *profiler) setLevel(dbName string, level int32) int32 {
+	p.rw.Lock()
+	defer p.rw.Unlock()
+
+	was := p.levels[dbName]
+
+	if level == 0 {
+		delete(p.levels, dbName)
+	} else {
+		p.levels[dbName] = level
+	}
+
+	return was
+}
+
+// Profile implements [middleware.Profiler].
+//
+// It queues operations that should be recorded by the database profiler for [Handler.runProfiler].
+// Nothing is recorded in read-only mode.
+func (h *Handler) Profile(ctx context.Context, op *middleware.HandledOp) {
+	if op.Response == nil || h.readOnly.Load() {
+		return
+	}
+
+	if _, ok := op.Request.WireBody().(*wire.OpMsg); !ok {
+		return
+	}
+
+	dbName, _ := op.Request.Document().Get("$db").(string)
+	if dbName == "" {
+		return
+	}
+
+	switch h.prof.level(dbName) {
+	case 0:
+		return
+	case 1:
+		if op.Duration < time.Duration(h.prof.slowMS.Load())*time.Millisecond {
+			return
+		}
+	}
+
+	if rate := math.Float64frombits(h.prof.sampleRate.Load()); rate < 1 && rand.Float64() >= rate {
+		return
+	}
+
+	// connection information is not available after the connection is closed
+	pOp := &profiledOp{
+		HandledOp: op,
+	}
+
+	ci := conninfo.Get(ctx)
+
+	if peer := ci.Peer; peer.IsValid() {
+		pOp.client = peer.String()
+	}
+
+	if conv := ci.Conv(); conv.Succeed() {
+		pOp.user = conv.Username()
+	}
+
+	select {
+	case h.prof.queue <- pOp:
+	default:
+		h.L.DebugContext(ctx, "Profiler queue is full, dropping operation")
+	}
+}
+
+// runProfiler writes queued operations into profile collections until ctx is canceled.
+func (h *Handler) runProfiler(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case op := <-h.prof.queue:
+			h.writeProfile(ctx, op)
+		}
+	}
+}
+
+// writeProfile writes a single profiler entry for the given operation.
+func (h *Handler) writeProfile(ctx context.Context, op *profiledOp) {
+	ctx, cancel := context.WithTimeout(ctx, profileWriteTimeout)
+	defer cancel()
+
+	dbName, _ := op.Request.Document().Get("$db").(string)
+
+	entry, err := h.profileEntry(ctx, op)
+	if err == nil {
+		err = h.p.InsertProfile(ctx, dbName, entry)
+	}
+
+	if err != nil {
+		h.L.WarnContext(ctx, "Failed to write profiler entry", slog.String("db", dbName), logging.Error(err))
+	}
+}
+
+// profileEntry returns a profiler entry for the given operation.
+//
+// For successful `find`, `count`, and `aggregate` commands, the plan summary is collected with EXPLAIN
+// without executing the query again.
+func (h *Handler) profileEntry(ctx context.Context, op *profiledOp) (*wirebson.Document, error) {
+	doc := op.Request.Document()
+	command := doc.Command()
+
+	cmd, err := redactSensitive(doc)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	entry := must.NotFail(wirebson.NewDocument(
+		"op", currentOpType(command),
+		"ns", currentOpNamespace(doc),
+		"command", cmd,
+	))
+
+	resp := op.Response.Document()
+
+	switch command {
+	case "find", "aggregate", "getMore":
+		if cursor, ok := resp.Get("cursor").(wirebson.AnyDocument); ok {
+			cursorDoc, err := cursor.Decode()
+			if err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+
+			if id, ok := cursorDoc.Get("id").(int64); ok && id != 0 {
+				must.NoError(entry.Add("cursorid", id))
+			}
+
+			for _, f := range []string{"firstBatch", "nextBatch"} {
+				if batch, ok := cursorDoc.Get(f).(wirebson.AnyArray); ok {
+					arr, err := batch.Decode()
+					if err != nil {
+						return nil, lazyerrors.Error(err)
+					}
+
+					must.NoError(entry.Add("nreturned", int32(arr.Len())))
+				}
+			}
+		}
+
+	case "insert":
+		if n, ok := resp.Get("n").(int32); ok {
+			must.NoError(entry.Add("ninserted", n))
+		}
+
+	case "delete":
+		if n, ok := resp.Get("n").(int32); ok {
+			must.NoError(entry.Add("ndeleted", n))
+		}
+
+	case "update":
+		if n, ok := resp.Get("n").(int32); ok {
+			must.NoError(entry.Add("nMatched", n))
+		}
+
+		if n, ok := resp.Get("nModified").(int32); ok {
+			must.NoError(entry.Add("nModified", n))
+		}
+
+		if upserted, ok := resp.Get("upserted").(wirebson.AnyArray); ok {
+			arr, err := upserted.Decode()
+			if err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+
+			must.NoError(entry.Add("nUpserted", int32(arr.Len())))
+		}
+	}
+
+	ok := op.Response.OK()
+
+	if ok && slices.Contains([]string{"find", "count", "aggregate"}, command) && !writesOutput(doc) {
+		dbName, _ := doc.Get("$db").(string)
+
+		plan, err := h.explainQuery(ctx, dbName, command, op.Request.DocumentRaw())
+		if err == nil {
+			var summary string
+			if summary, err = profilePlan(plan); err == nil {
+				must.NoError(entry.Add("planSummary", summary))
+			}
+		}
+
+		if err != nil {
+			h.L.DebugContext(ctx, "Failed to collect query plan for profiler", logging.Error(err))
+		}
+	}
+
+	millis := min(op.Duration.Milliseconds(), math.MaxInt32)
+
+	must.NoError(entry.Add("responseLength", op.Response.WireHeader().MessageLength))
+	must.NoError(entry.Add("protocol", "op_msg"))
+	must.NoError(entry.Add("millis", int32(millis)))
+
+	if !ok {
+		must.NoError(entry.Add("ok", float64(0)))
+
+		if msg, _ := resp.Get("errmsg").(string); msg != "" {
+			must.NoError(entry.Add("errMsg", msg))
+		}
+
+		must.NoError(entry.Add("errName", op.Response.ErrorName()))
+		must.NoError(entry.Add("errCode", int32(op.Response.ErrorCode())))
+	}
+
+	must.NoError(entry.Add("ts", op.Start.Add(op.Duration)))
+	must.NoError(entry.Add("client", op.client))
+
+	allUsers := wirebson.MakeArray(0)
+	if op.user != "" {
+		must.NoError(allUsers.Add(wirebson.MustDocument("user", op.user, "db", "admin")))
+	}
+
+	must.NoError(entry.Add("allUsers", allUsers))
+	must.NoError(entry.Add("user", op.user))
+
+	return entry, nil
+}
+
+// sensitiveCommand returns true if the given command could contain credentials,
+// such as passwords or SASL payloads, that should not be stored in the profile collection
+// or returned by `currentOp`.
+func sensitiveCommand(doc *wirebson.Document) bool {
+	switch doc.Command() {
+	case "saslStart", "saslContinue", "authenticate", "createUser", "updateUser":
+		return true
+	case "hello", "isMaster", "ismaster":
+		return doc.Get("speculativeAuthenticate") != nil
+	default:
+		return false
+	}
+}
+
+// redactSensitive returns the given command as is,
+// or with all values redacted (see [redactCommand]) if it could contain credentials.
+func redactSensitive(doc *wirebson.Document) (any, error) {
+	if !sensitiveCommand(doc) {
+		return doc, nil
+	}
+
+	redacted, err := redactCommand(doc)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	res, err := wirebson.FromDriver(redacted)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return res, nil
+}
+
+// profilePlan returns MongoDB-like plan summary for PostgreSQL query plan in JSON format.
+func profilePlan(b []byte) (string, error) {
+	var plans []struct {
+		Plan map[string]any `json:"Plan"`
+	}
+
+	if err := json.Unmarshal(b, &plans); err != nil {
+		return "", lazyerrors.Error(err)
+	}
+
+	if len(plans) == 0 {
+		return "", lazyerrors.New("no execution plan returned")
+	}
+
+	var stages []string
+
+	var walk func(node map[string]any)
+	walk = func(node map[string]any) {
+		var stage string
+
+		switch node["Node Type"] {
+		case "Seq Scan":
+			stage = "COLLSCAN"
+		case "Index Scan", "Index Only Scan", "Bitmap Index Scan":
+			stage = "IXSCAN"
+		}
+
+		if stage != "" {
+			if index, _ := node["Index Name"].(string); index != "" {
+				stage += " { " + index + " }"
+			}
+
+			if !slices.Contains(stages, stage) {
+				stages = append(stages, stage)
+			}
+		}
+
+		children, _ := node["Plans"].([]any)
+		for _, child := range children {
+			if c, ok := child.(map[string]any); ok {
+				walk(c)
+			}
+		}
+	}
+
+	walk(plans[0].Plan)
+
+	return strings.Join(stages, ", "), nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/util/testutil"
+)
+
+func TestProfilePlan(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		plan    string
+		summary string
+	}{
+		"SeqScan": {
+			plan: `[{"Plan": {
+				"Node Type": "Function Scan",
+				"Plans": [{"Node Type": "Seq Scan"}]
+			}}]`,
+			summary: "COLLSCAN",
+		},
+		"IndexScan": {
+			plan: `[{"Plan": {
+				"Node Type": "Index Scan",
+				"Index Name": "_id_"
+			}}]`,
+			summary: "IXSCAN { _id_ }",
+		},
+		"BitmapScan": {
+			plan: `[{"Plan": {
+				"Node Type": "Bitmap Heap Scan",
+				"Plans": [{"Node Type": "Bitmap Index Scan", "Index Name": "v_1"}]
+			}}]`,
+			summary: "IXSCAN { v_1 }",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			summary, err := profilePlan([]byte(tc.plan))
+			require.NoError(t, err)
+			assert.Equal(t, tc.summary, summary)
+		})
+	}
+}
+
+func TestProfileEntryRedacted(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		command  *wirebson.Document
+		expected *wirebson.Document
+	}{
+		"CreateUser": {
+			command: wirebson.MustDocument(
+				"createUser", "alice",
+				"pwd", "secret",
+				"roles", wirebson.MustArray(),
+				"$db", "admin",
+			),
+			expected: wirebson.MustDocument(
+				"createUser", "alice",
+				"pwd", "###",
+				"roles", wirebson.MustArray(),
+				"$db", "admin",
+			),
+		},
+		"SASLStart": {
+			command: wirebson.MustDocument(
+				"saslStart", int32(1),
+				"mechanism", "PLAIN",
+				"payload", wirebson.Binary{B: []byte("\x00alice\x00secret")},
+				"$db", "admin",
+			),
+			expected: wirebson.MustDocument(
+				"saslStart", "###",
+				"mechanism", "###",
+				"payload", "###",
+				"$db", "admin",
+			),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := middleware.RequestDoc(tc.command)
+			require.NoError(t, err)
+
+			resp, err := middleware.ResponseDoc(req, wirebson.MustDocument("ok", float64(1)))
+			require.NoError(t, err)
+
+			h := &Handler{NewOpts: &NewOpts{L: testutil.Logger(t)}}
+
+			entry, err := h.profileEntry(context.Background(), &profiledOp{
+				HandledOp: &middleware.HandledOp{Request: req, Response: resp},
+			})
+			require.NoError(t, err)
+
+			actual, ok := entry.Get("command").(*wirebson.Document)
+			require.True(t, ok, "%T", entry.Get("command"))
+			testutil.AssertEqual(t, tc.expected, actual)
+		})
+	}
+}
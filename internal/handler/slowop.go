@@ -0,0 +1,165 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+)
+
+// redacted replaces values of redacted commands, the same as MongoDB's log redaction.
+const redacted = "###"
+
+// logSlowOp logs the given operation if it took longer than the current slow operation threshold.
+//
+// With `mongo` log format, the entry is similar to MongoDB's slow query log message.
+func (h *Handler) logSlowOp(ctx context.Context, op *operation, resp *middleware.Response, stats *documentdb.QueryStats) {
+	duration := time.Since(op.start)
+	if duration < time.Duration(h.slowOpThreshold.Load()) {
+		return
+	}
+
+	command, err := redactCommand(op.command)
+	if err != nil {
+		h.L.WarnContext(ctx, "Failed to redact slow operation command", logging.Error(err))
+		return
+	}
+
+	queries, total := stats.Queries()
+
+	sqls := make(bson.A, len(queries))
+	for i, q := range queries {
+		sqls[i] = bson.D{
+			{Key: "sql", Value: strings.Join(strings.Fields(q.SQL), " ")},
+			{Key: "durationMicros", Value: q.Duration.Microseconds()},
+			{Key: "ok", Value: q.Err == nil},
+		}
+	}
+
+	attrs := []slog.Attr{
+		slog.String("type", "command"),
+		slog.String("ns", currentOpNamespace(op.command)),
+		slog.Any("command", command),
+		slog.String("remote", op.client),
+		slog.String("protocol", "op_msg"),
+		slog.Int64("durationMillis", duration.Milliseconds()),
+		slog.Any("ferretdb", bson.D{
+			{Key: "poolWaitMicros", Value: stats.PoolWait().Microseconds()},
+			{Key: "queriesTotal", Value: int64(total)},
+			{Key: "queries", Value: sqls},
+		}),
+	}
+
+	if resp != nil {
+		attrs = append(attrs, slog.Any("reslen", resp.WireHeader().MessageLength))
+
+		if !resp.OK() {
+			attrs = append(
+				attrs,
+				slog.Float64("ok", 0),
+				slog.String("errName", resp.ErrorName()),
+				slog.Any("errCode", int32(resp.ErrorCode())),
+			)
+		}
+	}
+
+	h.L.LogAttrs(ctx, slog.LevelInfo, "Slow query", attrs...)
+}
+
+// redactCommand returns the shape of the given command with all values replaced,
+// except the collection name and the database name.
+func redactCommand(doc *wirebson.Document) (bson.D, error) {
+	res := make(bson.D, 0, doc.Len())
+
+	for k, v := range doc.All() {
+		var val any
+
+		switch k {
+		case doc.Command(), "$db":
+			if s, ok := v.(string); ok {
+				val = s
+				break
+			}
+
+			fallthrough
+
+		default:
+			var err error
+			if val, err = redactValue(v); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+		}
+
+		res = append(res, bson.E{Key: k, Value: val})
+	}
+
+	return res, nil
+}
+
+// redactValue returns the shape of the given value with all scalar values replaced.
+func redactValue(v any) (any, error) {
+	switch v := v.(type) {
+	case wirebson.AnyDocument:
+		doc, err := v.Decode()
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		res := make(bson.D, 0, doc.Len())
+
+		for k, v := range doc.All() {
+			val, err := redactValue(v)
+			if err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+
+			res = append(res, bson.E{Key: k, Value: val})
+		}
+
+		return res, nil
+
+	case wirebson.AnyArray:
+		arr, err := v.Decode()
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		res := make(bson.A, 0, arr.Len())
+
+		for v := range arr.Values() {
+			val, err := redactValue(v)
+			if err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+
+			res = append(res, val)
+		}
+
+		return res, nil
+
+	default:
+		return redacted, nil
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"testing"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestRedactCommand(t *testing.T) {
+	t.Parallel()
+
+	doc := wirebson.MustDocument(
+		"find", "users",
+		"filter", wirebson.MustDocument(
+			"email", "user@example.com",
+			"age", wirebson.MustDocument("$gt", int32(42)),
+		),
+		"projection", wirebson.MustArray("a", int32(1)),
+		"limit", int64(10),
+		"$db", "test",
+	)
+
+	actual, err := redactCommand(doc)
+	require.NoError(t, err)
+
+	expected := bson.D{
+		{Key: "find", Value: "users"},
+		{Key: "filter", Value: bson.D{
+			{Key: "email", Value: "###"},
+			{Key: "age", Value: bson.D{{Key: "$gt", Value: "###"}}},
+		}},
+		{Key: "projection", Value: bson.A{"###", "###"}},
+		{Key: "limit", Value: "###"},
+		{Key: "$db", Value: "test"},
+	}
+	assert.Equal(t, expected, actual)
+}
@@ -0,0 +1,193 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+)
+
+const (
+	// Default TTL monitor interval, the same as MongoDB's.
+	defaultTTLMonitorInterval = time.Minute
+
+	// Default maximum number of documents deleted from a single collection at once.
+	defaultTTLMonitorBatchSize = 10_000
+
+	// Maximum number of namespaces with their own labels of the deleted documents metric.
+	// Documents deleted from other namespaces are counted together as [middleware.TopOther].
+	maxTTLNamespaces = 1000
+)
+
+// ttlMonitor periodically deletes expired documents using TTL indexes
+// and `expireAfterSeconds` option of time series collections.
+type ttlMonitor struct {
+	p         *documentdb.Pool
+	l         *slog.Logger
+	interval  time.Duration
+	batchSize int32
+	readOnly  func() bool
+
+	passes           atomic.Int64
+	subPasses        atomic.Int64
+	deletedDocuments atomic.Int64
+
+	deleted    *prometheus.CounterVec
+	namespaces map[string]struct{} // with their own deleted metric labels; used only by pass
+}
+
+// newTTLMonitor creates a new TTL monitor.
+//
+// Zero interval and batch size are replaced with defaults.
+// Passes are skipped while readOnly returns true.
+func newTTLMonitor(p *documentdb.Pool, l *slog.Logger, interval time.Duration, batchSize int, readOnly func() bool) *ttlMonitor {
+	if interval == 0 {
+		interval = defaultTTLMonitorInterval
+	}
+
+	if batchSize <= 0 {
+		batchSize = defaultTTLMonitorBatchSize
+	}
+
+	return &ttlMonitor{
+		p:          p,
+		l:          l,
+		interval:   interval,
+		batchSize:  int32(batchSize),
+		readOnly:   readOnly,
+		namespaces: map[string]struct{}{},
+		deleted: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "ferretdb",
+				Subsystem: "ttl",
+				Name:      "deleted_documents_total",
+				Help:      "Total number of documents deleted by the TTL monitor.",
+			},
+			[]string{"db", "collection"},
+		),
+	}
+}
+
+// Run runs TTL passes until ctx is canceled.
+// Negative interval disables the monitor.
+func (m *ttlMonitor) Run(ctx context.Context) {
+	if m.interval < 0 {
+		m.l.InfoContext(ctx, "TTL monitor is disabled")
+		return
+	}
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			if m.readOnly() {
+				m.l.DebugContext(ctx, "Skipping TTL pass in read-only mode")
+				continue
+			}
+
+			m.pass(ctx)
+		}
+	}
+}
+
+// pass runs a single TTL pass.
+//
+// Each sub-pass deletes up to batch size documents from every collection with TTL index.
+// Sub-passes are repeated while there are more expired documents.
+func (m *ttlMonitor) pass(ctx context.Context) {
+	m.passes.Add(1)
+
+	ttls, err := m.p.ListTTL(ctx)
+	if err != nil {
+		m.l.WarnContext(ctx, "Failed to list TTL indexes", logging.Error(err))
+		return
+	}
+
+	for len(ttls) > 0 && ctx.Err() == nil {
+		m.subPasses.Add(1)
+
+		var more []documentdb.TTL
+
+		for _, ttl := range ttls {
+			n, err := m.p.DeleteExpired(ctx, &ttl, m.batchSize)
+			if err != nil {
+				m.l.WarnContext(
+					ctx, "Failed to delete expired documents",
+					slog.String("db", ttl.DB), slog.String("collection", ttl.Collection), logging.Error(err),
+				)
+
+				continue
+			}
+
+			if n == 0 {
+				continue
+			}
+
+			m.deletedDocuments.Add(int64(n))
+			m.observeDeleted(ttl.DB, ttl.Collection, n)
+
+			if n == m.batchSize {
+				more = append(more, ttl)
+			}
+		}
+
+		ttls = more
+	}
+}
+
+// observeDeleted updates the deleted documents metric for the given namespace.
+//
+// The number of label values is limited, like for [middleware.Top].
+func (m *ttlMonitor) observeDeleted(db, collection string, n int32) {
+	ns := db + "." + collection
+
+	if _, ok := m.namespaces[ns]; !ok {
+		if len(m.namespaces) >= maxTTLNamespaces {
+			db, collection = middleware.TopOther, middleware.TopOther
+		} else {
+			m.namespaces[ns] = struct{}{}
+		}
+	}
+
+	m.deleted.WithLabelValues(db, collection).Add(float64(n))
+}
+
+// Describe implements [prometheus.Collector].
+func (m *ttlMonitor) Describe(ch chan<- *prometheus.Desc) {
+	m.deleted.Describe(ch)
+}
+
+// Collect implements [prometheus.Collector].
+func (m *ttlMonitor) Collect(ch chan<- prometheus.Metric) {
+	m.deleted.Collect(ch)
+}
+
+// check interfaces
+var (
+	_ prometheus.Collector = (*ttlMonitor)(nil)
+)
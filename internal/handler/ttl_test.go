@@ -0,0 +1,44 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"strconv"
+	"testing"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/util/testutil"
+)
+
+func TestTTLObserveDeleted(t *testing.T) {
+	t.Parallel()
+
+	m := newTTLMonitor(nil, testutil.Logger(t), 0, 0, nil)
+
+	for i := range maxTTLNamespaces {
+		m.observeDeleted("db", strconv.Itoa(i), 1)
+	}
+
+	m.observeDeleted("db", "0", 2)
+	m.observeDeleted("db", "new", 3)
+	m.observeDeleted("other", "new", 4)
+
+	assert.Equal(t, maxTTLNamespaces+1, promtestutil.CollectAndCount(m.deleted))
+	assert.Equal(t, 3.0, promtestutil.ToFloat64(m.deleted.WithLabelValues("db", "0")))
+	assert.Equal(t, 7.0, promtestutil.ToFloat64(m.deleted.WithLabelValues(middleware.TopOther, middleware.TopOther)))
+}
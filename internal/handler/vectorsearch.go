@@ -0,0 +1,338 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+)
+
+// vectorSearchMaxCandidates is the maximal value of `$vectorSearch`'s `numCandidates`, the same as in Atlas.
+const vectorSearchMaxCandidates = 10_000
+
+// firstStage returns the decoded pipeline of `aggregate` command,
+// and the name and the value of its first stage.
+// The name is empty if the pipeline is empty or invalid; DocumentDB reports those problems.
+func firstStage(doc *wirebson.Document) (*wirebson.Array, string, *wirebson.Document, error) {
+	p, ok := doc.Get("pipeline").(wirebson.AnyArray)
+	if !ok {
+		return nil, "", nil, nil
+	}
+
+	pipeline, err := p.Decode()
+	if err != nil {
+		return nil, "", nil, lazyerrors.Error(err)
+	}
+
+	if pipeline.Len() == 0 {
+		return pipeline, "", nil, nil
+	}
+
+	s, ok := pipeline.Get(0).(wirebson.AnyDocument)
+	if !ok {
+		return pipeline, "", nil, nil
+	}
+
+	stageDoc, err := s.Decode()
+	if err != nil {
+		return nil, "", nil, lazyerrors.Error(err)
+	}
+
+	if stageDoc.Len() != 1 {
+		return pipeline, "", nil, nil
+	}
+
+	name := stageDoc.Command()
+
+	stage, ok := stageDoc.Get(name).(wirebson.AnyDocument)
+	if !ok {
+		msg := fmt.Sprintf("%s requires a document as its argument", name)
+		return nil, "", nil, mongoerrors.NewWithArgument(mongoerrors.ErrFailedToParse, msg, name)
+	}
+
+	res, err := stage.Decode()
+	if err != nil {
+		return nil, "", nil, lazyerrors.Error(err)
+	}
+
+	return pipeline, name, res, nil
+}
+
+// listSearchIndexes returns the response of `aggregate` command with `$listSearchIndexes` stage.
+//
+// Other stages of the pipeline are not supported.
+func (h *Handler) listSearchIndexes(ctx context.Context, dbName, collectionName string, pipeline *wirebson.Array, stage *wirebson.Document) (*wirebson.Document, error) { //nolint:lll // for readability
+	if pipeline.Len() > 1 {
+		msg := "Stages after $listSearchIndexes are not supported yet"
+		return nil, mongoerrors.NewWithArgument(mongoerrors.ErrNotImplemented, msg, "$listSearchIndexes")
+	}
+
+	name, err := getOptionalParam(stage, "name", "")
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := getOptionalParam(stage, "id", "")
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, err := h.p.ListSearchIndexes(ctx, dbName, collectionName)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	firstBatch := wirebson.MakeArray(len(indexes))
+
+	for _, index := range indexes {
+		if (name != "" && index.Name != name) || (id != "" && index.ID != id) {
+			continue
+		}
+
+		err = firstBatch.Add(wirebson.MustDocument(
+			"id", index.ID,
+			"name", index.Name,
+			"type", index.Type,
+			"status", "READY",
+			"queryable", true,
+			"latestDefinition", index.Definition,
+		))
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	return wirebson.MustDocument(
+		"cursor", wirebson.MustDocument(
+			"id", int64(0),
+			"ns", dbName+"."+collectionName,
+			"firstBatch", firstBatch,
+		),
+		"ok", float64(1),
+	), nil
+}
+
+// vectorSearchSpec returns `aggregate` command spec with `$vectorSearch` stage replaced by DocumentDB's `$search`,
+// or nil if the search index used by the stage does not exist.
+func (h *Handler) vectorSearchSpec(ctx context.Context, dbName, collectionName string, doc *wirebson.Document, pipeline *wirebson.Array, stage *wirebson.Document) (wirebson.RawDocument, error) { //nolint:lll // for readability
+	search, indexName, err := vectorSearchStage(stage)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, err := h.p.ListSearchIndexes(ctx, dbName, collectionName)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if !slices.ContainsFunc(indexes, func(index documentdb.SearchIndex) bool { return index.Name == indexName }) {
+		return nil, nil
+	}
+
+	if pipeline, err = vectorSearchPipeline(pipeline, search); err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	res := wirebson.MakeDocument(doc.Len())
+
+	for k, v := range doc.All() {
+		if k == "pipeline" {
+			v = pipeline
+		}
+
+		if err = res.Add(k, v); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	return res.Encode()
+}
+
+// vectorSearchStage returns DocumentDB's `$search` stage for the given `$vectorSearch` stage,
+// and the name of the used search index.
+func vectorSearchStage(stage *wirebson.Document) (*wirebson.Document, string, error) {
+	const op = "$vectorSearch"
+
+	for _, f := range []string{"index", "path", "queryVector", "limit"} {
+		if stage.Get(f) == nil {
+			msg := fmt.Sprintf("%s.%s is required", op, f)
+			return nil, "", mongoerrors.NewWithArgument(mongoerrors.ErrLocation40414, msg, op)
+		}
+	}
+
+	for k := range stage.All() {
+		switch k {
+		case "index", "path", "queryVector", "limit", "numCandidates", "filter":
+		case "exact":
+			if exact, _ := stage.Get(k).(bool); exact {
+				msg := "Exact nearest neighbor search is not supported yet"
+				return nil, "", mongoerrors.NewWithArgument(mongoerrors.ErrNotImplemented, msg, op)
+			}
+		default:
+			msg := fmt.Sprintf("%s.%s is an unknown field", op, k)
+			return nil, "", mongoerrors.NewWithArgument(mongoerrors.ErrUnknownBsonField, msg, op)
+		}
+	}
+
+	index, err := getRequiredParam[string](stage, "index")
+	if err != nil {
+		return nil, "", err
+	}
+
+	path, err := getRequiredParam[string](stage, "path")
+	if err != nil {
+		return nil, "", err
+	}
+
+	vector, ok := stage.Get("queryVector").(wirebson.AnyArray)
+	if !ok {
+		msg := fmt.Sprintf("%s.queryVector must be an array of numbers", op)
+		return nil, "", mongoerrors.NewWithArgument(mongoerrors.ErrTypeMismatch, msg, op)
+	}
+
+	limit, err := getSearchInt(stage, op, "limit")
+	if err != nil {
+		return nil, "", err
+	}
+
+	candidates, err := getSearchInt(stage, op, "numCandidates")
+	if err != nil {
+		return nil, "", err
+	}
+
+	if candidates < limit || candidates > vectorSearchMaxCandidates {
+		msg := fmt.Sprintf(
+			"%s.numCandidates must be between %s.limit and %d, got %d",
+			op, op, vectorSearchMaxCandidates, candidates,
+		)
+
+		return nil, "", mongoerrors.NewWithArgument(mongoerrors.ErrBadValue, msg, op)
+	}
+
+	search := wirebson.MustDocument(
+		"vector", vector,
+		"path", path,
+		"k", int32(limit),
+		"efSearch", int32(candidates),
+	)
+
+	if v := stage.Get("filter"); v != nil {
+		filter, ok := v.(wirebson.AnyDocument)
+		if !ok {
+			msg := fmt.Sprintf("%s.filter must be a document", op)
+			return nil, "", mongoerrors.NewWithArgument(mongoerrors.ErrTypeMismatch, msg, op)
+		}
+
+		if err = search.Add("filter", filter); err != nil {
+			return nil, "", lazyerrors.Error(err)
+		}
+	}
+
+	res := wirebson.MustDocument(
+		"$search", wirebson.MustDocument(
+			"cosmosSearch", search,
+			"returnStoredSource", true,
+		),
+	)
+
+	return res, index, nil
+}
+
+// vectorSearchPipeline returns the pipeline with the given first stage,
+// and `vectorSearchScore` metadata replaced by DocumentDB's `searchScore` in other stages.
+func vectorSearchPipeline(pipeline *wirebson.Array, first *wirebson.Document) (*wirebson.Array, error) {
+	res := wirebson.MakeArray(pipeline.Len())
+
+	if err := res.Add(first); err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	for i, v := range pipeline.All() {
+		if i == 0 {
+			continue
+		}
+
+		stage, err := replaceVectorSearchScore(v)
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		if err = res.Add(stage); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	return res, nil
+}
+
+// replaceVectorSearchScore recursively replaces `{$meta: "vectorSearchScore"}` expressions
+// in the given value with `{$meta: "searchScore"}`.
+func replaceVectorSearchScore(v any) (any, error) {
+	switch v := v.(type) {
+	case wirebson.AnyDocument:
+		doc, err := v.Decode()
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		if doc.Len() == 1 && doc.Get("$meta") == "vectorSearchScore" {
+			return wirebson.MustDocument("$meta", "searchScore"), nil
+		}
+
+		res := wirebson.MakeDocument(doc.Len())
+
+		for k, f := range doc.All() {
+			if f, err = replaceVectorSearchScore(f); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+
+			if err = res.Add(k, f); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+		}
+
+		return res, nil
+
+	case wirebson.AnyArray:
+		arr, err := v.Decode()
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		res := wirebson.MakeArray(arr.Len())
+
+		for e := range arr.Values() {
+			if e, err = replaceVectorSearchScore(e); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+
+			if err = res.Add(e); err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+		}
+
+		return res, nil
+
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/documentdb"
+)
+
+// writeStatements returns `update` or `delete` statements from the given field of the command
+// and the document sequence.
+func writeStatements(doc *wirebson.Document, field string, seq []byte) ([]*wirebson.Document, error) {
+	var statements []*wirebson.Document
+
+	if arr, ok := doc.Get(field).(wirebson.AnyArray); ok {
+		arrDoc, err := arr.Decode()
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		for v := range arrDoc.Values() {
+			d, ok := v.(wirebson.AnyDocument)
+			if !ok {
+				continue
+			}
+
+			s, err := d.Decode()
+			if err != nil {
+				return nil, lazyerrors.Error(err)
+			}
+
+			statements = append(statements, s)
+		}
+	}
+
+	docs, err := splitDocumentSequence(seq)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	for _, d := range docs {
+		s, err := d.Decode()
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		statements = append(statements, s)
+	}
+
+	return statements, nil
+}
+
+// writeFilters returns filters of `update` or `delete` statements for [documentdb.Pool.WithWriteConn].
+// Statements without valid filters are skipped; DocumentDB reports them.
+func writeFilters(doc *wirebson.Document, statements []*wirebson.Document) []documentdb.WriteFilter {
+	let, _ := doc.Get("let").(wirebson.AnyDocument)
+
+	res := make([]documentdb.WriteFilter, 0, len(statements))
+
+	for _, s := range statements {
+		filter, ok := s.Get("q").(wirebson.AnyDocument)
+		if !ok {
+			continue
+		}
+
+		collation, _ := s.Get("collation").(wirebson.AnyDocument)
+
+		res = append(res, documentdb.WriteFilter{
+			Filter:    filter,
+			Collation: collation,
+			Let:       let,
+		})
+	}
+
+	return res
+}
+
+// upsertedIDs returns _id values of documents upserted by `update` or `findAndModify` command
+// with the given result.
+func upsertedIDs(res wirebson.RawDocument) ([]any, error) {
+	doc, err := res.Decode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	// findAndModify
+	if v, ok := doc.Get("lastErrorObject").(wirebson.AnyDocument); ok {
+		leo, err := v.Decode()
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		if id := leo.Get("upserted"); id != nil {
+			return []any{id}, nil
+		}
+
+		return nil, nil
+	}
+
+	// update
+	v, ok := doc.Get("upserted").(wirebson.AnyArray)
+	if !ok {
+		return nil, nil
+	}
+
+	arr, err := v.Decode()
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	var ids []any
+
+	for v := range arr.Values() {
+		u, ok := v.(wirebson.AnyDocument)
+		if !ok {
+			continue
+		}
+
+		uDoc, err := u.Decode()
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		if id := uDoc.Get("_id"); id != nil {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"testing"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertedIDs(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		res      *wirebson.Document
+		expected []any
+	}{
+		"Update": {
+			res: wirebson.MustDocument(
+				"n", int32(2),
+				"nModified", int32(0),
+				"upserted", wirebson.MustArray(
+					wirebson.MustDocument("index", int32(0), "_id", "a"),
+					wirebson.MustDocument("index", int32(1), "_id", int32(42)),
+				),
+				"ok", float64(1),
+			),
+			expected: []any{"a", int32(42)},
+		},
+		"UpdateNone": {
+			res:      wirebson.MustDocument("n", int32(1), "nModified", int32(1), "ok", float64(1)),
+			expected: nil,
+		},
+		"FindAndModify": {
+			res: wirebson.MustDocument(
+				"lastErrorObject", wirebson.MustDocument("n", int32(1), "updatedExisting", false, "upserted", "b"),
+				"value", wirebson.Null,
+				"ok", float64(1),
+			),
+			expected: []any{"b"},
+		},
+		"FindAndModifyNone": {
+			res: wirebson.MustDocument(
+				"lastErrorObject", wirebson.MustDocument("n", int32(1), "updatedExisting", true),
+				"value", wirebson.MustDocument("_id", "c"),
+				"ok", float64(1),
+			),
+			expected: nil,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			raw, err := tc.res.Encode()
+			require.NoError(t, err)
+
+			actual, err := upsertedIDs(raw)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
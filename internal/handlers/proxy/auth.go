@@ -0,0 +1,264 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/xdg-go/scram"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+)
+
+// Supported upstream authentication mechanisms.
+const (
+	mechanismSCRAMSHA1   = "SCRAM-SHA-1"
+	mechanismSCRAMSHA256 = "SCRAM-SHA-256"
+)
+
+// errUpstreamAuth is returned when the upstream authentication fails.
+var errUpstreamAuth = errors.New("upstream authentication failed")
+
+// anonymousCommands contains commands that do not require client authentication.
+//
+// Keep in sync with the DocumentDB handler.
+var anonymousCommands = map[string]struct{}{
+	"authenticate":     {},
+	"buildInfo":        {},
+	"buildinfo":        {},
+	"connPoolStats":    {},
+	"connectionStatus": {},
+	"hello":            {},
+	"isMaster":         {},
+	"ismaster":         {},
+	"logout":           {},
+	"ping":             {},
+	"saslContinue":     {},
+	"saslStart":        {},
+	"whatsmyuri":       {},
+}
+
+// Credentials represents upstream credentials.
+// Zero value means no upstream authentication.
+type Credentials struct {
+	Username  string `json:"username"`
+	Password  string `json:"password"`
+	DB        string `json:"db"`        // defaults to "admin"
+	Mechanism string `json:"mechanism"` // defaults to "SCRAM-SHA-256"
+}
+
+// validate checks credentials and sets default values.
+func (creds *Credentials) validate() error {
+	if creds.Username == "" {
+		if *creds != (Credentials{}) {
+			return lazyerrors.New("upstream username is not set")
+		}
+
+		return nil
+	}
+
+	if creds.DB == "" {
+		creds.DB = "admin"
+	}
+
+	switch creds.Mechanism {
+	case "":
+		creds.Mechanism = mechanismSCRAMSHA256
+	case mechanismSCRAMSHA1, mechanismSCRAMSHA256:
+	default:
+		return lazyerrors.Errorf("unsupported upstream authentication mechanism %q", creds.Mechanism)
+	}
+
+	return nil
+}
+
+// loadUserMap loads the mapping of FerretDB usernames to upstream credentials from the given JSON file.
+func loadUserMap(file string) (map[string]Credentials, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, lazyerrors.Errorf("Failed to read proxy user map file: %w", err)
+	}
+
+	var res map[string]Credentials
+	if err = json.Unmarshal(b, &res); err != nil {
+		return nil, lazyerrors.Errorf("Failed to parse proxy user map file: %w", err)
+	}
+
+	for user, creds := range res {
+		if creds.Username == "" {
+			return nil, lazyerrors.Errorf("upstream username is not set for user %q", user)
+		}
+
+		if err = creds.validate(); err != nil {
+			return nil, lazyerrors.Errorf("user %q: %w", user, err)
+		}
+
+		res[user] = creds
+	}
+
+	return res, nil
+}
+
+// upstreamAuth returns true if the handler authenticates upstream connections itself.
+func (h *Handler) upstreamAuth() bool {
+	return h.opts.Username != "" || len(h.userMap) > 0
+}
+
+// credentials returns upstream credentials for the given request.
+//
+// If the request should not be sent upstream, error response is returned.
+func (h *Handler) credentials(ctx context.Context, req *middleware.Request) (Credentials, *middleware.Response) {
+	if !h.upstreamAuth() {
+		return Credentials{}, nil
+	}
+
+	command := req.Document().Command()
+
+	conv := conninfo.Get(ctx).Conv()
+	if !conv.Succeed() {
+		if _, ok := anonymousCommands[command]; !ok && h.opts.Auth {
+			return Credentials{}, middleware.ResponseErr(req, mongoerrors.New(
+				mongoerrors.ErrUnauthorized,
+				fmt.Sprintf("Command %s requires authentication", command),
+			))
+		}
+
+		return h.defaultCreds, nil
+	}
+
+	username := conv.Username()
+
+	if creds, ok := h.userMap[username]; ok {
+		return creds, nil
+	}
+
+	if h.defaultCreds.Username == "" {
+		return Credentials{}, middleware.ResponseErr(req, mongoerrors.New(
+			mongoerrors.ErrUnauthorized,
+			fmt.Sprintf("User %s is not mapped to an upstream user", username),
+		))
+	}
+
+	return h.defaultCreds, nil
+}
+
+// authenticate performs SCRAM authentication of the upstream connection.
+func (c *conn) authenticate(ctx context.Context, creds Credentials) (err error) {
+	ctx, span := otel.Tracer("").Start(
+		ctx,
+		"proxy.conn.authenticate",
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+	)
+
+	defer func() {
+		if err == nil {
+			span.SetStatus(otelcodes.Ok, "")
+		} else {
+			span.SetStatus(otelcodes.Error, "")
+			span.RecordError(err)
+		}
+
+		span.End()
+	}()
+
+	var client *scram.Client
+
+	switch creds.Mechanism {
+	case mechanismSCRAMSHA1:
+		// MongoDB uses a digest of the password for SCRAM-SHA-1
+		h := md5.Sum([]byte(creds.Username + ":mongo:" + creds.Password))
+		client, err = scram.SHA1.NewClient(creds.Username, hex.EncodeToString(h[:]), "")
+	default:
+		client, err = scram.SHA256.NewClient(creds.Username, creds.Password, "")
+	}
+
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	conv := client.NewConversation()
+
+	payload, err := conv.Step("")
+	if err != nil {
+		return lazyerrors.Error(err)
+	}
+
+	doc := wirebson.MustDocument(
+		"saslStart", int32(1),
+		"mechanism", creds.Mechanism,
+		"payload", wirebson.Binary{B: []byte(payload)},
+		"options", wirebson.MustDocument("skipEmptyExchange", true),
+		"$db", creds.DB,
+	)
+
+	for {
+		var req *middleware.Request
+		if req, err = middleware.RequestDoc(doc); err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		var resp *middleware.Response
+		if resp, err = c.handle(ctx, req); err != nil {
+			return lazyerrors.Error(err)
+		}
+
+		if !resp.OK() {
+			msg, _ := resp.Document().Get("errmsg").(string)
+			return fmt.Errorf("%w: %s (%s)", errUpstreamAuth, msg, resp.ErrorName())
+		}
+
+		respDoc := resp.Document()
+
+		convID, _ := respDoc.Get("conversationId").(int32)
+		done, _ := respDoc.Get("done").(bool)
+		serverPayload, _ := respDoc.Get("payload").(wirebson.Binary)
+
+		if !conv.Done() {
+			if payload, err = conv.Step(string(serverPayload.B)); err != nil {
+				return fmt.Errorf("%w: %w", errUpstreamAuth, err)
+			}
+		}
+
+		if done {
+			if !conv.Valid() {
+				return fmt.Errorf("%w: invalid server signature", errUpstreamAuth)
+			}
+
+			c.l.DebugContext(ctx, "Authenticated upstream connection")
+
+			return nil
+		}
+
+		doc = wirebson.MustDocument(
+			"saslContinue", int32(1),
+			"conversationId", convID,
+			"payload", wirebson.Binary{B: []byte(payload)},
+			"$db", creds.DB,
+		)
+	}
+}
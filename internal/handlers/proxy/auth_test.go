@@ -0,0 +1,71 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadUserMap(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	t.Run("Valid", func(t *testing.T) {
+		t.Parallel()
+
+		file := filepath.Join(dir, "valid.json")
+		b := []byte(`{
+			"alice": {"username": "upstream-alice", "password": "secret"},
+			"bob": {"username": "upstream-bob", "password": "secret", "db": "bob", "mechanism": "SCRAM-SHA-1"}
+		}`)
+		require.NoError(t, os.WriteFile(file, b, 0o666))
+
+		actual, err := loadUserMap(file)
+		require.NoError(t, err)
+
+		expected := map[string]Credentials{
+			"alice": {Username: "upstream-alice", Password: "secret", DB: "admin", Mechanism: "SCRAM-SHA-256"},
+			"bob":   {Username: "upstream-bob", Password: "secret", DB: "bob", Mechanism: "SCRAM-SHA-1"},
+		}
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("NoUsername", func(t *testing.T) {
+		t.Parallel()
+
+		file := filepath.Join(dir, "nousername.json")
+		require.NoError(t, os.WriteFile(file, []byte(`{"alice": {"password": "secret"}}`), 0o666))
+
+		_, err := loadUserMap(file)
+		assert.Error(t, err)
+	})
+
+	t.Run("InvalidMechanism", func(t *testing.T) {
+		t.Parallel()
+
+		file := filepath.Join(dir, "mechanism.json")
+		b := []byte(`{"alice": {"username": "alice", "mechanism": "PLAIN"}}`)
+		require.NoError(t, os.WriteFile(file, b, 0o666))
+
+		_, err := loadUserMap(file)
+		assert.Error(t, err)
+	})
+}
@@ -41,16 +41,17 @@
 type conn struct {
 	// the order of fields is weird to make the struct smaller due to alignment
 
-	c    net.Conn      // protected by m
-	bufr *bufio.Reader // protected by m
-	bufw *bufio.Writer // protected by m
-	l    *slog.Logger
-	m    sync.Mutex
+	c     net.Conn      // protected by m
+	bufr  *bufio.Reader // protected by m
+	bufw  *bufio.Writer // protected by m
+	l     *slog.Logger
+	creds Credentials // used for authentication; zero value for unauthenticated connection
+	m     sync.Mutex
 }
 
-// newConn creates a new connection.
+// newConn creates a new connection, authenticating it with given credentials if they are not zero.
 // Context cancellation stops dialing, but does not affect established connection.
-func newConn(ctx context.Context, opts *NewOpts) (res *conn, err error) {
+func newConn(ctx context.Context, opts *NewOpts, creds Credentials) (res *conn, err error) {
 	host, portS, err := net.SplitHostPort(opts.Addr)
 	if err != nil {
 		err = lazyerrors.Error(err)
@@ -103,9 +104,20 @@ func newConn(ctx context.Context, opts *NewOpts) (res *conn, err error) {
 			slog.String("remote", c.RemoteAddr().String()),
 		),
 
-		c:    c,
-		bufw: bufio.NewWriter(c),
-		bufr: bufio.NewReader(c),
+		c:     c,
+		bufw:  bufio.NewWriter(c),
+		bufr:  bufio.NewReader(c),
+		creds: creds,
+	}
+
+	if creds.Username == "" {
+		return
+	}
+
+	if err = res.authenticate(ctx, creds); err != nil {
+		res.close()
+		res = nil
+		err = lazyerrors.Error(err)
 	}
 
 	return
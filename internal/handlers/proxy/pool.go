@@ -0,0 +1,532 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/AlekSi/lazyerrors"
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+)
+
+// errPoolClosed is returned when the pool is closed.
+var errPoolClosed = errors.New("proxy pool is closed")
+
+// pinnedCommands contains commands that change the state of the upstream connection,
+// so the client connection should use a dedicated upstream connection from then on.
+var pinnedCommands = map[string]struct{}{
+	"abortTransaction":  {},
+	"authenticate":      {},
+	"commitTransaction": {},
+	"logout":            {},
+	"saslContinue":      {},
+	"saslStart":         {},
+}
+
+// pooledCursor represents a cursor created on a pooled connection.
+type pooledCursor struct {
+	c  *conn
+	ci *conninfo.ConnInfo
+}
+
+// pool is a bounded pool of upstream connections shared by client connections
+// that send only stateless commands.
+//
+// Each pooled connection handles one request at a time.
+// Cursors created on a pooled connection stay bound to it until they are exhausted or killed.
+//
+//nolint:vet // for readability
+type pool struct {
+	opts  *NewOpts
+	creds Credentials
+
+	m        sync.Mutex
+	conns    map[*conn]struct{}      // protected by m
+	idle     []*conn                 // protected by m
+	dialing  int                     // protected by m
+	released chan struct{}           // protected by m; closed and replaced when a connection is released
+	cursors  map[int64]*pooledCursor // protected by m
+	closed   bool                    // protected by m
+
+	waits        prometheus.Counter
+	waitTimeouts prometheus.Counter
+	waitDuration prometheus.Histogram
+}
+
+// newPool creates a new pool of connections authenticated with given credentials.
+// No actual connections are established.
+func newPool(opts *NewOpts, creds Credentials) *pool {
+	return &pool{
+		opts:     opts,
+		creds:    creds,
+		conns:    map[*conn]struct{}{},
+		released: make(chan struct{}),
+		cursors:  map[int64]*pooledCursor{},
+
+		waits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "pool_waits_total",
+			Help:      "Total number of times a request waited for a pooled connection.",
+		}),
+		waitTimeouts: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "pool_wait_timeouts_total",
+			Help:      "Total number of times a request timed out waiting for a pooled connection.",
+		}),
+		waitDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "pool_wait_duration_seconds",
+			Help:      "Time spent waiting for a pooled connection in seconds.",
+			Buckets:   []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30},
+		}),
+	}
+}
+
+// pinned returns true if the given request changes the state of the upstream connection
+// and can't be sent over a shared pooled connection.
+func pinned(doc *wirebson.Document) bool {
+	command := doc.Command()
+
+	if _, ok := pinnedCommands[command]; ok {
+		return true
+	}
+
+	switch command {
+	case "hello", "isMaster", "ismaster":
+		if doc.Get("speculativeAuthenticate") != nil {
+			return true
+		}
+	}
+
+	// multi-document transactions;
+	// retryable writes also have txnNumber, but they don't need a dedicated connection
+	if doc.Get("startTransaction") != nil {
+		return true
+	}
+
+	return doc.Get("txnNumber") != nil && doc.Get("autocommit") == false
+}
+
+// handle sends a single request over a pooled connection.
+//
+// getMore and killCursors requests are sent over the connection that created the cursor.
+func (p *pool) handle(ctx context.Context, req *middleware.Request) (resp *middleware.Response, err error) {
+	ctx, span := otel.Tracer("").Start(
+		ctx,
+		"proxy.pool.handle",
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+	)
+
+	defer func() {
+		if err == nil {
+			span.SetStatus(otelcodes.Ok, "")
+		} else {
+			span.SetStatus(otelcodes.Error, "")
+			span.RecordError(err)
+		}
+
+		span.End()
+	}()
+
+	doc := req.Document()
+	command := doc.Command()
+	cursorIDs := requestCursorIDs(doc)
+
+	var want *conn
+
+	if len(cursorIDs) > 0 {
+		p.m.Lock()
+		if pc := p.cursors[cursorIDs[0]]; pc != nil {
+			want = pc.c
+		}
+		p.m.Unlock()
+	}
+
+	c, err := p.acquire(ctx, want)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			resp = middleware.ResponseErr(req, mongoerrors.New(
+				mongoerrors.ErrExceededTimeLimit,
+				"Couldn't get a connection within the time limit of "+p.opts.PoolWaitTimeout.String(),
+			))
+			err = nil
+
+			return
+		}
+
+		err = lazyerrors.Error(err)
+
+		return
+	}
+
+	resp, err = c.handle(ctx, req)
+	if err != nil {
+		p.discard(c)
+		err = lazyerrors.Error(err)
+
+		return
+	}
+
+	p.trackCursors(conninfo.Get(ctx), c, command, cursorIDs, resp)
+	p.release(c)
+
+	return
+}
+
+// acquire returns an exclusively used connection from the pool, establishing it if necessary.
+//
+// If want is not nil, it waits for that connection to become idle,
+// unless it was discarded in the meantime.
+func (p *pool) acquire(ctx context.Context, want *conn) (*conn, error) {
+	var waitStart time.Time
+
+	if t := p.opts.PoolWaitTimeout; t > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t)
+
+		defer cancel()
+	}
+
+	for {
+		p.m.Lock()
+
+		if p.closed {
+			p.m.Unlock()
+			return nil, lazyerrors.Error(errPoolClosed)
+		}
+
+		if want != nil {
+			if _, ok := p.conns[want]; !ok {
+				want = nil
+			}
+		}
+
+		var c *conn
+
+		switch {
+		case want != nil:
+			if i := slices.Index(p.idle, want); i >= 0 {
+				p.idle = slices.Delete(p.idle, i, i+1)
+				c = want
+			}
+
+		case len(p.idle) > 0:
+			c = p.idle[len(p.idle)-1]
+			p.idle = p.idle[:len(p.idle)-1]
+
+		case len(p.conns)+p.dialing < p.opts.PoolSize:
+			p.dialing++
+			p.m.Unlock()
+
+			p.observeWait(waitStart)
+
+			return p.dial(ctx)
+		}
+
+		if c != nil {
+			p.m.Unlock()
+			p.observeWait(waitStart)
+
+			return c, nil
+		}
+
+		released := p.released
+		p.m.Unlock()
+
+		if waitStart.IsZero() {
+			waitStart = time.Now()
+			p.waits.Inc()
+		}
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				p.waitTimeouts.Inc()
+			}
+
+			p.observeWait(waitStart)
+
+			return nil, lazyerrors.Error(ctx.Err())
+		}
+	}
+}
+
+// dial establishes a new pooled connection.
+// The caller should increment dialing counter before calling it.
+func (p *pool) dial(ctx context.Context) (*conn, error) {
+	c, err := newConn(ctx, p.opts, p.creds)
+
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	p.dialing--
+
+	if err != nil {
+		p.notify()
+		return nil, lazyerrors.Error(err)
+	}
+
+	if p.closed {
+		c.close()
+		return nil, lazyerrors.Error(errPoolClosed)
+	}
+
+	p.conns[c] = struct{}{}
+
+	return c, nil
+}
+
+// observeWait records the time spent waiting for a connection, if any.
+func (p *pool) observeWait(waitStart time.Time) {
+	if !waitStart.IsZero() {
+		p.waitDuration.Observe(time.Since(waitStart).Seconds())
+	}
+}
+
+// release returns the connection to the pool.
+func (p *pool) release(c *conn) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.closed {
+		c.close()
+		return
+	}
+
+	p.idle = append(p.idle, c)
+	p.notify()
+}
+
+// discard closes the broken connection and removes it from the pool together with its cursors.
+func (p *pool) discard(c *conn) {
+	c.close()
+
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	delete(p.conns, c)
+
+	for id, pc := range p.cursors {
+		if pc.c == c {
+			delete(p.cursors, id)
+		}
+	}
+
+	p.notify()
+}
+
+// notify wakes up all waiters.
+// The caller should hold the lock.
+func (p *pool) notify() {
+	close(p.released)
+	p.released = make(chan struct{})
+}
+
+// trackCursors updates cursor to connection mapping using the request and response.
+func (p *pool) trackCursors(ci *conninfo.ConnInfo, c *conn, command string, reqIDs []int64, resp *middleware.Response) {
+	respID := responseCursorID(resp)
+
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	switch command {
+	case "getMore", "killCursors":
+		// exhausted, killed, or not found cursors
+		for _, id := range reqIDs {
+			if id != respID {
+				delete(p.cursors, id)
+			}
+		}
+	}
+
+	if respID == 0 {
+		return
+	}
+
+	if _, ok := p.cursors[respID]; !ok {
+		p.cursors[respID] = &pooledCursor{c: c, ci: ci}
+	}
+}
+
+// hasCursor returns true if any of the given cursors was created by the given client connection
+// on a pooled connection.
+func (p *pool) hasCursor(ci *conninfo.ConnInfo, ids []int64) bool {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	for _, id := range ids {
+		if pc := p.cursors[id]; pc != nil && pc.ci == ci {
+			return true
+		}
+	}
+
+	return false
+}
+
+// forget removes all cursors created by the given client connection.
+// Upstream cursors are not killed; they time out there.
+func (p *pool) forget(ci *conninfo.ConnInfo) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	for id, pc := range p.cursors {
+		if pc.ci == ci {
+			delete(p.cursors, id)
+		}
+	}
+}
+
+// close closes all pooled connections.
+// Connections that are in use are closed when released.
+func (p *pool) close() {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	p.closed = true
+
+	for _, c := range p.idle {
+		c.close()
+	}
+
+	p.idle = nil
+	clear(p.cursors)
+	p.notify()
+}
+
+// requestCursorIDs returns cursor IDs used by getMore and killCursors requests.
+func requestCursorIDs(doc *wirebson.Document) []int64 {
+	switch command := doc.Command(); command {
+	case "getMore":
+		if id, ok := doc.Get(command).(int64); ok {
+			return []int64{id}
+		}
+
+	case "killCursors":
+		arr, ok := doc.Get("cursors").(wirebson.AnyArray)
+		if !ok {
+			return nil
+		}
+
+		a, err := arr.Decode()
+		if err != nil {
+			return nil
+		}
+
+		var res []int64
+
+		for v := range a.Values() {
+			if id, ok := v.(int64); ok {
+				res = append(res, id)
+			}
+		}
+
+		return res
+	}
+
+	return nil
+}
+
+// responseCursorID returns the cursor ID from the response, or zero.
+func responseCursorID(resp *middleware.Response) int64 {
+	if !resp.OK() {
+		return 0
+	}
+
+	v, ok := resp.Document().Get("cursor").(wirebson.AnyDocument)
+	if !ok {
+		return 0
+	}
+
+	cursor, err := v.Decode()
+	if err != nil {
+		return 0
+	}
+
+	id, _ := cursor.Get("id").(int64)
+
+	return id
+}
+
+// stats returns the current numbers of all pooled connections, idle connections, and bound cursors.
+func (p *pool) stats() (int, int, int) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	return len(p.conns), len(p.idle), len(p.cursors)
+}
+
+// Describe implements [prometheus.Collector].
+func (p *pool) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(p, ch)
+}
+
+// Collect implements [prometheus.Collector].
+func (p *pool) Collect(ch chan<- prometheus.Metric) {
+	total, idle, cursors := p.stats()
+
+	desc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "pool_conns"),
+		"The current number of pooled connections.",
+		[]string{"state"}, nil,
+	)
+
+	ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(idle), "idle")
+	ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(total-idle), "busy")
+
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "pool_conns_max"),
+			"The maximum number of pooled connections.",
+			nil, nil,
+		),
+		prometheus.GaugeValue,
+		float64(p.opts.PoolSize),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "pool_cursors"),
+			"The current number of cursors bound to pooled connections.",
+			nil, nil,
+		),
+		prometheus.GaugeValue,
+		float64(cursors),
+	)
+
+	p.waits.Collect(ch)
+	p.waitTimeouts.Collect(ch)
+	p.waitDuration.Collect(ch)
+}
+
+// check interfaces
+var (
+	_ prometheus.Collector = (*pool)(nil)
+)
@@ -0,0 +1,110 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"testing"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
+)
+
+func TestPinned(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		doc      *wirebson.Document
+		expected bool
+	}{
+		"Find": {
+			doc:      wirebson.MustDocument("find", "coll", "$db", "db"),
+			expected: false,
+		},
+		"FindSession": {
+			doc:      wirebson.MustDocument("find", "coll", "lsid", wirebson.MustDocument(), "$db", "db"),
+			expected: false,
+		},
+		"Hello": {
+			doc:      wirebson.MustDocument("hello", int32(1), "$db", "admin"),
+			expected: false,
+		},
+		"HelloSpeculativeAuthenticate": {
+			doc:      wirebson.MustDocument("hello", int32(1), "speculativeAuthenticate", wirebson.MustDocument()),
+			expected: true,
+		},
+		"SASLStart": {
+			doc:      wirebson.MustDocument("saslStart", int32(1), "$db", "admin"),
+			expected: true,
+		},
+		"RetryableWrite": {
+			doc:      wirebson.MustDocument("insert", "coll", "lsid", wirebson.MustDocument(), "txnNumber", int64(1), "$db", "db"),
+			expected: false,
+		},
+		"StartTransaction": {
+			doc: wirebson.MustDocument(
+				"insert", "coll", "txnNumber", int64(1), "startTransaction", true, "autocommit", false, "$db", "db",
+			),
+			expected: true,
+		},
+		"Transaction": {
+			doc:      wirebson.MustDocument("insert", "coll", "txnNumber", int64(1), "autocommit", false, "$db", "db"),
+			expected: true,
+		},
+		"CommitTransaction": {
+			doc:      wirebson.MustDocument("commitTransaction", int32(1), "$db", "admin"),
+			expected: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, pinned(tc.doc))
+		})
+	}
+}
+
+func TestRequestCursorIDs(t *testing.T) {
+	t.Parallel()
+
+	doc := wirebson.MustDocument("getMore", int64(42), "collection", "coll", "$db", "db")
+	assert.Equal(t, []int64{42}, requestCursorIDs(doc))
+
+	doc = wirebson.MustDocument(
+		"killCursors", "coll",
+		"cursors", wirebson.MustArray(int64(1), int64(2)),
+		"$db", "db",
+	)
+	assert.Equal(t, []int64{1, 2}, requestCursorIDs(doc))
+
+	doc = wirebson.MustDocument("find", "coll", "$db", "db")
+	assert.Nil(t, requestCursorIDs(doc))
+}
+
+func TestPoolHasCursor(t *testing.T) {
+	t.Parallel()
+
+	p := newPool(new(NewOpts), Credentials{})
+
+	ci, other := conninfo.New(), conninfo.New()
+	p.cursors[42] = &pooledCursor{c: new(conn), ci: ci}
+
+	assert.True(t, p.hasCursor(ci, []int64{42}))
+	assert.True(t, p.hasCursor(ci, []int64{1, 42}))
+	assert.False(t, p.hasCursor(ci, []int64{1}))
+	assert.False(t, p.hasCursor(ci, nil))
+	assert.False(t, p.hasCursor(other, []int64{42}), "cursors of other client connections should not be used")
+}
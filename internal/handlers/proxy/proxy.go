@@ -17,8 +17,10 @@
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"sync"
+	"time"
 
 	"github.com/AlekSi/lazyerrors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -28,6 +30,8 @@
 
 	"github.com/FerretDB/FerretDB/v2/internal/clientconn/conninfo"
 	"github.com/FerretDB/FerretDB/v2/internal/handler/middleware"
+	"github.com/FerretDB/FerretDB/v2/internal/mongoerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
 	"github.com/FerretDB/FerretDB/v2/internal/util/must"
 )
 
@@ -40,12 +44,26 @@
 
 // Handler handles requests by sending them to another wire protocol compatible service.
 //
+// By default, each client connection uses a dedicated upstream connection.
+// If pooling is enabled, client connections that send only stateless commands
+// share a bounded pool of upstream connections;
+// a client connection is pinned to a dedicated upstream connection
+// once it authenticates or starts a transaction.
+//
+// If upstream credentials are configured, the handler authenticates upstream connections itself.
+// In that case, clients authenticate against FerretDB (see [middleware.ProxyMode]),
+// and FerretDB users are mapped to upstream users.
+//
 //nolint:vet // for readability
 type Handler struct {
-	opts *NewOpts
+	opts         *NewOpts
+	pool         *pool // nil if pooling is disabled
+	defaultCreds Credentials
+	userMap      map[string]Credentials // FerretDB username -> upstream credentials
 
-	connsRW  sync.RWMutex
-	connsGet map[*conninfo.ConnInfo]func() (*conn, error)
+	connsRW   sync.RWMutex
+	connsGet  map[*conninfo.ConnInfo]func() (*conn, error)
+	connsSeen map[*conninfo.ConnInfo]struct{} // client connections with registered close callback
 
 	runM   sync.Mutex
 	runCtx context.Context
@@ -61,6 +79,17 @@ type NewOpts struct {
 	TLSKeyFile  string
 	TLSCAFile   string
 
+	PoolSize        int           // zero value disables pooling
+	PoolWaitTimeout time.Duration // zero value means no timeout
+
+	// Upstream authentication
+	Auth          bool   // require client authentication for non-anonymous commands
+	Username      string // empty value disables upstream authentication with default credentials
+	Password      string
+	AuthDB        string // defaults to "admin"
+	AuthMechanism string // defaults to "SCRAM-SHA-256"
+	UserMapFile   string // empty value disables mapping of FerretDB users to upstream users
+
 	L *slog.Logger
 }
 
@@ -71,10 +100,42 @@ func New(opts *NewOpts) (*Handler, error) {
 	must.NotBeZero(opts.Addr)
 	must.NotBeZero(opts.L)
 
-	return &Handler{
-		opts:     opts,
-		connsGet: map[*conninfo.ConnInfo]func() (*conn, error){},
-	}, nil
+	if opts.PoolSize < 0 {
+		return nil, lazyerrors.Errorf("invalid pool size %d", opts.PoolSize)
+	}
+
+	h := &Handler{
+		opts: opts,
+		defaultCreds: Credentials{
+			Username:  opts.Username,
+			Password:  opts.Password,
+			DB:        opts.AuthDB,
+			Mechanism: opts.AuthMechanism,
+		},
+		connsGet:  map[*conninfo.ConnInfo]func() (*conn, error){},
+		connsSeen: map[*conninfo.ConnInfo]struct{}{},
+	}
+
+	if opts.Username == "" {
+		h.defaultCreds = Credentials{}
+	}
+
+	if err := h.defaultCreds.validate(); err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	if opts.UserMapFile != "" {
+		var err error
+		if h.userMap, err = loadUserMap(opts.UserMapFile); err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+	}
+
+	if opts.PoolSize > 0 {
+		h.pool = newPool(opts, h.defaultCreds)
+	}
+
+	return h, nil
 }
 
 // Run implements [middleware.Handler].
@@ -96,9 +157,14 @@ func (h *Handler) Run(ctx context.Context) {
 		}
 	}
 	h.connsGet = nil
+	h.connsSeen = nil
 
 	h.connsRW.Unlock()
 
+	if h.pool != nil {
+		h.pool.close()
+	}
+
 	h.opts.L.InfoContext(ctx, "Stopped")
 }
 
@@ -143,7 +209,36 @@ func (h *Handler) Handle(ctx context.Context, req *middleware.Request) (resp *mi
 
 	ci := conninfo.Get(ctx)
 
-	c, err := h.getConn(ctx, ci)
+	creds, resp := h.credentials(ctx, req)
+	if resp != nil {
+		return
+	}
+
+	defer func() {
+		if !errors.Is(err, errUpstreamAuth) {
+			return
+		}
+
+		h.opts.L.WarnContext(ctx, "Failed to authenticate upstream connection", logging.Error(err))
+
+		resp = middleware.ResponseErr(req, mongoerrors.New(
+			mongoerrors.ErrAuthenticationFailed,
+			"Authentication to upstream failed.",
+		))
+		err = nil
+	}()
+
+	h.watchConn(ci)
+
+	if h.pool != nil && !h.pinned(ci, req, creds) {
+		if resp, err = h.pool.handle(ctx, req); err != nil {
+			err = lazyerrors.Error(err)
+		}
+
+		return
+	}
+
+	c, err := h.getConn(ctx, ci, creds)
 	if err != nil {
 		err = lazyerrors.Error(err)
 		return
@@ -157,9 +252,38 @@ func (h *Handler) Handle(ctx context.Context, req *middleware.Request) (resp *mi
 	return
 }
 
+// pinned returns true if the given client connection should use a dedicated upstream connection,
+// either because it already does, because the request changes the upstream connection state,
+// or because it uses upstream credentials different from the pool's.
+//
+// Requests for cursors created on pooled connections are never pinned,
+// even if the client connection got a dedicated upstream connection after that.
+func (h *Handler) pinned(ci *conninfo.ConnInfo, req *middleware.Request, creds Credentials) bool {
+	if h.pool.hasCursor(ci, requestCursorIDs(req.Document())) {
+		return false
+	}
+
+	if creds != h.defaultCreds {
+		return true
+	}
+
+	h.connsRW.RLock()
+	cg := h.connsGet[ci]
+	h.connsRW.RUnlock()
+
+	if cg != nil {
+		return true
+	}
+
+	return pinned(req.Document())
+}
+
 // getConn returns a proxy connection for the given client connection info,
 // establishing it if necessary, while preserving one-to-one mapping.
-func (h *Handler) getConn(ctx context.Context, ci *conninfo.ConnInfo) (c *conn, err error) {
+//
+// If the existing connection was authenticated with different credentials
+// (for example, because the client authenticated after connecting), it is replaced.
+func (h *Handler) getConn(ctx context.Context, ci *conninfo.ConnInfo, creds Credentials) (c *conn, err error) {
 	ctx, span := otel.Tracer("").Start(
 		ctx,
 		"proxy.Handler.getConn",
@@ -185,9 +309,14 @@ func (h *Handler) getConn(ctx context.Context, ci *conninfo.ConnInfo) (c *conn,
 	if cg != nil {
 		if c, err = cg(); err != nil {
 			err = lazyerrors.Error(err)
+			return
 		}
 
-		return
+		if c.creds == creds {
+			return
+		}
+
+		h.replaceConn(ci, c)
 	}
 
 	// slow path
@@ -206,13 +335,11 @@ func (h *Handler) getConn(ctx context.Context, ci *conninfo.ConnInfo) (c *conn,
 	}
 
 	cg = sync.OnceValues(func() (*conn, error) {
-		oc, oerr := newConn(ctx, h.opts)
+		oc, oerr := newConn(ctx, h.opts, creds)
 		if oerr != nil {
 			return nil, lazyerrors.Error(oerr)
 		}
 
-		ci.OnClose(h.closeConn)
-
 		return oc, nil
 	})
 
@@ -227,11 +354,55 @@ func (h *Handler) getConn(ctx context.Context, ci *conninfo.ConnInfo) (c *conn,
 	return
 }
 
-// closeConn closes the proxy connection for the given client connection info.
+// watchConn registers a callback that closes the proxy connection
+// when the given client connection is closed.
+// The callback is registered only once per client connection.
+func (h *Handler) watchConn(ci *conninfo.ConnInfo) {
+	h.connsRW.RLock()
+	_, seen := h.connsSeen[ci]
+	h.connsRW.RUnlock()
+
+	if seen {
+		return
+	}
+
+	h.connsRW.Lock()
+	defer h.connsRW.Unlock()
+
+	if _, seen = h.connsSeen[ci]; seen {
+		return
+	}
+
+	h.connsSeen[ci] = struct{}{}
+	ci.OnClose(h.closeConn)
+}
+
+// replaceConn closes the given proxy connection for the given client connection info
+// if it is still used, so the next call to getConn establishes a new one.
+func (h *Handler) replaceConn(ci *conninfo.ConnInfo, c *conn) {
+	h.connsRW.Lock()
+	defer h.connsRW.Unlock()
+
+	if cg := h.connsGet[ci]; cg != nil {
+		if oc, _ := cg(); oc == c {
+			delete(h.connsGet, ci)
+			c.close()
+		}
+	}
+}
+
+// closeConn closes the proxy connection for the given client connection info
+// and forgets cursors it created on pooled connections.
 func (h *Handler) closeConn(ci *conninfo.ConnInfo) {
+	if h.pool != nil {
+		h.pool.forget(ci)
+	}
+
 	h.connsRW.Lock()
 	defer h.connsRW.Unlock()
 
+	delete(h.connsSeen, ci)
+
 	if cg := h.connsGet[ci]; cg != nil {
 		delete(h.connsGet, ci)
 
@@ -241,6 +412,40 @@ func (h *Handler) closeConn(ci *conninfo.ConnInfo) {
 	}
 }
 
+// Stats represents the state of upstream connections.
+type Stats struct {
+	Addr      string // upstream address
+	Dedicated int32  // connections dedicated to client connections
+
+	// Pooled connections; all zeros if pooling is disabled.
+	Pooled  int32
+	Idle    int32
+	Max     int32
+	Cursors int32 // cursors bound to pooled connections
+}
+
+// Stats returns the current state of upstream connections.
+func (h *Handler) Stats() *Stats {
+	res := &Stats{
+		Addr: h.opts.Addr,
+	}
+
+	h.connsRW.RLock()
+	res.Dedicated = int32(len(h.connsGet))
+	h.connsRW.RUnlock()
+
+	if h.pool != nil {
+		total, idle, cursors := h.pool.stats()
+
+		res.Pooled = int32(total)
+		res.Idle = int32(idle)
+		res.Max = int32(h.opts.PoolSize)
+		res.Cursors = int32(cursors)
+	}
+
+	return res
+}
+
 // Describe implements [prometheus.Collector].
 func (h *Handler) Describe(ch chan<- *prometheus.Desc) {
 	prometheus.DescribeByCollect(h, ch)
@@ -248,6 +453,10 @@ func (h *Handler) Describe(ch chan<- *prometheus.Desc) {
 
 // Collect implements [prometheus.Collector].
 func (h *Handler) Collect(ch chan<- prometheus.Metric) {
+	if h.pool != nil {
+		h.pool.Collect(ch)
+	}
+
 	h.connsRW.RLock()
 	defer h.connsRW.RUnlock()
 
@@ -257,7 +466,7 @@ func (h *Handler) Collect(ch chan<- prometheus.Metric) {
 	ch <- prometheus.MustNewConstMetric(
 		prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, subsystem, "conns"),
-			"The current number of connections.",
+			"The current number of dedicated (not pooled) connections.",
 			nil, nil,
 		),
 		prometheus.GaugeValue,
@@ -162,12 +162,28 @@ func setupMCP(tb testing.TB, ctx context.Context) string {
 		PostgreSQLURL:          testutil.PostgreSQLURL(tb),
 		Auth:                   false,
 		ReplSetName:            "",
+		ReadOnly:               false,
 		SessionCleanupInterval: 0,
+		TTLMonitorInterval:     0,
+		TTLMonitorBatchSize:    0,
+		DefaultMaxTime:         0,
+		SlowOpThreshold:        0,
+		MaxRequests:            0,
+		MaxUserRequests:        0,
+		AdmissionMaxWait:       0,
 
 		ProxyAddr:        "",
 		ProxyTLSCertFile: "",
 		ProxyTLSKeyFile:  "",
 		ProxyTLSCAFile:   "",
+		ProxyPoolSize:    0,
+		ProxyPoolWait:    0,
+
+		ProxyUsername:      "",
+		ProxyPassword:      "",
+		ProxyAuthDB:        "",
+		ProxyAuthMechanism: "",
+		ProxyUserMapFile:   "",
 
 		TCPAddr:        "127.0.0.1:0",
 		UnixAddr:       "",
@@ -175,9 +191,13 @@ func setupMCP(tb testing.TB, ctx context.Context) string {
 		TLSCertFile:    "",
 		TLSKeyFile:     "",
 		TLSCAFile:      "",
+		MaxConnections: 0,
 		Mode:           middleware.NormalMode,
 		TestRecordsDir: "",
 
+		RoutingFile:           "",
+		RoutingReloadInterval: 0,
+
 		DataAPIAddr: "",
 
 		MCPAddr: "127.0.0.1:0",
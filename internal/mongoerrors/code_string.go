@@ -66,6 +66,7 @@ func _() {
 	_ = x[ErrMaxSubPipelineDepthExceeded-232]
 	_ = x[ErrNotImplemented-238]
 	_ = x[ErrConversionFailure-241]
+	_ = x[ErrExceededTimeLimit-262]
 	_ = x[ErrOperationNotSupportedInTransaction-263]
 	_ = x[ErrIndexBuildAborted-276]
 	_ = x[ErrUnableToFindIndex-291]
@@ -77,6 +78,7 @@ func _() {
 	_ = x[ErrNotWritablePrimary-10107]
 	_ = x[ErrBsonObjectTooLarge-10334]
 	_ = x[ErrDuplicateKey-11000]
+	_ = x[ErrInterrupted-11601]
 	_ = x[ErrBackgroundOperationInProgressForNamespace-12587]
 	_ = x[ErrLocation13026-13026]
 	_ = x[ErrLocation13027-13027]
@@ -561,7 +563,7 @@ func _() {
 	_ = x[ErrLocation8993000-8993000]
 }
 
-const _Code_name = "UnsetInternalErrorBadValueGraphContainsCycleFailedToParseUserNotFoundUnsupportedFormatUnauthorizedTypeMismatchOverflowInvalidLengthProtocolErrorAuthenticationFailedIllegalOperationAlreadyInitializedNamespaceNotFoundIndexNotFoundPathNotViableRoleNotFoundCannotBackfillArrayConflictingUpdateOperatorsCursorNotFoundNamespaceExistsMaxTimeMSExpiredDollarPrefixedFieldNameCanNotBeTypeArrayNotSingleValueFieldLocation55EmptyFieldNameDottedFieldNameCommandNotFoundShardKeyNotFoundImmutableFieldCannotCreateIndexIndexAlreadyExistsInvalidOptionsInvalidNamespaceIndexOptionsConflictIndexKeySpecsConflictOperationFailedNotExactValueFieldCommandNotSupportedNamespaceNotShardedDocumentFailedValidationCursorInUseExceededMemoryLimitDurationOverflowViewDepthLimitExceededCommandNotSupportedOnViewOptionNotSupportedOnViewAmbiguousIndexKeyPatternClientMetadataCannotBeMutatedInvalidIndexSpecificationOptionInvalidUUIDQueryFeatureNotAllowedMaxSubPipelineDepthExceededNotImplementedConversionFailureOperationNotSupportedInTransactionIndexBuildAbortedUnableToFindIndexMechanismUnavailableUnsupportedOpQueryCommandCollectionUUIDMismatchUserCountLimitExceededLocation10065NotWritablePrimaryBsonObjectTooLargeDuplicateKeyBackgroundOperationInProgressForNamespaceLocation13026Location13027Location13068Location13103Location13111MergeStageNoMatchingDocumentDbAlreadyExistsLocation13548Location15947Location15952Location15955Location15957Location15958Location15959Location15972Location15976Location15981Location15998Location16004Location16006Location16007Location16020Location16034Location16035Location16410Location16411Location16433DollarAddNumericOrDateTypesDollarModByZeroProhibitedDollarModOnlyNumericDollarAddOnlyOneDateLocation16702Location16747Location16748Location16749Location16755Location16764HashedIndexDoNotSupportArrayValuesLocation16800Location16801Location16804Location16874Location16875Location16876Location16878Location16879Location16880Location16882Location16883Location16979Location16990Location16994Location17040Location17041Location17042Location17043Location17044Location17045Location17046Location17047Location17048Location17049Location17053DollarCondMissingIfParameterDollarCondMissingThenParameterDollarCondMissingElseParameterDollarCondBadParameterDollarSizeRequiresArrayExactlyOneTextIndexLocation17261Location17276Location17308Location17310Location17385DocumentAfterUpdateLargerThanMaxSizeDocumentToUpsertLargerThanMaxSizeLocation18533Location18534Location18535Location18536Location18537Location18628Location18629Location28625Location28646Location28647Location28648Location28650Location28651Location28656Location28657Location28664RangeArgumentExpressionArgsOutOfRangeDollarAbsCantTakeLongMinValueArrayOperatorElemAtFirstArgMustBeArrayDollarArrayElemAtSecondArgArgMustBeNumericDollarArrayElemAtSecondArgArgMustBe32BitDollarSqrtGreaterOrEqualToZeroDollarSliceInvalidInputDollarSliceInvalidTypeSecondArgDollarSliceInvalidValueSecondArgDollarSliceInvalidTypeThirdArgDollarSliceInvalidValueThirdArgDollarSliceInvalidSignThirdArgLocation28745Location28746Location28747Location28748Location28749DollarLogArgumentMustBeNumericDollarLogBaseMustBeNumericDollarLogNumberMustBePositiveDollarLogBaseMustBeGreaterThanOneDollarLog10MustBePositiveNumberDollarPowBaseMustBeNumericDollarPowExponentMustBeNumericDollarPowExponentInvalidForZeroBaseLocation28765DollarLnMustBePositiveNumberLocation28769Location28803Location28808Location28809Location28810Location28811Location28812Location28818Location28822Location31002Location31022Location31023Location31024KeyCannotContainNullByteLocation31034Location31095Location31109Location31119Location31120Location31138Location31170Location31249Location31250Location31253Location31254Location31256Location31271Location31276Location31308Location31319Location31320Location31321Location31325Location31393Location31395Location31441Location31465Location34435Location34443Location34444Location34445Location34446Location34447Location34448Location34449Location34450Location34451Location34452Location34453Location34454Location34455Location34460Location34461Location34462Location34463Location34464Location34465Location34466Location34467Location34468Location34471Location34473DollarSwitchRequiresObjectDollarSwitchRequiresArrayForBranchesDollarSwitchRequiresObjectForEachBranchDollarSwitchUnknownArgumentForBranchDollarSwitchRequiresCaseExpressionForBranchDollarSwitchRequiresThenExpressionForBranchDollarSwitchNoMatchingBranchAndNoDefaultDollarSwitchBadArgumentDollarSwitchRequiresAtLeastOneBranchLocation40075Location40076Location40077Location40078Location40079Location40080DollarInRequiresArrayLocation40085Location40086Location40087Location40090Location40091Location40092Location40093Location40094Location40096Location40097Location40100Location40101Location40102Location40103Location40104Location40105Location40147Location40156Location40158Location40160Location40169Location40177Location40181Location40185Location40191Location40192Location40193Location40194Location40195Location40196Location40197Location40198Location40199Location40200Location40201Location40202Location40218Location40228Location40229Location40234Location40235Location40236Location40237Location40238Location40239Location40240Location40241Location40242Location40243Location40244Location40245Location40246Location40257Location40258Location40260Location40261Location40272Location40319Location40321Location40323UnrecognizedCommandLocation40352DollarArrayToObjectRequiresArrayDollarObjectToArrayRequiresObjectDollarArrayToObjectAllMustBeObjectsDollarArrayToObjectIncorrectNumberOfKeysDollarArrayToObjectRequiresObjectWithKAndVDollarArrayToObjectObjectKeyMustBeStringDollarArrayToObjectArrayKeyMustBeStringDollarArrayToObjectAllMustBeArraysDollarArrayToObjectIncorrectArrayLengthDollarArrayToObjectBadInputTypeFormatDollarMergeObjectsInvalidTypeLocation40414UnknownBsonFieldLocation40485Location40489Location40515Location40516Location40517Location40518Location40519Location40520Location40521Location40522Location40523Location40524Location40525Location40533Location40535Location40536Location40539Location40540Location40541Location40542Location40600Location40601Location40602Location40603Location40621ChangeStreamBadResumeTokenLocation40684InsufficientPrivilegeLocation50687Location50692Location50694Location50695Location50696Location50699Location50700Location50723Location50752Location50759Location50840Location50989Location51003Location51024Location51044Location51045Location51047Location51074Location51075DollarRoundOverflowInt64DollarRoundFirstArgMustBeNumericDollarRoundPrecisionMustBeIntegralDollarRoundPrecisionOutOfRangeLocation51091Location51103Location51104Location51105Location51106Location51107Location51108Location51109Location51110Location51111Location51132Location51134Location51151Location51156Location51178Location51183Location51185Location51186Location51187Location51191Location51246Location51247Location51276Location51743Location51744Location51745Location51746Location51747Location51748Location51749Location51750Location51751Location327391Location327392Location605001DollarIfNullRequiresAtLeastTwoArgsLocation2942500Location2942501Location2942502Location2942503Location2942504Location2942505Location2942506DollarRandNonEmptyArgumentLocation3041701Location3041702Location3041703Location3041704IntermediateResultTooLargeDollarSetFieldRequiresObjectDollarSetFieldUnknownArgumentLocation4161102Location4161103Location4161104Location4161105Location4161106Location4161107Location4161108Location4161109Location4341107Location4890500Location4940400Location4940401Location5107200Location5107201Location5166301Location5166302Location5166303Location5166304Location5166305Location5166307Location5166400Location5166401Location5166402Location5166403Location5166404Location5166405Location5166406Location5339900Location5339901Location5339902Location5371601Location5371602Location5371603Location5423900Location5423901Location5423902Location5429413Location5429414Location5429513Location5439007Location5439008Location5439009Location5439010Location5439012Location5439013Location5439014Location5439015Location5439016Location5439017Location5439018Location5490710Location5624900Location5624901Location5626500Location5654600Location5654601Location5654602Location5687301Location5687302Location5687400Location5687401Location5733201Location5733401Location5733402Location5733403Location5733406Location5733408Location5733409Location5739101Location5746102Location5787801Location5787900Location5787901Location5787902Location5787903Location5787906Location5787907Location5787908Location5788001Location5788002Location5788003Location5788004Location5788005Location5788200Location5788604Location5858203Location5860402Location5876900Location5897900Location5946802Location5976500Location6007200Location6045000Location6050106Location6050202Location6050204Location6053600Location6586400Location7429703Location7436100Location7555701Location7555702Location7749501Location7750301Location7750302Location7750303Location8993000"
+const _Code_name = "UnsetInternalErrorBadValueGraphContainsCycleFailedToParseUserNotFoundUnsupportedFormatUnauthorizedTypeMismatchOverflowInvalidLengthProtocolErrorAuthenticationFailedIllegalOperationAlreadyInitializedNamespaceNotFoundIndexNotFoundPathNotViableRoleNotFoundCannotBackfillArrayConflictingUpdateOperatorsCursorNotFoundNamespaceExistsMaxTimeMSExpiredDollarPrefixedFieldNameCanNotBeTypeArrayNotSingleValueFieldLocation55EmptyFieldNameDottedFieldNameCommandNotFoundShardKeyNotFoundImmutableFieldCannotCreateIndexIndexAlreadyExistsInvalidOptionsInvalidNamespaceIndexOptionsConflictIndexKeySpecsConflictOperationFailedNotExactValueFieldCommandNotSupportedNamespaceNotShardedDocumentFailedValidationCursorInUseExceededMemoryLimitDurationOverflowViewDepthLimitExceededCommandNotSupportedOnViewOptionNotSupportedOnViewAmbiguousIndexKeyPatternClientMetadataCannotBeMutatedInvalidIndexSpecificationOptionInvalidUUIDQueryFeatureNotAllowedMaxSubPipelineDepthExceededNotImplementedConversionFailureExceededTimeLimitOperationNotSupportedInTransactionIndexBuildAbortedUnableToFindIndexMechanismUnavailableUnsupportedOpQueryCommandCollectionUUIDMismatchUserCountLimitExceededLocation10065NotWritablePrimaryBsonObjectTooLargeDuplicateKeyInterruptedBackgroundOperationInProgressForNamespaceLocation13026Location13027Location13068Location13103Location13111MergeStageNoMatchingDocumentDbAlreadyExistsLocation13548Location15947Location15952Location15955Location15957Location15958Location15959Location15972Location15976Location15981Location15998Location16004Location16006Location16007Location16020Location16034Location16035Location16410Location16411Location16433DollarAddNumericOrDateTypesDollarModByZeroProhibitedDollarModOnlyNumericDollarAddOnlyOneDateLocation16702Location16747Location16748Location16749Location16755Location16764HashedIndexDoNotSupportArrayValuesLocation16800Location16801Location16804Location16874Location16875Location16876Location16878Location16879Location16880Location16882Location16883Location16979Location16990Location16994Location17040Location17041Location17042Location17043Location17044Location17045Location17046Location17047Location17048Location17049Location17053DollarCondMissingIfParameterDollarCondMissingThenParameterDollarCondMissingElseParameterDollarCondBadParameterDollarSizeRequiresArrayExactlyOneTextIndexLocation17261Location17276Location17308Location17310Location17385DocumentAfterUpdateLargerThanMaxSizeDocumentToUpsertLargerThanMaxSizeLocation18533Location18534Location18535Location18536Location18537Location18628Location18629Location28625Location28646Location28647Location28648Location28650Location28651Location28656Location28657Location28664RangeArgumentExpressionArgsOutOfRangeDollarAbsCantTakeLongMinValueArrayOperatorElemAtFirstArgMustBeArrayDollarArrayElemAtSecondArgArgMustBeNumericDollarArrayElemAtSecondArgArgMustBe32BitDollarSqrtGreaterOrEqualToZeroDollarSliceInvalidInputDollarSliceInvalidTypeSecondArgDollarSliceInvalidValueSecondArgDollarSliceInvalidTypeThirdArgDollarSliceInvalidValueThirdArgDollarSliceInvalidSignThirdArgLocation28745Location28746Location28747Location28748Location28749DollarLogArgumentMustBeNumericDollarLogBaseMustBeNumericDollarLogNumberMustBePositiveDollarLogBaseMustBeGreaterThanOneDollarLog10MustBePositiveNumberDollarPowBaseMustBeNumericDollarPowExponentMustBeNumericDollarPowExponentInvalidForZeroBaseLocation28765DollarLnMustBePositiveNumberLocation28769Location28803Location28808Location28809Location28810Location28811Location28812Location28818Location28822Location31002Location31022Location31023Location31024KeyCannotContainNullByteLocation31034Location31095Location31109Location31119Location31120Location31138Location31170Location31249Location31250Location31253Location31254Location31256Location31271Location31276Location31308Location31319Location31320Location31321Location31325Location31393Location31395Location31441Location31465Location34435Location34443Location34444Location34445Location34446Location34447Location34448Location34449Location34450Location34451Location34452Location34453Location34454Location34455Location34460Location34461Location34462Location34463Location34464Location34465Location34466Location34467Location34468Location34471Location34473DollarSwitchRequiresObjectDollarSwitchRequiresArrayForBranchesDollarSwitchRequiresObjectForEachBranchDollarSwitchUnknownArgumentForBranchDollarSwitchRequiresCaseExpressionForBranchDollarSwitchRequiresThenExpressionForBranchDollarSwitchNoMatchingBranchAndNoDefaultDollarSwitchBadArgumentDollarSwitchRequiresAtLeastOneBranchLocation40075Location40076Location40077Location40078Location40079Location40080DollarInRequiresArrayLocation40085Location40086Location40087Location40090Location40091Location40092Location40093Location40094Location40096Location40097Location40100Location40101Location40102Location40103Location40104Location40105Location40147Location40156Location40158Location40160Location40169Location40177Location40181Location40185Location40191Location40192Location40193Location40194Location40195Location40196Location40197Location40198Location40199Location40200Location40201Location40202Location40218Location40228Location40229Location40234Location40235Location40236Location40237Location40238Location40239Location40240Location40241Location40242Location40243Location40244Location40245Location40246Location40257Location40258Location40260Location40261Location40272Location40319Location40321Location40323UnrecognizedCommandLocation40352DollarArrayToObjectRequiresArrayDollarObjectToArrayRequiresObjectDollarArrayToObjectAllMustBeObjectsDollarArrayToObjectIncorrectNumberOfKeysDollarArrayToObjectRequiresObjectWithKAndVDollarArrayToObjectObjectKeyMustBeStringDollarArrayToObjectArrayKeyMustBeStringDollarArrayToObjectAllMustBeArraysDollarArrayToObjectIncorrectArrayLengthDollarArrayToObjectBadInputTypeFormatDollarMergeObjectsInvalidTypeLocation40414UnknownBsonFieldLocation40485Location40489Location40515Location40516Location40517Location40518Location40519Location40520Location40521Location40522Location40523Location40524Location40525Location40533Location40535Location40536Location40539Location40540Location40541Location40542Location40600Location40601Location40602Location40603Location40621ChangeStreamBadResumeTokenLocation40684InsufficientPrivilegeLocation50687Location50692Location50694Location50695Location50696Location50699Location50700Location50723Location50752Location50759Location50840Location50989Location51003Location51024Location51044Location51045Location51047Location51074Location51075DollarRoundOverflowInt64DollarRoundFirstArgMustBeNumericDollarRoundPrecisionMustBeIntegralDollarRoundPrecisionOutOfRangeLocation51091Location51103Location51104Location51105Location51106Location51107Location51108Location51109Location51110Location51111Location51132Location51134Location51151Location51156Location51178Location51183Location51185Location51186Location51187Location51191Location51246Location51247Location51276Location51743Location51744Location51745Location51746Location51747Location51748Location51749Location51750Location51751Location327391Location327392Location605001DollarIfNullRequiresAtLeastTwoArgsLocation2942500Location2942501Location2942502Location2942503Location2942504Location2942505Location2942506DollarRandNonEmptyArgumentLocation3041701Location3041702Location3041703Location3041704IntermediateResultTooLargeDollarSetFieldRequiresObjectDollarSetFieldUnknownArgumentLocation4161102Location4161103Location4161104Location4161105Location4161106Location4161107Location4161108Location4161109Location4341107Location4890500Location4940400Location4940401Location5107200Location5107201Location5166301Location5166302Location5166303Location5166304Location5166305Location5166307Location5166400Location5166401Location5166402Location5166403Location5166404Location5166405Location5166406Location5339900Location5339901Location5339902Location5371601Location5371602Location5371603Location5423900Location5423901Location5423902Location5429413Location5429414Location5429513Location5439007Location5439008Location5439009Location5439010Location5439012Location5439013Location5439014Location5439015Location5439016Location5439017Location5439018Location5490710Location5624900Location5624901Location5626500Location5654600Location5654601Location5654602Location5687301Location5687302Location5687400Location5687401Location5733201Location5733401Location5733402Location5733403Location5733406Location5733408Location5733409Location5739101Location5746102Location5787801Location5787900Location5787901Location5787902Location5787903Location5787906Location5787907Location5787908Location5788001Location5788002Location5788003Location5788004Location5788005Location5788200Location5788604Location5858203Location5860402Location5876900Location5897900Location5946802Location5976500Location6007200Location6045000Location6050106Location6050202Location6050204Location6053600Location6586400Location7429703Location7436100Location7555701Location7555702Location7749501Location7750301Location7750302Location7750303Location8993000"
 
 var _Code_map = map[Code]string{
 	0:       _Code_name[0:5],
@@ -622,499 +624,501 @@ func _() {
 	232:     _Code_name[921:948],
 	238:     _Code_name[948:962],
 	241:     _Code_name[962:979],
-	263:     _Code_name[979:1013],
-	276:     _Code_name[1013:1030],
-	291:     _Code_name[1030:1047],
-	334:     _Code_name[1047:1067],
-	352:     _Code_name[1067:1092],
-	361:     _Code_name[1092:1114],
-	8000:    _Code_name[1114:1136],
-	10065:   _Code_name[1136:1149],
-	10107:   _Code_name[1149:1167],
-	10334:   _Code_name[1167:1185],
-	11000:   _Code_name[1185:1197],
-	12587:   _Code_name[1197:1238],
-	13026:   _Code_name[1238:1251],
-	13027:   _Code_name[1251:1264],
-	13068:   _Code_name[1264:1277],
-	13103:   _Code_name[1277:1290],
-	13111:   _Code_name[1290:1303],
-	13113:   _Code_name[1303:1331],
-	13297:   _Code_name[1331:1346],
-	13548:   _Code_name[1346:1359],
-	15947:   _Code_name[1359:1372],
-	15952:   _Code_name[1372:1385],
-	15955:   _Code_name[1385:1398],
-	15957:   _Code_name[1398:1411],
-	15958:   _Code_name[1411:1424],
-	15959:   _Code_name[1424:1437],
-	15972:   _Code_name[1437:1450],
-	15976:   _Code_name[1450:1463],
-	15981:   _Code_name[1463:1476],
-	15998:   _Code_name[1476:1489],
-	16004:   _Code_name[1489:1502],
-	16006:   _Code_name[1502:1515],
-	16007:   _Code_name[1515:1528],
-	16020:   _Code_name[1528:1541],
-	16034:   _Code_name[1541:1554],
-	16035:   _Code_name[1554:1567],
-	16410:   _Code_name[1567:1580],
-	16411:   _Code_name[1580:1593],
-	16433:   _Code_name[1593:1606],
-	16554:   _Code_name[1606:1633],
-	16610:   _Code_name[1633:1658],
-	16611:   _Code_name[1658:1678],
-	16612:   _Code_name[1678:1698],
-	16702:   _Code_name[1698:1711],
-	16747:   _Code_name[1711:1724],
-	16748:   _Code_name[1724:1737],
-	16749:   _Code_name[1737:1750],
-	16755:   _Code_name[1750:1763],
-	16764:   _Code_name[1763:1776],
-	16766:   _Code_name[1776:1810],
-	16800:   _Code_name[1810:1823],
-	16801:   _Code_name[1823:1836],
-	16804:   _Code_name[1836:1849],
-	16874:   _Code_name[1849:1862],
-	16875:   _Code_name[1862:1875],
-	16876:   _Code_name[1875:1888],
-	16878:   _Code_name[1888:1901],
-	16879:   _Code_name[1901:1914],
-	16880:   _Code_name[1914:1927],
-	16882:   _Code_name[1927:1940],
-	16883:   _Code_name[1940:1953],
-	16979:   _Code_name[1953:1966],
-	16990:   _Code_name[1966:1979],
-	16994:   _Code_name[1979:1992],
-	17040:   _Code_name[1992:2005],
-	17041:   _Code_name[2005:2018],
-	17042:   _Code_name[2018:2031],
-	17043:   _Code_name[2031:2044],
-	17044:   _Code_name[2044:2057],
-	17045:   _Code_name[2057:2070],
-	17046:   _Code_name[2070:2083],
-	17047:   _Code_name[2083:2096],
-	17048:   _Code_name[2096:2109],
-	17049:   _Code_name[2109:2122],
-	17053:   _Code_name[2122:2135],
-	17080:   _Code_name[2135:2163],
-	17081:   _Code_name[2163:2193],
-	17082:   _Code_name[2193:2223],
-	17083:   _Code_name[2223:2245],
-	17124:   _Code_name[2245:2268],
-	17194:   _Code_name[2268:2287],
-	17261:   _Code_name[2287:2300],
-	17276:   _Code_name[2300:2313],
-	17308:   _Code_name[2313:2326],
-	17310:   _Code_name[2326:2339],
-	17385:   _Code_name[2339:2352],
-	17419:   _Code_name[2352:2388],
-	17420:   _Code_name[2388:2421],
-	18533:   _Code_name[2421:2434],
-	18534:   _Code_name[2434:2447],
-	18535:   _Code_name[2447:2460],
-	18536:   _Code_name[2460:2473],
-	18537:   _Code_name[2473:2486],
-	18628:   _Code_name[2486:2499],
-	18629:   _Code_name[2499:2512],
-	28625:   _Code_name[2512:2525],
-	28646:   _Code_name[2525:2538],
-	28647:   _Code_name[2538:2551],
-	28648:   _Code_name[2551:2564],
-	28650:   _Code_name[2564:2577],
-	28651:   _Code_name[2577:2590],
-	28656:   _Code_name[2590:2603],
-	28657:   _Code_name[2603:2616],
-	28664:   _Code_name[2616:2629],
-	28667:   _Code_name[2629:2666],
-	28680:   _Code_name[2666:2695],
-	28689:   _Code_name[2695:2733],
-	28690:   _Code_name[2733:2775],
-	28691:   _Code_name[2775:2815],
-	28714:   _Code_name[2815:2845],
-	28724:   _Code_name[2845:2868],
-	28725:   _Code_name[2868:2899],
-	28726:   _Code_name[2899:2931],
-	28727:   _Code_name[2931:2961],
-	28728:   _Code_name[2961:2992],
-	28729:   _Code_name[2992:3022],
-	28745:   _Code_name[3022:3035],
-	28746:   _Code_name[3035:3048],
-	28747:   _Code_name[3048:3061],
-	28748:   _Code_name[3061:3074],
-	28749:   _Code_name[3074:3087],
-	28756:   _Code_name[3087:3117],
-	28757:   _Code_name[3117:3143],
-	28758:   _Code_name[3143:3172],
-	28759:   _Code_name[3172:3205],
-	28761:   _Code_name[3205:3236],
-	28762:   _Code_name[3236:3262],
-	28763:   _Code_name[3262:3292],
-	28764:   _Code_name[3292:3327],
-	28765:   _Code_name[3327:3340],
-	28766:   _Code_name[3340:3368],
-	28769:   _Code_name[3368:3381],
-	28803:   _Code_name[3381:3394],
-	28808:   _Code_name[3394:3407],
-	28809:   _Code_name[3407:3420],
-	28810:   _Code_name[3420:3433],
-	28811:   _Code_name[3433:3446],
-	28812:   _Code_name[3446:3459],
-	28818:   _Code_name[3459:3472],
-	28822:   _Code_name[3472:3485],
-	31002:   _Code_name[3485:3498],
-	31022:   _Code_name[3498:3511],
-	31023:   _Code_name[3511:3524],
-	31024:   _Code_name[3524:3537],
-	31032:   _Code_name[3537:3561],
-	31034:   _Code_name[3561:3574],
-	31095:   _Code_name[3574:3587],
-	31109:   _Code_name[3587:3600],
-	31119:   _Code_name[3600:3613],
-	31120:   _Code_name[3613:3626],
-	31138:   _Code_name[3626:3639],
-	31170:   _Code_name[3639:3652],
-	31249:   _Code_name[3652:3665],
-	31250:   _Code_name[3665:3678],
-	31253:   _Code_name[3678:3691],
-	31254:   _Code_name[3691:3704],
-	31256:   _Code_name[3704:3717],
-	31271:   _Code_name[3717:3730],
-	31276:   _Code_name[3730:3743],
-	31308:   _Code_name[3743:3756],
-	31319:   _Code_name[3756:3769],
-	31320:   _Code_name[3769:3782],
-	31321:   _Code_name[3782:3795],
-	31325:   _Code_name[3795:3808],
-	31393:   _Code_name[3808:3821],
-	31395:   _Code_name[3821:3834],
-	31441:   _Code_name[3834:3847],
-	31465:   _Code_name[3847:3860],
-	34435:   _Code_name[3860:3873],
-	34443:   _Code_name[3873:3886],
-	34444:   _Code_name[3886:3899],
-	34445:   _Code_name[3899:3912],
-	34446:   _Code_name[3912:3925],
-	34447:   _Code_name[3925:3938],
-	34448:   _Code_name[3938:3951],
-	34449:   _Code_name[3951:3964],
-	34450:   _Code_name[3964:3977],
-	34451:   _Code_name[3977:3990],
-	34452:   _Code_name[3990:4003],
-	34453:   _Code_name[4003:4016],
-	34454:   _Code_name[4016:4029],
-	34455:   _Code_name[4029:4042],
-	34460:   _Code_name[4042:4055],
-	34461:   _Code_name[4055:4068],
-	34462:   _Code_name[4068:4081],
-	34463:   _Code_name[4081:4094],
-	34464:   _Code_name[4094:4107],
-	34465:   _Code_name[4107:4120],
-	34466:   _Code_name[4120:4133],
-	34467:   _Code_name[4133:4146],
-	34468:   _Code_name[4146:4159],
-	34471:   _Code_name[4159:4172],
-	34473:   _Code_name[4172:4185],
-	40060:   _Code_name[4185:4211],
-	40061:   _Code_name[4211:4247],
-	40062:   _Code_name[4247:4286],
-	40063:   _Code_name[4286:4322],
-	40064:   _Code_name[4322:4365],
-	40065:   _Code_name[4365:4408],
-	40066:   _Code_name[4408:4448],
-	40067:   _Code_name[4448:4471],
-	40068:   _Code_name[4471:4507],
-	40075:   _Code_name[4507:4520],
-	40076:   _Code_name[4520:4533],
-	40077:   _Code_name[4533:4546],
-	40078:   _Code_name[4546:4559],
-	40079:   _Code_name[4559:4572],
-	40080:   _Code_name[4572:4585],
-	40081:   _Code_name[4585:4606],
-	40085:   _Code_name[4606:4619],
-	40086:   _Code_name[4619:4632],
-	40087:   _Code_name[4632:4645],
-	40090:   _Code_name[4645:4658],
-	40091:   _Code_name[4658:4671],
-	40092:   _Code_name[4671:4684],
-	40093:   _Code_name[4684:4697],
-	40094:   _Code_name[4697:4710],
-	40096:   _Code_name[4710:4723],
-	40097:   _Code_name[4723:4736],
-	40100:   _Code_name[4736:4749],
-	40101:   _Code_name[4749:4762],
-	40102:   _Code_name[4762:4775],
-	40103:   _Code_name[4775:4788],
-	40104:   _Code_name[4788:4801],
-	40105:   _Code_name[4801:4814],
-	40147:   _Code_name[4814:4827],
-	40156:   _Code_name[4827:4840],
-	40158:   _Code_name[4840:4853],
-	40160:   _Code_name[4853:4866],
-	40169:   _Code_name[4866:4879],
-	40177:   _Code_name[4879:4892],
-	40181:   _Code_name[4892:4905],
-	40185:   _Code_name[4905:4918],
-	40191:   _Code_name[4918:4931],
-	40192:   _Code_name[4931:4944],
-	40193:   _Code_name[4944:4957],
-	40194:   _Code_name[4957:4970],
-	40195:   _Code_name[4970:4983],
-	40196:   _Code_name[4983:4996],
-	40197:   _Code_name[4996:5009],
-	40198:   _Code_name[5009:5022],
-	40199:   _Code_name[5022:5035],
-	40200:   _Code_name[5035:5048],
-	40201:   _Code_name[5048:5061],
-	40202:   _Code_name[5061:5074],
-	40218:   _Code_name[5074:5087],
-	40228:   _Code_name[5087:5100],
-	40229:   _Code_name[5100:5113],
-	40234:   _Code_name[5113:5126],
-	40235:   _Code_name[5126:5139],
-	40236:   _Code_name[5139:5152],
-	40237:   _Code_name[5152:5165],
-	40238:   _Code_name[5165:5178],
-	40239:   _Code_name[5178:5191],
-	40240:   _Code_name[5191:5204],
-	40241:   _Code_name[5204:5217],
-	40242:   _Code_name[5217:5230],
-	40243:   _Code_name[5230:5243],
-	40244:   _Code_name[5243:5256],
-	40245:   _Code_name[5256:5269],
-	40246:   _Code_name[5269:5282],
-	40257:   _Code_name[5282:5295],
-	40258:   _Code_name[5295:5308],
-	40260:   _Code_name[5308:5321],
-	40261:   _Code_name[5321:5334],
-	40272:   _Code_name[5334:5347],
-	40319:   _Code_name[5347:5360],
-	40321:   _Code_name[5360:5373],
-	40323:   _Code_name[5373:5386],
-	40324:   _Code_name[5386:5405],
-	40352:   _Code_name[5405:5418],
-	40386:   _Code_name[5418:5450],
-	40390:   _Code_name[5450:5483],
-	40391:   _Code_name[5483:5518],
-	40392:   _Code_name[5518:5558],
-	40393:   _Code_name[5558:5600],
-	40394:   _Code_name[5600:5640],
-	40395:   _Code_name[5640:5679],
-	40396:   _Code_name[5679:5713],
-	40397:   _Code_name[5713:5752],
-	40398:   _Code_name[5752:5789],
-	40400:   _Code_name[5789:5818],
-	40414:   _Code_name[5818:5831],
-	40415:   _Code_name[5831:5847],
-	40485:   _Code_name[5847:5860],
-	40489:   _Code_name[5860:5873],
-	40515:   _Code_name[5873:5886],
-	40516:   _Code_name[5886:5899],
-	40517:   _Code_name[5899:5912],
-	40518:   _Code_name[5912:5925],
-	40519:   _Code_name[5925:5938],
-	40520:   _Code_name[5938:5951],
-	40521:   _Code_name[5951:5964],
-	40522:   _Code_name[5964:5977],
-	40523:   _Code_name[5977:5990],
-	40524:   _Code_name[5990:6003],
-	40525:   _Code_name[6003:6016],
-	40533:   _Code_name[6016:6029],
-	40535:   _Code_name[6029:6042],
-	40536:   _Code_name[6042:6055],
-	40539:   _Code_name[6055:6068],
-	40540:   _Code_name[6068:6081],
-	40541:   _Code_name[6081:6094],
-	40542:   _Code_name[6094:6107],
-	40600:   _Code_name[6107:6120],
-	40601:   _Code_name[6120:6133],
-	40602:   _Code_name[6133:6146],
-	40603:   _Code_name[6146:6159],
-	40621:   _Code_name[6159:6172],
-	40647:   _Code_name[6172:6198],
-	40684:   _Code_name[6198:6211],
-	42501:   _Code_name[6211:6232],
-	50687:   _Code_name[6232:6245],
-	50692:   _Code_name[6245:6258],
-	50694:   _Code_name[6258:6271],
-	50695:   _Code_name[6271:6284],
-	50696:   _Code_name[6284:6297],
-	50699:   _Code_name[6297:6310],
-	50700:   _Code_name[6310:6323],
-	50723:   _Code_name[6323:6336],
-	50752:   _Code_name[6336:6349],
-	50759:   _Code_name[6349:6362],
-	50840:   _Code_name[6362:6375],
-	50989:   _Code_name[6375:6388],
-	51003:   _Code_name[6388:6401],
-	51024:   _Code_name[6401:6414],
-	51044:   _Code_name[6414:6427],
-	51045:   _Code_name[6427:6440],
-	51047:   _Code_name[6440:6453],
-	51074:   _Code_name[6453:6466],
-	51075:   _Code_name[6466:6479],
-	51080:   _Code_name[6479:6503],
-	51081:   _Code_name[6503:6535],
-	51082:   _Code_name[6535:6569],
-	51083:   _Code_name[6569:6599],
-	51091:   _Code_name[6599:6612],
-	51103:   _Code_name[6612:6625],
-	51104:   _Code_name[6625:6638],
-	51105:   _Code_name[6638:6651],
-	51106:   _Code_name[6651:6664],
-	51107:   _Code_name[6664:6677],
-	51108:   _Code_name[6677:6690],
-	51109:   _Code_name[6690:6703],
-	51110:   _Code_name[6703:6716],
-	51111:   _Code_name[6716:6729],
-	51132:   _Code_name[6729:6742],
-	51134:   _Code_name[6742:6755],
-	51151:   _Code_name[6755:6768],
-	51156:   _Code_name[6768:6781],
-	51178:   _Code_name[6781:6794],
-	51183:   _Code_name[6794:6807],
-	51185:   _Code_name[6807:6820],
-	51186:   _Code_name[6820:6833],
-	51187:   _Code_name[6833:6846],
-	51191:   _Code_name[6846:6859],
-	51246:   _Code_name[6859:6872],
-	51247:   _Code_name[6872:6885],
-	51276:   _Code_name[6885:6898],
-	51743:   _Code_name[6898:6911],
-	51744:   _Code_name[6911:6924],
-	51745:   _Code_name[6924:6937],
-	51746:   _Code_name[6937:6950],
-	51747:   _Code_name[6950:6963],
-	51748:   _Code_name[6963:6976],
-	51749:   _Code_name[6976:6989],
-	51750:   _Code_name[6989:7002],
-	51751:   _Code_name[7002:7015],
-	327391:  _Code_name[7015:7029],
-	327392:  _Code_name[7029:7043],
-	605001:  _Code_name[7043:7057],
-	1257300: _Code_name[7057:7091],
-	2942500: _Code_name[7091:7106],
-	2942501: _Code_name[7106:7121],
-	2942502: _Code_name[7121:7136],
-	2942503: _Code_name[7136:7151],
-	2942504: _Code_name[7151:7166],
-	2942505: _Code_name[7166:7181],
-	2942506: _Code_name[7181:7196],
-	3040501: _Code_name[7196:7222],
-	3041701: _Code_name[7222:7237],
-	3041702: _Code_name[7237:7252],
-	3041703: _Code_name[7252:7267],
-	3041704: _Code_name[7267:7282],
-	4031700: _Code_name[7282:7308],
-	4161100: _Code_name[7308:7336],
-	4161101: _Code_name[7336:7365],
-	4161102: _Code_name[7365:7380],
-	4161103: _Code_name[7380:7395],
-	4161104: _Code_name[7395:7410],
-	4161105: _Code_name[7410:7425],
-	4161106: _Code_name[7425:7440],
-	4161107: _Code_name[7440:7455],
-	4161108: _Code_name[7455:7470],
-	4161109: _Code_name[7470:7485],
-	4341107: _Code_name[7485:7500],
-	4890500: _Code_name[7500:7515],
-	4940400: _Code_name[7515:7530],
-	4940401: _Code_name[7530:7545],
-	5107200: _Code_name[7545:7560],
-	5107201: _Code_name[7560:7575],
-	5166301: _Code_name[7575:7590],
-	5166302: _Code_name[7590:7605],
-	5166303: _Code_name[7605:7620],
-	5166304: _Code_name[7620:7635],
-	5166305: _Code_name[7635:7650],
-	5166307: _Code_name[7650:7665],
-	5166400: _Code_name[7665:7680],
-	5166401: _Code_name[7680:7695],
-	5166402: _Code_name[7695:7710],
-	5166403: _Code_name[7710:7725],
-	5166404: _Code_name[7725:7740],
-	5166405: _Code_name[7740:7755],
-	5166406: _Code_name[7755:7770],
-	5339900: _Code_name[7770:7785],
-	5339901: _Code_name[7785:7800],
-	5339902: _Code_name[7800:7815],
-	5371601: _Code_name[7815:7830],
-	5371602: _Code_name[7830:7845],
-	5371603: _Code_name[7845:7860],
-	5423900: _Code_name[7860:7875],
-	5423901: _Code_name[7875:7890],
-	5423902: _Code_name[7890:7905],
-	5429413: _Code_name[7905:7920],
-	5429414: _Code_name[7920:7935],
-	5429513: _Code_name[7935:7950],
-	5439007: _Code_name[7950:7965],
-	5439008: _Code_name[7965:7980],
-	5439009: _Code_name[7980:7995],
-	5439010: _Code_name[7995:8010],
-	5439012: _Code_name[8010:8025],
-	5439013: _Code_name[8025:8040],
-	5439014: _Code_name[8040:8055],
-	5439015: _Code_name[8055:8070],
-	5439016: _Code_name[8070:8085],
-	5439017: _Code_name[8085:8100],
-	5439018: _Code_name[8100:8115],
-	5490710: _Code_name[8115:8130],
-	5624900: _Code_name[8130:8145],
-	5624901: _Code_name[8145:8160],
-	5626500: _Code_name[8160:8175],
-	5654600: _Code_name[8175:8190],
-	5654601: _Code_name[8190:8205],
-	5654602: _Code_name[8205:8220],
-	5687301: _Code_name[8220:8235],
-	5687302: _Code_name[8235:8250],
-	5687400: _Code_name[8250:8265],
-	5687401: _Code_name[8265:8280],
-	5733201: _Code_name[8280:8295],
-	5733401: _Code_name[8295:8310],
-	5733402: _Code_name[8310:8325],
-	5733403: _Code_name[8325:8340],
-	5733406: _Code_name[8340:8355],
-	5733408: _Code_name[8355:8370],
-	5733409: _Code_name[8370:8385],
-	5739101: _Code_name[8385:8400],
-	5746102: _Code_name[8400:8415],
-	5787801: _Code_name[8415:8430],
-	5787900: _Code_name[8430:8445],
-	5787901: _Code_name[8445:8460],
-	5787902: _Code_name[8460:8475],
-	5787903: _Code_name[8475:8490],
-	5787906: _Code_name[8490:8505],
-	5787907: _Code_name[8505:8520],
-	5787908: _Code_name[8520:8535],
-	5788001: _Code_name[8535:8550],
-	5788002: _Code_name[8550:8565],
-	5788003: _Code_name[8565:8580],
-	5788004: _Code_name[8580:8595],
-	5788005: _Code_name[8595:8610],
-	5788200: _Code_name[8610:8625],
-	5788604: _Code_name[8625:8640],
-	5858203: _Code_name[8640:8655],
-	5860402: _Code_name[8655:8670],
-	5876900: _Code_name[8670:8685],
-	5897900: _Code_name[8685:8700],
-	5946802: _Code_name[8700:8715],
-	5976500: _Code_name[8715:8730],
-	6007200: _Code_name[8730:8745],
-	6045000: _Code_name[8745:8760],
-	6050106: _Code_name[8760:8775],
-	6050202: _Code_name[8775:8790],
-	6050204: _Code_name[8790:8805],
-	6053600: _Code_name[8805:8820],
-	6586400: _Code_name[8820:8835],
-	7429703: _Code_name[8835:8850],
-	7436100: _Code_name[8850:8865],
-	7555701: _Code_name[8865:8880],
-	7555702: _Code_name[8880:8895],
-	7749501: _Code_name[8895:8910],
-	7750301: _Code_name[8910:8925],
-	7750302: _Code_name[8925:8940],
-	7750303: _Code_name[8940:8955],
-	8993000: _Code_name[8955:8970],
+	262:     _Code_name[979:996],
+	263:     _Code_name[996:1030],
+	276:     _Code_name[1030:1047],
+	291:     _Code_name[1047:1064],
+	334:     _Code_name[1064:1084],
+	352:     _Code_name[1084:1109],
+	361:     _Code_name[1109:1131],
+	8000:    _Code_name[1131:1153],
+	10065:   _Code_name[1153:1166],
+	10107:   _Code_name[1166:1184],
+	10334:   _Code_name[1184:1202],
+	11000:   _Code_name[1202:1214],
+	11601:   _Code_name[1214:1225],
+	12587:   _Code_name[1225:1266],
+	13026:   _Code_name[1266:1279],
+	13027:   _Code_name[1279:1292],
+	13068:   _Code_name[1292:1305],
+	13103:   _Code_name[1305:1318],
+	13111:   _Code_name[1318:1331],
+	13113:   _Code_name[1331:1359],
+	13297:   _Code_name[1359:1374],
+	13548:   _Code_name[1374:1387],
+	15947:   _Code_name[1387:1400],
+	15952:   _Code_name[1400:1413],
+	15955:   _Code_name[1413:1426],
+	15957:   _Code_name[1426:1439],
+	15958:   _Code_name[1439:1452],
+	15959:   _Code_name[1452:1465],
+	15972:   _Code_name[1465:1478],
+	15976:   _Code_name[1478:1491],
+	15981:   _Code_name[1491:1504],
+	15998:   _Code_name[1504:1517],
+	16004:   _Code_name[1517:1530],
+	16006:   _Code_name[1530:1543],
+	16007:   _Code_name[1543:1556],
+	16020:   _Code_name[1556:1569],
+	16034:   _Code_name[1569:1582],
+	16035:   _Code_name[1582:1595],
+	16410:   _Code_name[1595:1608],
+	16411:   _Code_name[1608:1621],
+	16433:   _Code_name[1621:1634],
+	16554:   _Code_name[1634:1661],
+	16610:   _Code_name[1661:1686],
+	16611:   _Code_name[1686:1706],
+	16612:   _Code_name[1706:1726],
+	16702:   _Code_name[1726:1739],
+	16747:   _Code_name[1739:1752],
+	16748:   _Code_name[1752:1765],
+	16749:   _Code_name[1765:1778],
+	16755:   _Code_name[1778:1791],
+	16764:   _Code_name[1791:1804],
+	16766:   _Code_name[1804:1838],
+	16800:   _Code_name[1838:1851],
+	16801:   _Code_name[1851:1864],
+	16804:   _Code_name[1864:1877],
+	16874:   _Code_name[1877:1890],
+	16875:   _Code_name[1890:1903],
+	16876:   _Code_name[1903:1916],
+	16878:   _Code_name[1916:1929],
+	16879:   _Code_name[1929:1942],
+	16880:   _Code_name[1942:1955],
+	16882:   _Code_name[1955:1968],
+	16883:   _Code_name[1968:1981],
+	16979:   _Code_name[1981:1994],
+	16990:   _Code_name[1994:2007],
+	16994:   _Code_name[2007:2020],
+	17040:   _Code_name[2020:2033],
+	17041:   _Code_name[2033:2046],
+	17042:   _Code_name[2046:2059],
+	17043:   _Code_name[2059:2072],
+	17044:   _Code_name[2072:2085],
+	17045:   _Code_name[2085:2098],
+	17046:   _Code_name[2098:2111],
+	17047:   _Code_name[2111:2124],
+	17048:   _Code_name[2124:2137],
+	17049:   _Code_name[2137:2150],
+	17053:   _Code_name[2150:2163],
+	17080:   _Code_name[2163:2191],
+	17081:   _Code_name[2191:2221],
+	17082:   _Code_name[2221:2251],
+	17083:   _Code_name[2251:2273],
+	17124:   _Code_name[2273:2296],
+	17194:   _Code_name[2296:2315],
+	17261:   _Code_name[2315:2328],
+	17276:   _Code_name[2328:2341],
+	17308:   _Code_name[2341:2354],
+	17310:   _Code_name[2354:2367],
+	17385:   _Code_name[2367:2380],
+	17419:   _Code_name[2380:2416],
+	17420:   _Code_name[2416:2449],
+	18533:   _Code_name[2449:2462],
+	18534:   _Code_name[2462:2475],
+	18535:   _Code_name[2475:2488],
+	18536:   _Code_name[2488:2501],
+	18537:   _Code_name[2501:2514],
+	18628:   _Code_name[2514:2527],
+	18629:   _Code_name[2527:2540],
+	28625:   _Code_name[2540:2553],
+	28646:   _Code_name[2553:2566],
+	28647:   _Code_name[2566:2579],
+	28648:   _Code_name[2579:2592],
+	28650:   _Code_name[2592:2605],
+	28651:   _Code_name[2605:2618],
+	28656:   _Code_name[2618:2631],
+	28657:   _Code_name[2631:2644],
+	28664:   _Code_name[2644:2657],
+	28667:   _Code_name[2657:2694],
+	28680:   _Code_name[2694:2723],
+	28689:   _Code_name[2723:2761],
+	28690:   _Code_name[2761:2803],
+	28691:   _Code_name[2803:2843],
+	28714:   _Code_name[2843:2873],
+	28724:   _Code_name[2873:2896],
+	28725:   _Code_name[2896:2927],
+	28726:   _Code_name[2927:2959],
+	28727:   _Code_name[2959:2989],
+	28728:   _Code_name[2989:3020],
+	28729:   _Code_name[3020:3050],
+	28745:   _Code_name[3050:3063],
+	28746:   _Code_name[3063:3076],
+	28747:   _Code_name[3076:3089],
+	28748:   _Code_name[3089:3102],
+	28749:   _Code_name[3102:3115],
+	28756:   _Code_name[3115:3145],
+	28757:   _Code_name[3145:3171],
+	28758:   _Code_name[3171:3200],
+	28759:   _Code_name[3200:3233],
+	28761:   _Code_name[3233:3264],
+	28762:   _Code_name[3264:3290],
+	28763:   _Code_name[3290:3320],
+	28764:   _Code_name[3320:3355],
+	28765:   _Code_name[3355:3368],
+	28766:   _Code_name[3368:3396],
+	28769:   _Code_name[3396:3409],
+	28803:   _Code_name[3409:3422],
+	28808:   _Code_name[3422:3435],
+	28809:   _Code_name[3435:3448],
+	28810:   _Code_name[3448:3461],
+	28811:   _Code_name[3461:3474],
+	28812:   _Code_name[3474:3487],
+	28818:   _Code_name[3487:3500],
+	28822:   _Code_name[3500:3513],
+	31002:   _Code_name[3513:3526],
+	31022:   _Code_name[3526:3539],
+	31023:   _Code_name[3539:3552],
+	31024:   _Code_name[3552:3565],
+	31032:   _Code_name[3565:3589],
+	31034:   _Code_name[3589:3602],
+	31095:   _Code_name[3602:3615],
+	31109:   _Code_name[3615:3628],
+	31119:   _Code_name[3628:3641],
+	31120:   _Code_name[3641:3654],
+	31138:   _Code_name[3654:3667],
+	31170:   _Code_name[3667:3680],
+	31249:   _Code_name[3680:3693],
+	31250:   _Code_name[3693:3706],
+	31253:   _Code_name[3706:3719],
+	31254:   _Code_name[3719:3732],
+	31256:   _Code_name[3732:3745],
+	31271:   _Code_name[3745:3758],
+	31276:   _Code_name[3758:3771],
+	31308:   _Code_name[3771:3784],
+	31319:   _Code_name[3784:3797],
+	31320:   _Code_name[3797:3810],
+	31321:   _Code_name[3810:3823],
+	31325:   _Code_name[3823:3836],
+	31393:   _Code_name[3836:3849],
+	31395:   _Code_name[3849:3862],
+	31441:   _Code_name[3862:3875],
+	31465:   _Code_name[3875:3888],
+	34435:   _Code_name[3888:3901],
+	34443:   _Code_name[3901:3914],
+	34444:   _Code_name[3914:3927],
+	34445:   _Code_name[3927:3940],
+	34446:   _Code_name[3940:3953],
+	34447:   _Code_name[3953:3966],
+	34448:   _Code_name[3966:3979],
+	34449:   _Code_name[3979:3992],
+	34450:   _Code_name[3992:4005],
+	34451:   _Code_name[4005:4018],
+	34452:   _Code_name[4018:4031],
+	34453:   _Code_name[4031:4044],
+	34454:   _Code_name[4044:4057],
+	34455:   _Code_name[4057:4070],
+	34460:   _Code_name[4070:4083],
+	34461:   _Code_name[4083:4096],
+	34462:   _Code_name[4096:4109],
+	34463:   _Code_name[4109:4122],
+	34464:   _Code_name[4122:4135],
+	34465:   _Code_name[4135:4148],
+	34466:   _Code_name[4148:4161],
+	34467:   _Code_name[4161:4174],
+	34468:   _Code_name[4174:4187],
+	34471:   _Code_name[4187:4200],
+	34473:   _Code_name[4200:4213],
+	40060:   _Code_name[4213:4239],
+	40061:   _Code_name[4239:4275],
+	40062:   _Code_name[4275:4314],
+	40063:   _Code_name[4314:4350],
+	40064:   _Code_name[4350:4393],
+	40065:   _Code_name[4393:4436],
+	40066:   _Code_name[4436:4476],
+	40067:   _Code_name[4476:4499],
+	40068:   _Code_name[4499:4535],
+	40075:   _Code_name[4535:4548],
+	40076:   _Code_name[4548:4561],
+	40077:   _Code_name[4561:4574],
+	40078:   _Code_name[4574:4587],
+	40079:   _Code_name[4587:4600],
+	40080:   _Code_name[4600:4613],
+	40081:   _Code_name[4613:4634],
+	40085:   _Code_name[4634:4647],
+	40086:   _Code_name[4647:4660],
+	40087:   _Code_name[4660:4673],
+	40090:   _Code_name[4673:4686],
+	40091:   _Code_name[4686:4699],
+	40092:   _Code_name[4699:4712],
+	40093:   _Code_name[4712:4725],
+	40094:   _Code_name[4725:4738],
+	40096:   _Code_name[4738:4751],
+	40097:   _Code_name[4751:4764],
+	40100:   _Code_name[4764:4777],
+	40101:   _Code_name[4777:4790],
+	40102:   _Code_name[4790:4803],
+	40103:   _Code_name[4803:4816],
+	40104:   _Code_name[4816:4829],
+	40105:   _Code_name[4829:4842],
+	40147:   _Code_name[4842:4855],
+	40156:   _Code_name[4855:4868],
+	40158:   _Code_name[4868:4881],
+	40160:   _Code_name[4881:4894],
+	40169:   _Code_name[4894:4907],
+	40177:   _Code_name[4907:4920],
+	40181:   _Code_name[4920:4933],
+	40185:   _Code_name[4933:4946],
+	40191:   _Code_name[4946:4959],
+	40192:   _Code_name[4959:4972],
+	40193:   _Code_name[4972:4985],
+	40194:   _Code_name[4985:4998],
+	40195:   _Code_name[4998:5011],
+	40196:   _Code_name[5011:5024],
+	40197:   _Code_name[5024:5037],
+	40198:   _Code_name[5037:5050],
+	40199:   _Code_name[5050:5063],
+	40200:   _Code_name[5063:5076],
+	40201:   _Code_name[5076:5089],
+	40202:   _Code_name[5089:5102],
+	40218:   _Code_name[5102:5115],
+	40228:   _Code_name[5115:5128],
+	40229:   _Code_name[5128:5141],
+	40234:   _Code_name[5141:5154],
+	40235:   _Code_name[5154:5167],
+	40236:   _Code_name[5167:5180],
+	40237:   _Code_name[5180:5193],
+	40238:   _Code_name[5193:5206],
+	40239:   _Code_name[5206:5219],
+	40240:   _Code_name[5219:5232],
+	40241:   _Code_name[5232:5245],
+	40242:   _Code_name[5245:5258],
+	40243:   _Code_name[5258:5271],
+	40244:   _Code_name[5271:5284],
+	40245:   _Code_name[5284:5297],
+	40246:   _Code_name[5297:5310],
+	40257:   _Code_name[5310:5323],
+	40258:   _Code_name[5323:5336],
+	40260:   _Code_name[5336:5349],
+	40261:   _Code_name[5349:5362],
+	40272:   _Code_name[5362:5375],
+	40319:   _Code_name[5375:5388],
+	40321:   _Code_name[5388:5401],
+	40323:   _Code_name[5401:5414],
+	40324:   _Code_name[5414:5433],
+	40352:   _Code_name[5433:5446],
+	40386:   _Code_name[5446:5478],
+	40390:   _Code_name[5478:5511],
+	40391:   _Code_name[5511:5546],
+	40392:   _Code_name[5546:5586],
+	40393:   _Code_name[5586:5628],
+	40394:   _Code_name[5628:5668],
+	40395:   _Code_name[5668:5707],
+	40396:   _Code_name[5707:5741],
+	40397:   _Code_name[5741:5780],
+	40398:   _Code_name[5780:5817],
+	40400:   _Code_name[5817:5846],
+	40414:   _Code_name[5846:5859],
+	40415:   _Code_name[5859:5875],
+	40485:   _Code_name[5875:5888],
+	40489:   _Code_name[5888:5901],
+	40515:   _Code_name[5901:5914],
+	40516:   _Code_name[5914:5927],
+	40517:   _Code_name[5927:5940],
+	40518:   _Code_name[5940:5953],
+	40519:   _Code_name[5953:5966],
+	40520:   _Code_name[5966:5979],
+	40521:   _Code_name[5979:5992],
+	40522:   _Code_name[5992:6005],
+	40523:   _Code_name[6005:6018],
+	40524:   _Code_name[6018:6031],
+	40525:   _Code_name[6031:6044],
+	40533:   _Code_name[6044:6057],
+	40535:   _Code_name[6057:6070],
+	40536:   _Code_name[6070:6083],
+	40539:   _Code_name[6083:6096],
+	40540:   _Code_name[6096:6109],
+	40541:   _Code_name[6109:6122],
+	40542:   _Code_name[6122:6135],
+	40600:   _Code_name[6135:6148],
+	40601:   _Code_name[6148:6161],
+	40602:   _Code_name[6161:6174],
+	40603:   _Code_name[6174:6187],
+	40621:   _Code_name[6187:6200],
+	40647:   _Code_name[6200:6226],
+	40684:   _Code_name[6226:6239],
+	42501:   _Code_name[6239:6260],
+	50687:   _Code_name[6260:6273],
+	50692:   _Code_name[6273:6286],
+	50694:   _Code_name[6286:6299],
+	50695:   _Code_name[6299:6312],
+	50696:   _Code_name[6312:6325],
+	50699:   _Code_name[6325:6338],
+	50700:   _Code_name[6338:6351],
+	50723:   _Code_name[6351:6364],
+	50752:   _Code_name[6364:6377],
+	50759:   _Code_name[6377:6390],
+	50840:   _Code_name[6390:6403],
+	50989:   _Code_name[6403:6416],
+	51003:   _Code_name[6416:6429],
+	51024:   _Code_name[6429:6442],
+	51044:   _Code_name[6442:6455],
+	51045:   _Code_name[6455:6468],
+	51047:   _Code_name[6468:6481],
+	51074:   _Code_name[6481:6494],
+	51075:   _Code_name[6494:6507],
+	51080:   _Code_name[6507:6531],
+	51081:   _Code_name[6531:6563],
+	51082:   _Code_name[6563:6597],
+	51083:   _Code_name[6597:6627],
+	51091:   _Code_name[6627:6640],
+	51103:   _Code_name[6640:6653],
+	51104:   _Code_name[6653:6666],
+	51105:   _Code_name[6666:6679],
+	51106:   _Code_name[6679:6692],
+	51107:   _Code_name[6692:6705],
+	51108:   _Code_name[6705:6718],
+	51109:   _Code_name[6718:6731],
+	51110:   _Code_name[6731:6744],
+	51111:   _Code_name[6744:6757],
+	51132:   _Code_name[6757:6770],
+	51134:   _Code_name[6770:6783],
+	51151:   _Code_name[6783:6796],
+	51156:   _Code_name[6796:6809],
+	51178:   _Code_name[6809:6822],
+	51183:   _Code_name[6822:6835],
+	51185:   _Code_name[6835:6848],
+	51186:   _Code_name[6848:6861],
+	51187:   _Code_name[6861:6874],
+	51191:   _Code_name[6874:6887],
+	51246:   _Code_name[6887:6900],
+	51247:   _Code_name[6900:6913],
+	51276:   _Code_name[6913:6926],
+	51743:   _Code_name[6926:6939],
+	51744:   _Code_name[6939:6952],
+	51745:   _Code_name[6952:6965],
+	51746:   _Code_name[6965:6978],
+	51747:   _Code_name[6978:6991],
+	51748:   _Code_name[6991:7004],
+	51749:   _Code_name[7004:7017],
+	51750:   _Code_name[7017:7030],
+	51751:   _Code_name[7030:7043],
+	327391:  _Code_name[7043:7057],
+	327392:  _Code_name[7057:7071],
+	605001:  _Code_name[7071:7085],
+	1257300: _Code_name[7085:7119],
+	2942500: _Code_name[7119:7134],
+	2942501: _Code_name[7134:7149],
+	2942502: _Code_name[7149:7164],
+	2942503: _Code_name[7164:7179],
+	2942504: _Code_name[7179:7194],
+	2942505: _Code_name[7194:7209],
+	2942506: _Code_name[7209:7224],
+	3040501: _Code_name[7224:7250],
+	3041701: _Code_name[7250:7265],
+	3041702: _Code_name[7265:7280],
+	3041703: _Code_name[7280:7295],
+	3041704: _Code_name[7295:7310],
+	4031700: _Code_name[7310:7336],
+	4161100: _Code_name[7336:7364],
+	4161101: _Code_name[7364:7393],
+	4161102: _Code_name[7393:7408],
+	4161103: _Code_name[7408:7423],
+	4161104: _Code_name[7423:7438],
+	4161105: _Code_name[7438:7453],
+	4161106: _Code_name[7453:7468],
+	4161107: _Code_name[7468:7483],
+	4161108: _Code_name[7483:7498],
+	4161109: _Code_name[7498:7513],
+	4341107: _Code_name[7513:7528],
+	4890500: _Code_name[7528:7543],
+	4940400: _Code_name[7543:7558],
+	4940401: _Code_name[7558:7573],
+	5107200: _Code_name[7573:7588],
+	5107201: _Code_name[7588:7603],
+	5166301: _Code_name[7603:7618],
+	5166302: _Code_name[7618:7633],
+	5166303: _Code_name[7633:7648],
+	5166304: _Code_name[7648:7663],
+	5166305: _Code_name[7663:7678],
+	5166307: _Code_name[7678:7693],
+	5166400: _Code_name[7693:7708],
+	5166401: _Code_name[7708:7723],
+	5166402: _Code_name[7723:7738],
+	5166403: _Code_name[7738:7753],
+	5166404: _Code_name[7753:7768],
+	5166405: _Code_name[7768:7783],
+	5166406: _Code_name[7783:7798],
+	5339900: _Code_name[7798:7813],
+	5339901: _Code_name[7813:7828],
+	5339902: _Code_name[7828:7843],
+	5371601: _Code_name[7843:7858],
+	5371602: _Code_name[7858:7873],
+	5371603: _Code_name[7873:7888],
+	5423900: _Code_name[7888:7903],
+	5423901: _Code_name[7903:7918],
+	5423902: _Code_name[7918:7933],
+	5429413: _Code_name[7933:7948],
+	5429414: _Code_name[7948:7963],
+	5429513: _Code_name[7963:7978],
+	5439007: _Code_name[7978:7993],
+	5439008: _Code_name[7993:8008],
+	5439009: _Code_name[8008:8023],
+	5439010: _Code_name[8023:8038],
+	5439012: _Code_name[8038:8053],
+	5439013: _Code_name[8053:8068],
+	5439014: _Code_name[8068:8083],
+	5439015: _Code_name[8083:8098],
+	5439016: _Code_name[8098:8113],
+	5439017: _Code_name[8113:8128],
+	5439018: _Code_name[8128:8143],
+	5490710: _Code_name[8143:8158],
+	5624900: _Code_name[8158:8173],
+	5624901: _Code_name[8173:8188],
+	5626500: _Code_name[8188:8203],
+	5654600: _Code_name[8203:8218],
+	5654601: _Code_name[8218:8233],
+	5654602: _Code_name[8233:8248],
+	5687301: _Code_name[8248:8263],
+	5687302: _Code_name[8263:8278],
+	5687400: _Code_name[8278:8293],
+	5687401: _Code_name[8293:8308],
+	5733201: _Code_name[8308:8323],
+	5733401: _Code_name[8323:8338],
+	5733402: _Code_name[8338:8353],
+	5733403: _Code_name[8353:8368],
+	5733406: _Code_name[8368:8383],
+	5733408: _Code_name[8383:8398],
+	5733409: _Code_name[8398:8413],
+	5739101: _Code_name[8413:8428],
+	5746102: _Code_name[8428:8443],
+	5787801: _Code_name[8443:8458],
+	5787900: _Code_name[8458:8473],
+	5787901: _Code_name[8473:8488],
+	5787902: _Code_name[8488:8503],
+	5787903: _Code_name[8503:8518],
+	5787906: _Code_name[8518:8533],
+	5787907: _Code_name[8533:8548],
+	5787908: _Code_name[8548:8563],
+	5788001: _Code_name[8563:8578],
+	5788002: _Code_name[8578:8593],
+	5788003: _Code_name[8593:8608],
+	5788004: _Code_name[8608:8623],
+	5788005: _Code_name[8623:8638],
+	5788200: _Code_name[8638:8653],
+	5788604: _Code_name[8653:8668],
+	5858203: _Code_name[8668:8683],
+	5860402: _Code_name[8683:8698],
+	5876900: _Code_name[8698:8713],
+	5897900: _Code_name[8713:8728],
+	5946802: _Code_name[8728:8743],
+	5976500: _Code_name[8743:8758],
+	6007200: _Code_name[8758:8773],
+	6045000: _Code_name[8773:8788],
+	6050106: _Code_name[8788:8803],
+	6050202: _Code_name[8803:8818],
+	6050204: _Code_name[8818:8833],
+	6053600: _Code_name[8833:8848],
+	6586400: _Code_name[8848:8863],
+	7429703: _Code_name[8863:8878],
+	7436100: _Code_name[8878:8893],
+	7555701: _Code_name[8893:8908],
+	7555702: _Code_name[8908:8923],
+	7749501: _Code_name[8923:8938],
+	7750301: _Code_name[8938:8953],
+	7750302: _Code_name[8953:8968],
+	7750303: _Code_name[8968:8983],
+	8993000: _Code_name[8983:8998],
 }
 
 func (i Code) String() string {
@@ -62,6 +62,7 @@
 	ErrMaxSubPipelineDepthExceeded                 = Code(232)     // MaxSubPipelineDepthExceeded
 	ErrNotImplemented                              = Code(238)     // NotImplemented
 	ErrConversionFailure                           = Code(241)     // ConversionFailure
+	ErrExceededTimeLimit                           = Code(262)     // ExceededTimeLimit
 	ErrOperationNotSupportedInTransaction          = Code(263)     // OperationNotSupportedInTransaction
 	ErrIndexBuildAborted                           = Code(276)     // IndexBuildAborted
 	ErrUnableToFindIndex                           = Code(291)     // UnableToFindIndex
@@ -73,6 +74,7 @@
 	ErrNotWritablePrimary                          = Code(10107)   // NotWritablePrimary
 	ErrBsonObjectTooLarge                          = Code(10334)   // BsonObjectTooLarge
 	ErrDuplicateKey                                = Code(11000)   // DuplicateKey
+	ErrInterrupted                                 = Code(11601)   // Interrupted
 	ErrBackgroundOperationInProgressForNamespace   = Code(12587)   // BackgroundOperationInProgressForNamespace
 	ErrLocation13026                               = Code(13026)   // Location13026
 	ErrLocation13027                               = Code(13027)   // Location13027
@@ -56,8 +56,10 @@ type line struct {
 	"ClientMetadataCannotBeMutated": 186,
 	"InvalidUUID":                   207,
 	"NotImplemented":                238,
+	"ExceededTimeLimit":             262,
 	"MechanismUnavailable":          334,
 	"UnsupportedOpQueryCommand":     352,
+	"Interrupted":                   11601,
 	"Location16979":                 16979,
 	"Location40621":                 40621,
 	"Location50687":                 50687,
@@ -119,6 +119,8 @@ func Listen(opts *ListenOpts) (*Listener, error) {
 	http.HandleFunc("/debug/archive", archiveHandler(l))
 	http.Handle("/debug/archive.zip", http.RedirectHandler("/debug/archive", 303))
 
+	http.HandleFunc("/debug/log", logHandler(l))
+
 	svOpts := []statsviz.Option{
 		statsviz.Root("/debug/graphs"),
 		// TODO https://github.com/FerretDB/FerretDB/issues/3600
@@ -172,6 +174,7 @@ func Listen(opts *ListenOpts) (*Listener, error) {
 		// custom handlers registered above
 		"/debug/metrics": "Metrics in Prometheus format",
 		"/debug/archive": "Zip archive with debugging information",
+		"/debug/log":     "Show and change log levels",
 		"/debug/graphs":  "Visualize metrics",
 		"/debug/livez":   "Liveness probe",
 		"/debug/readyz":  "Readiness probe",
@@ -19,11 +19,14 @@
 	"archive/zip"
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
+	"net/url"
 	"regexp"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
@@ -80,6 +83,57 @@ func TestDebug(t *testing.T) {
 		assertProbe(t, ready, http.StatusOK)
 	})
 
+	t.Run("Log", func(t *testing.T) {
+		u := "http://" + h.lis.Addr().String() + "/debug/log"
+
+		getLevels := func(t *testing.T, res *http.Response) *logLevels {
+			t.Helper()
+
+			defer res.Body.Close() //nolint:errcheck // we are only reading it
+
+			require.Equal(t, http.StatusOK, res.StatusCode)
+
+			var levels logLevels
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&levels))
+
+			return &levels
+		}
+
+		res, err := http.Get(u)
+		require.NoError(t, err)
+
+		levels := getLevels(t, res)
+		assert.Equal(t, "DEBUG", levels.Level)
+		assert.Contains(t, levels.Components, "pgx")
+		assert.Nil(t, levels.Components["pgx"])
+
+		res, err = http.PostForm(u, url.Values{"component": {"pgx"}, "level": {"debug-4"}, "duration": {"100ms"}})
+		require.NoError(t, err)
+
+		levels = getLevels(t, res)
+		require.NotNil(t, levels.Components["pgx"])
+		assert.Equal(t, "DEBUG-4", *levels.Components["pgx"])
+
+		assert.Eventually(t, func() bool {
+			res, err := http.Get(u)
+			require.NoError(t, err)
+
+			return getLevels(t, res).Components["pgx"] == nil
+		}, 5*time.Second, 50*time.Millisecond, "level should be restored")
+
+		for name, values := range map[string]url.Values{
+			"UnknownComponent": {"component": {"unknown"}, "level": {"debug"}},
+			"InvalidLevel":     {"component": {"pgx"}, "level": {"verbose"}},
+			"InvalidDuration":  {"component": {"pgx"}, "level": {"debug"}, "duration": {"-1s"}},
+			"NoGlobalLevel":    {},
+		} {
+			res, err = http.PostForm(u, values)
+			require.NoError(t, err, name)
+			require.NoError(t, res.Body.Close())
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode, name)
+		}
+	})
+
 	t.Run("Archive", func(t *testing.T) {
 		u := "http://" + h.lis.Addr().String() + "/debug/archive"
 
@@ -0,0 +1,180 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/logging"
+)
+
+// logLevels represents the response of /debug/log handler.
+type logLevels struct {
+	Level string `json:"level"`
+
+	// Components without set levels use the global level; they have nil values.
+	Components map[string]*string `json:"components"`
+}
+
+// getLogLevels returns the current levels of the given handler.
+func getLogLevels(lh *logging.Handler) *logLevels {
+	levels := lh.ComponentLevels()
+
+	res := &logLevels{
+		Level:      lh.Level().String(),
+		Components: map[string]*string{},
+	}
+
+	for _, c := range logging.Components() {
+		res.Components[c] = nil
+
+		if l, ok := levels[c]; ok {
+			s := l.String()
+			res.Components[c] = &s
+		}
+	}
+
+	return res
+}
+
+// setLogLevel changes the global level or the level of the given component.
+// Nil level resets the component's level.
+//
+// It returns a function that restores the previous level if it was not changed since then.
+func setLogLevel(lh *logging.Handler, component string, level *slog.Level) (func() bool, error) {
+	if component == "" {
+		if level == nil {
+			return nil, errors.New("level is required")
+		}
+
+		prev := lh.Level()
+		lh.SetLevel(*level)
+
+		return func() bool {
+			if lh.Level() != *level {
+				return false
+			}
+
+			lh.SetLevel(prev)
+
+			return true
+		}, nil
+	}
+
+	prev, prevSet := lh.ComponentLevels()[component]
+
+	var l slog.Leveler
+	if level != nil {
+		l = *level
+	}
+
+	if err := lh.SetComponentLevel(component, l); err != nil {
+		return nil, err
+	}
+
+	return func() bool {
+		cur, curSet := lh.ComponentLevels()[component]
+		if curSet != (level != nil) || (curSet && cur != *level) {
+			return false
+		}
+
+		var l slog.Leveler
+		if prevSet {
+			l = prev
+		}
+
+		return lh.SetComponentLevel(component, l) == nil
+	}, nil
+}
+
+// logHandler returns a handler that shows and changes log levels.
+//
+// POST requests change the global level or the level of the component (see [logging.Components])
+// with `level` and optional `component` form values.
+// Empty level resets the component's level to the global level.
+// Optional `duration` form value restores the previous level after the given time,
+// unless it was changed again.
+func logHandler(l *slog.Logger) http.HandlerFunc {
+	lh := l.Handler().(*logging.Handler)
+
+	return func(rw http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			component := r.FormValue("component")
+
+			var level *slog.Level
+
+			if s := r.FormValue("level"); s != "" {
+				level = new(slog.Level)
+				if err := level.UnmarshalText([]byte(s)); err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+			}
+
+			var d time.Duration
+
+			if s := r.FormValue("duration"); s != "" {
+				var err error
+				if d, err = time.ParseDuration(s); err != nil || d <= 0 {
+					http.Error(rw, fmt.Sprintf("invalid duration %q", s), http.StatusBadRequest)
+					return
+				}
+			}
+
+			restore, err := setLogLevel(lh, component, level)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			l.InfoContext(
+				ctx, "Log level changed",
+				slog.String("component", component), slog.Any("level", level), slog.Duration("duration", d),
+			)
+
+			if d > 0 {
+				time.AfterFunc(d, func() {
+					if restore() {
+						l.Info("Log level restored", slog.String("component", component))
+					}
+				})
+			}
+
+		default:
+			rw.Header().Set("Allow", "GET, POST")
+			http.Error(rw, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+
+		e := json.NewEncoder(rw)
+		e.SetIndent("", "  ")
+
+		if err := e.Encode(getLogLevels(lh)); err != nil {
+			l.ErrorContext(ctx, "Failed to encode log levels", logging.Error(err))
+		}
+	}
+}
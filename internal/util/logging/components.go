@@ -0,0 +1,101 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// components contains names of loggers (see [WithName])
+// which levels could be changed independently of the [Handler]'s level.
+var components = []string{
+	"cursors",
+	"dataapi",
+	"listener",
+	"mcp",
+	"pgx",
+	"pool",
+	"session",
+}
+
+// Components returns sorted names of loggers which levels could be changed with [Handler.SetComponentLevel].
+func Components() []string {
+	return slices.Clone(components)
+}
+
+// componentLevels stores levels of components shared by a handler and all handlers derived from it.
+type componentLevels struct {
+	m      sync.Mutex                            // protects writes
+	levels atomic.Pointer[map[string]slog.Level] // copied on write
+}
+
+// newComponentLevels creates a new componentLevels without any levels set.
+func newComponentLevels() *componentLevels {
+	var res componentLevels
+	res.levels.Store(new(map[string]slog.Level))
+
+	return &res
+}
+
+// level returns the level set for the innermost of the given names, if any.
+func (cl *componentLevels) level(names []string) (slog.Level, bool) {
+	levels := *cl.levels.Load()
+	if len(levels) == 0 {
+		return 0, false
+	}
+
+	for _, name := range slices.Backward(names) {
+		if l, ok := levels[name]; ok {
+			return l, true
+		}
+	}
+
+	return 0, false
+}
+
+// get returns a copy of all set levels.
+func (cl *componentLevels) get() map[string]slog.Level {
+	return maps.Clone(*cl.levels.Load())
+}
+
+// set sets or unsets (if l is nil) the level of the given component.
+func (cl *componentLevels) set(component string, l slog.Leveler) error {
+	if !slices.Contains(components, component) {
+		return fmt.Errorf("unknown logging component %q", component)
+	}
+
+	cl.m.Lock()
+	defer cl.m.Unlock()
+
+	levels := maps.Clone(*cl.levels.Load())
+	if levels == nil {
+		levels = map[string]slog.Level{}
+	}
+
+	if l == nil {
+		delete(levels, component)
+	} else {
+		levels[component] = l.Level()
+	}
+
+	cl.levels.Store(&levels)
+
+	return nil
+}
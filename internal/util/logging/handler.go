@@ -21,6 +21,7 @@
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/FerretDB/wire/wirebson"
@@ -35,12 +36,16 @@
 //   - shorter source locations;
 //   - removal of time, level, and source attributes;
 //   - message checks for leading/trailing spaces and ending punctuation;
-//   - collecting recent log entries for `getLog` command.
+//   - collecting recent log entries for `getLog` command;
+//   - changing the minimal level at runtime, both for all loggers and for individual components.
 type Handler struct {
 	base          slog.Handler
 	out           io.Writer
 	skipChecks    bool
 	recentEntries *circularBuffer
+	level         *slog.LevelVar
+	components    *componentLevels
+	names         []string // set by [WithName], from outermost to innermost
 }
 
 // NewHandlerOpts represents [NewHandler] options.
@@ -123,6 +128,19 @@ func replaceAttrFunc(opts *NewHandlerOpts) func([]string, slog.Attr) slog.Attr {
 func NewHandler(out io.Writer, opts *NewHandlerOpts) *Handler {
 	must.NotBeZero(opts)
 
+	level, ok := opts.Level.(*slog.LevelVar)
+	if !ok {
+		level = new(slog.LevelVar)
+		if opts.Level != nil {
+			level.Set(opts.Level.Level())
+		}
+	}
+
+	// base handlers use the level variable, so it could be changed at runtime
+	o := *opts
+	o.Level = level
+	opts = &o
+
 	var h slog.Handler
 
 	stdOpts := &slog.HandlerOptions{
@@ -153,11 +171,17 @@ func NewHandler(out io.Writer, opts *NewHandlerOpts) *Handler {
 		out:           out,
 		skipChecks:    opts.SkipChecks,
 		recentEntries: newCircularBuffer(opts.recentEntriesSize),
+		level:         level,
+		components:    newComponentLevels(),
 	}
 }
 
 // Enabled implements [slog.Handler].
 func (h *Handler) Enabled(ctx context.Context, l slog.Level) bool {
+	if minLevel, ok := h.components.level(h.names); ok {
+		return l >= minLevel
+	}
+
 	return h.base.Enabled(ctx, l)
 }
 
@@ -214,11 +238,22 @@ func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
 
 // WithAttrs implements [slog.Handler].
 func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	names := h.names
+
+	for _, a := range attrs {
+		if a.Key == nameKey && a.Value.Kind() == slog.KindString {
+			names = append(slices.Clip(names), a.Value.String())
+		}
+	}
+
 	return &Handler{
 		base:          h.base.WithAttrs(attrs),
 		out:           h.out,
 		skipChecks:    h.skipChecks,
 		recentEntries: h.recentEntries,
+		level:         h.level,
+		components:    h.components,
+		names:         names,
 	}
 }
 
@@ -229,9 +264,37 @@ func (h *Handler) WithGroup(name string) slog.Handler {
 		out:           h.out,
 		skipChecks:    h.skipChecks,
 		recentEntries: h.recentEntries,
+		level:         h.level,
+		components:    h.components,
+		names:         h.names,
 	}
 }
 
+// Level returns the current minimal level.
+func (h *Handler) Level() slog.Level {
+	return h.level.Level()
+}
+
+// SetLevel changes the minimal level for this handler and all handlers derived from it.
+func (h *Handler) SetLevel(l slog.Level) {
+	h.level.Set(l)
+}
+
+// ComponentLevels returns levels set for components with [Handler.SetComponentLevel].
+// Other components use the handler's level.
+func (h *Handler) ComponentLevels() map[string]slog.Level {
+	return h.components.get()
+}
+
+// SetComponentLevel changes the minimal level of the given component (see [Components])
+// for this handler and all handlers derived from it.
+// The level is used for loggers with that name (see [WithName]) and their children instead of the handler's level.
+//
+// Nil level makes the component use the handler's level again.
+func (h *Handler) SetComponentLevel(component string, l slog.Leveler) error {
+	return h.components.set(component, l)
+}
+
 // RecentEntries returns recent log entries.
 func (h *Handler) RecentEntries() (*wirebson.Array, error) {
 	return h.recentEntries.getArray()
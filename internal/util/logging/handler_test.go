@@ -85,6 +85,62 @@ func TestHandler(t *testing.T) {
 	}
 }
 
+func TestHandlerLevels(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var level slog.LevelVar
+	h := NewHandler(new(bytes.Buffer), &NewHandlerOpts{
+		Base:  "console",
+		Level: &level,
+	})
+
+	root := slog.New(h)
+	pool := WithName(root, "pool")
+	pgx := WithName(pool, "pgx")
+	conn := WithName(WithName(root, "listener"), "// 127.0.0.1:1234 -> 127.0.0.1:27017 ")
+
+	assert.False(t, root.Enabled(ctx, slog.LevelDebug))
+	assert.False(t, pgx.Enabled(ctx, slog.LevelDebug))
+
+	t.Run("Level", func(t *testing.T) {
+		level.Set(slog.LevelDebug)
+		assert.True(t, pgx.Enabled(ctx, slog.LevelDebug), "passed level variable should be used")
+
+		h.SetLevel(slog.LevelWarn)
+		assert.Equal(t, slog.LevelWarn, level.Level())
+		assert.False(t, pool.Handler().Enabled(ctx, slog.LevelInfo), "derived handlers should share the level")
+
+		h.SetLevel(slog.LevelInfo)
+	})
+
+	t.Run("Components", func(t *testing.T) {
+		assert.Contains(t, Components(), "pgx")
+		assert.Error(t, h.SetComponentLevel("unknown", slog.LevelDebug))
+
+		require.NoError(t, pgx.Handler().(*Handler).SetComponentLevel("pgx", slog.LevelDebug-4))
+		require.NoError(t, h.SetComponentLevel("pool", slog.LevelError))
+		require.NoError(t, h.SetComponentLevel("listener", slog.LevelDebug))
+
+		assert.Equal(t, map[string]slog.Level{
+			"listener": slog.LevelDebug,
+			"pgx":      slog.LevelDebug - 4,
+			"pool":     slog.LevelError,
+		}, h.ComponentLevels())
+
+		assert.False(t, root.Enabled(ctx, slog.LevelDebug))
+		assert.False(t, pool.Enabled(ctx, slog.LevelWarn))
+		assert.True(t, pgx.Enabled(ctx, slog.LevelDebug-4), "innermost name should be used")
+		assert.True(t, conn.Enabled(ctx, slog.LevelDebug), "children should use the component level")
+
+		require.NoError(t, h.SetComponentLevel("pool", nil))
+		require.NoError(t, h.SetComponentLevel("pgx", nil))
+		assert.True(t, pool.Enabled(ctx, slog.LevelWarn))
+		assert.False(t, pgx.Enabled(ctx, slog.LevelDebug))
+	})
+}
+
 func TestShortPath(t *testing.T) {
 	t.Parallel()
 
@@ -40,6 +40,7 @@
 // WithName returns a logger with a given period-separated name.
 //
 // How this name is used depends on the handler.
+// [Handler] also uses it to apply component levels; see [Handler.SetComponentLevel].
 //
 // TODO https://github.com/FerretDB/FerretDB/issues/4431
 func WithName(l *slog.Logger, name string) *slog.Logger {
@@ -46,13 +46,30 @@ type SetupOpts struct {
 	PostgreSQLURL          string
 	Auth                   bool
 	ReplSetName            string
+	ReadOnly               bool
 	SessionCleanupInterval time.Duration
+	TTLMonitorInterval     time.Duration
+	TTLMonitorBatchSize    int
+	DefaultMaxTime         time.Duration // zero value means no limit
+	SlowOpThreshold        time.Duration // zero value disables the slow operation log
+	MaxRequests            int           // zero value means PostgreSQL pool size
+	MaxUserRequests        int           // zero value means no limit
+	AdmissionMaxWait       time.Duration // zero value means no limit
 
 	// Proxy handler
 	ProxyAddr        string
 	ProxyTLSCertFile string
 	ProxyTLSKeyFile  string
 	ProxyTLSCAFile   string
+	ProxyPoolSize    int           // zero value disables pooling
+	ProxyPoolWait    time.Duration // zero value means no timeout
+
+	// Proxy upstream authentication
+	ProxyUsername      string // empty value disables upstream authentication with default credentials
+	ProxyPassword      string
+	ProxyAuthDB        string
+	ProxyAuthMechanism string
+	ProxyUserMapFile   string // empty value disables mapping of FerretDB users to upstream users
 
 	// Wire protocol listener
 	TCPAddr        string // empty value disables TCP listener
@@ -61,9 +78,14 @@ type SetupOpts struct {
 	TLSCertFile    string
 	TLSKeyFile     string
 	TLSCAFile      string
+	MaxConnections int // zero value means no limit
 	Mode           middleware.Mode
 	TestRecordsDir string // empty value disables recording
 
+	// Routing mode
+	RoutingFile           string
+	RoutingReloadInterval time.Duration // zero value disables reloading
+
 	// DataAPI listener
 	DataAPIAddr string // empty value disables Data API listener
 
@@ -111,6 +133,40 @@ func Setup(ctx context.Context, opts *SetupOpts) *SetupResult {
 	exitCtx, exitCancel := context.WithCancel(ctx)
 	exitCancel() // no defer - we need canceled context
 
+	var proxyH *proxy.Handler
+	var proxyStats func() *proxy.Stats
+
+	if opts.ProxyAddr != "" {
+		//exhaustruct:enforce
+		proxyH, err = proxy.New(&proxy.NewOpts{
+			Addr:        opts.ProxyAddr,
+			TLSCertFile: opts.ProxyTLSCertFile,
+			TLSKeyFile:  opts.ProxyTLSKeyFile,
+			TLSCAFile:   opts.ProxyTLSCAFile,
+
+			PoolSize:        opts.ProxyPoolSize,
+			PoolWaitTimeout: opts.ProxyPoolWait,
+
+			Auth:          opts.Auth,
+			Username:      opts.ProxyUsername,
+			Password:      opts.ProxyPassword,
+			AuthDB:        opts.ProxyAuthDB,
+			AuthMechanism: opts.ProxyAuthMechanism,
+			UserMapFile:   opts.ProxyUserMapFile,
+
+			L: logging.WithName(opts.Logger, "proxy"),
+		})
+		if err != nil {
+			opts.Logger.LogAttrs(ctx, logging.LevelDPanic, "Failed to construct proxy handler", logging.Error(err))
+			res.Run(exitCtx)
+
+			return nil
+		}
+
+		res.proxyH = proxyH
+		proxyStats = proxyH.Stats
+	}
+
 	//exhaustruct:enforce
 	res.docdbH, err = handler.New(&handler.NewOpts{
 		PostgreSQLURL: opts.PostgreSQLURL,
@@ -121,12 +177,22 @@ func Setup(ctx context.Context, opts *SetupOpts) *SetupResult {
 		TCPHost: opts.TCPAddr,
 
 		ReplSetName: opts.ReplSetName,
+		ReadOnly:    opts.ReadOnly,
 
 		L:             logging.WithName(opts.Logger, "documentdb"),
 		Metrics:       opts.Metrics,
 		StateProvider: opts.StateProvider,
 
 		SessionCleanupInterval: opts.SessionCleanupInterval,
+		TTLMonitorInterval:     opts.TTLMonitorInterval,
+		TTLMonitorBatchSize:    opts.TTLMonitorBatchSize,
+		DefaultMaxTime:         opts.DefaultMaxTime,
+		SlowOpThreshold:        opts.SlowOpThreshold,
+		MaxRequests:            opts.MaxRequests,
+		MaxUserRequests:        opts.MaxUserRequests,
+		AdmissionMaxWait:       opts.AdmissionMaxWait,
+
+		ProxyStats: proxyStats,
 	})
 	if err != nil {
 		opts.Logger.LogAttrs(ctx, logging.LevelDPanic, "Failed to construct DocumentDB handler", logging.Error(err))
@@ -135,17 +201,27 @@ func Setup(ctx context.Context, opts *SetupOpts) *SetupResult {
 		return nil
 	}
 
-	if opts.ProxyAddr != "" {
+	docdbH := res.docdbH
+
+	// In proxy mode, DocumentDB handler is only used for authenticating clients against FerretDB
+	// when the proxy handler authenticates upstream connections itself.
+	if opts.Mode == middleware.ProxyMode {
+		if !opts.Auth || (opts.ProxyUsername == "" && opts.ProxyUserMapFile == "") {
+			docdbH = nil
+		}
+	}
+
+	var router *middleware.Router
+
+	if opts.Mode == middleware.RoutingMode {
 		//exhaustruct:enforce
-		res.proxyH, err = proxy.New(&proxy.NewOpts{
-			Addr:        opts.ProxyAddr,
-			TLSCertFile: opts.ProxyTLSCertFile,
-			TLSKeyFile:  opts.ProxyTLSKeyFile,
-			TLSCAFile:   opts.ProxyTLSCAFile,
-			L:           logging.WithName(opts.Logger, "proxy"),
+		router, err = middleware.NewRouter(&middleware.RouterOpts{
+			File:           opts.RoutingFile,
+			ReloadInterval: opts.RoutingReloadInterval,
+			L:              logging.WithName(opts.Logger, "routing"),
 		})
 		if err != nil {
-			opts.Logger.LogAttrs(ctx, logging.LevelDPanic, "Failed to construct proxy handler", logging.Error(err))
+			opts.Logger.LogAttrs(ctx, logging.LevelDPanic, "Failed to construct router", logging.Error(err))
 			res.Run(exitCtx)
 
 			return nil
@@ -155,16 +231,18 @@ func Setup(ctx context.Context, opts *SetupOpts) *SetupResult {
 	//exhaustruct:enforce
 	res.m = middleware.New(&middleware.NewOpts{
 		Mode:    opts.Mode,
-		DocDB:   res.docdbH,
+		DocDB:   docdbH,
 		Proxy:   res.proxyH,
+		Router:  router,
 		Metrics: opts.Metrics,
 		L:       logging.WithName(opts.Logger, "middleware"),
 	})
 
 	//exhaustruct:enforce
 	res.WireListener, err = clientconn.Listen(&clientconn.ListenerOpts{
-		M:      res.m,
-		Logger: opts.Logger,
+		M:       res.m,
+		Metrics: opts.Metrics,
+		Logger:  opts.Logger,
 
 		TCP:  opts.TCPAddr,
 		Unix: opts.UnixAddr,
@@ -174,6 +252,8 @@ func Setup(ctx context.Context, opts *SetupOpts) *SetupResult {
 		TLSKeyFile:  opts.TLSKeyFile,
 		TLSCAFile:   opts.TLSCAFile,
 
+		MaxConnections: opts.MaxConnections,
+
 		Mode:             opts.Mode,
 		ProxyAddr:        opts.ProxyAddr,
 		ProxyTLSCertFile: opts.ProxyTLSCertFile,
@@ -297,7 +377,8 @@ func (sr *SetupResult) runListeners(ctx context.Context) {
 func (sr *SetupResult) runHandlers(ctx context.Context) {
 	var wg sync.WaitGroup
 
-	{
+	// middleware is not created if Setup exits early
+	if sr.m != nil {
 		wg.Add(1)
 
 		go func() {